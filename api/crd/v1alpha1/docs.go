@@ -0,0 +1,5 @@
+// Package v1alpha1 contains the Kubernetes custom resources of DCM.
+// +groupName=dcm.io
+package v1alpha1
+
+//go:generate go run sigs.k8s.io/controller-tools/cmd/controller-gen@v0.17.3 crd paths=./... output:crd:dir=../../../deploy/crds
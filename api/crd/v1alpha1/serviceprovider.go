@@ -0,0 +1,107 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	// GroupVersion of the custom resources
+	GroupVersion = schema.GroupVersion{Group: "dcm.io", Version: "v1alpha1"}
+
+	// ServiceProviderResource is the resource of the ServiceProvider kind
+	ServiceProviderResource = GroupVersion.WithResource("serviceproviders")
+)
+
+const (
+	ServiceProviderKind     = "ServiceProvider"
+	ServiceProviderListKind = "ServiceProviderList"
+)
+
+// Condition types of a ServiceProvider
+const (
+	// ConditionRegistered is true when all the resource kinds are registered
+	ConditionRegistered = "Registered"
+	// ConditionEndpointReachable is true when the endpoint passed the check
+	// done on registration
+	ConditionEndpointReachable = "EndpointReachable"
+)
+
+// ServiceProvider declares a provider registered in DCM for each of its
+// resource kinds
+//
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=sp
+// +kubebuilder:printcolumn:name="Service ID",type=string,JSONPath=`.spec.serviceId`
+// +kubebuilder:printcolumn:name="Endpoint",type=string,JSONPath=`.spec.endpoint`
+// +kubebuilder:printcolumn:name="Registered",type=string,JSONPath=`.status.conditions[?(@.type=="Registered")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type ServiceProvider struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServiceProviderSpec `json:"spec"`
+	// +optional
+	Status ServiceProviderStatus `json:"status,omitempty"`
+}
+
+// ServiceProviderSpec is the registration of the provider
+type ServiceProviderSpec struct {
+	// ServiceID is the UUID of the provider. When the provider has several
+	// resource kinds each one is registered with a service ID derived from it.
+	// +kubebuilder:validation:Format=uuid
+	ServiceID string `json:"serviceId"`
+	// ResourceKinds are the kinds of resources the provider serves
+	// +kubebuilder:validation:MinItems=1
+	ResourceKinds []string `json:"resourceKinds"`
+	// Endpoint is the URL of the provider API
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Endpoint string                  `json:"endpoint"`
+	Metadata ServiceProviderMetadata `json:"metadata"`
+	// Operations are the operations supported by the provider
+	// +kubebuilder:validation:MinItems=1
+	Operations []string `json:"operations"`
+}
+
+// ServiceProviderMetadata is the placement and the constraints of the
+// provider
+type ServiceProviderMetadata struct {
+	// +kubebuilder:validation:MinLength=1
+	Zone string `json:"zone"`
+	// +kubebuilder:validation:MinLength=1
+	Region string `json:"region"`
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// +optional
+	ResourceConstraints map[string]string `json:"resourceConstraints,omitempty"`
+}
+
+// ServiceProviderStatus is the state of the registrations of the provider
+type ServiceProviderStatus struct {
+	// ObservedGeneration is the generation of the spec last reconciled
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Registrations are the registrations of the provider in DCM
+	// +optional
+	Registrations []ServiceProviderRegistration `json:"registrations,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ServiceProviderRegistration is the registration of a resource kind
+type ServiceProviderRegistration struct {
+	ResourceKind string `json:"resourceKind"`
+	ServiceID    string `json:"serviceId"`
+}
+
+// ServiceProviderList is a list of ServiceProvider
+//
+// +kubebuilder:object:root=true
+type ServiceProviderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServiceProvider `json:"items"`
+}
@@ -0,0 +1,27 @@
+// Package v1alpha1 contains the contract of the API of the DCM providers.
+package v1alpha1
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Spec is the OpenAPI document of the provider contract
+//
+//go:embed openapi.yaml
+var Spec []byte
+
+// GetContract returns the validated OpenAPI document of the provider contract
+func GetContract() (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(Spec)
+	if err != nil {
+		return nil, err
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
@@ -18,41 +18,83 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xaeVPjOBb/KirtVvVMVYITcJju/MeQ1Ey6gWaBzNRsV1dKsV8SNbakluRAluK7b8m3",
-	"Y8UkHDPMLv+AbR3v+r1DT7nDHg8FZ8C0wv07rLwFhCR+PCaaBHz+G4Ub8yokFyA1hXjQSwYnVEMYf8gf",
-	"qvPIktCATAOYCMmX1AdZna1XAnAfKy0pm+P7VvaBSElW5t2nSgRkNWEkBOuCjQMSFI+kB5NrynzLjIIY",
-	"n34DT9uoa65JYNb6oDxJhaac4T6+Mp8Ri8IpSMRnKNUGSsTKt6FMwxykndRQSi7dTseiW+5DnWY8H8Vj",
-	"LQy3JBQB4L7b6dTJtTCYyfU9RmxJAuojykSkCz5LGpHwPaISfNz/km7ydTPv7lN5d3fg/SI1J2JcoxmP",
-	"mP9UAXpPVX5vR+VrkIwESIFcUg9QMu/xQpynHmXxOkF/5UrXeTBf0fjiBM24RHoBKPNKdHQ+KouGF1qL",
-	"vuME3CPBgivdf99536kz26oSWKd3GYUhkSvjI5ep1DnXZWqfoin8RqVG5k9EAnRKvAVlYFtV4wCYLzhl",
-	"FnGH6Yihb6Rt5MFZdp1laCNA/frWY0a/R4CoD0zTGQWZq7SRSHf/ANze4U9teP9h2u7u+wdt4vYO2+7+",
-	"4WHX7f7kdjpWLWdRrsrEGQlhK9muoyksqdS2rQ1wiNlQ1Ql8zseQADnjMgQfcVaFTq7/EsUv+JfhFW7h",
-	"83H89/Ol+TcYngyvhgbL28f/5L0WgFeiUXAWhYaHZYKmSZigCbewTzSZEmUePc40oQxi5ypUVV/T7J+x",
-	"ZWKMpDOrLtHKnbEE1IrSa67dwrdtAqKdJTDc1zKCksOf0MS3q07P4FZPBJnDRPNrYLasdQ0shqkELSks",
-	"KZvHGjQrkVlpVCpBRYFWFfjA6qP49/HocPRtuDrdH3fOrv44OPl97H7+faRPrz5en666i7PBeP/k6l+r",
-	"s29/3J4Nhgdng6Ob0+OPH2yQs9cC/5Qww338D6coSZy0HnFy49Ygct8QGU9BE2PxurICMoUgiZW+T42C",
-	"SHBemdEc6PBxpDQPUbqPhQcJc2tIHIAI+CoEplE6pazpSLWBKKuf5vWMx5nSktC0ZHukAHk+LW9nkeM/",
-	"nEGjFPGEsgxG4x6YbLdBljUPSjdItWHLdBcwp0qDBH9zzitXpHV+j5TiHiUa/Eqxtls6ObdEvNrysIS5",
-	"bRCdY/SBUHwZCcGlEaA0a5dIKnMlToi21VdmONkYaRqC0iQUuIVN1DcLjF2hbUYa0ZlV2xvQlobI2vq0",
-	"KJrYcm0W4Ytka91BEx2pBqulEyxLI2Fks+vlhCiNkgk7q+V+I5YTPV/A9whssXwHDJpyzla5ZTHWFG5d",
-	"hwjqzGgArwCxpRrh+GJ4dDXELXwxPBo8skBoAk5apKkaftAP4/Fo8GNFb71eB967nU4b9j9M227Xd9vk",
-	"p+5h23UPD3s911RmnQdjWYmbSr7Pddyc+mv4UIIzBXWAhKAUmVsC82UMcpSNWz31/zMOVATLY0Fhf+Jp",
-	"utzNiVf25oi9vFk34OOdrmbBbU1TKKCJtfVM2pghHzhOPCE9PRJvj+jyVDHXAKiX6yAVkNmme2Q+UTbj",
-	"ScOCaeIZjdVKvMHxae10lB70A+pBGliSoyU+EsRbANrfM0EukkGaSVTfcW5ubvZIPLzH5dxJ1yrnZHQ8",
-	"PLsctvf3OnsLHQaxvFTH3rSB7hKkSphbdkkgFqSbYoYRQXEfH+x1YgYE0YtY5Q7xQ8qcFJPmyxwsUevI",
-	"zCpyouZoSeEmj/xZzXdD9QLlPcmK1nPcjnzcx7+ATvufcWWahOGYof2ka2TUDokLECEC6sVrnW8qqfkT",
-	"933Iucst1tioa6fvT0Y5vWckmPe9LNQq/SlTYMSNJzNPJV2cRC3rOo1npFZKI8xqVzORIECF/zebJQu9",
-	"L2mXSnj/2xkmt0JsmQWQQC82WuTXeBh5C/Cu495AQ9Co2uKEKp2s3mCKus4qLJcpJ6xW8qaVW0MzRouN",
-	"S5W494wGGiSarrIao872eQlggkgSgo6pfrnD1JD5HkEMsDQ0pvsUllzPA19fEIuVps9GLLrPjUXXjsWf",
-	"iY9kemp5ZS6QY6OA0X0LC2sf/FiCOc8RxEpJQhQNxCpkktml/mKqgZ+5v3p2OyfiF2eKtPe3hq/uC9F9",
-	"w5YVWzleas3mauBy7rLHkX+fwC4Abe2fme+IPAy+ZGYJfLaAZcqlIl4VPOB1JJWjWF5gRxG1XabVo5r7",
-	"p6DujKOMxl+HPvcZhc2vS/9eoK9htNyEt6ZnU4XUIY2mRCXXRlHSjxkNbIXd64B4538/sL5BOwWqKOFZ",
-	"RBY8j5O2L2EIbqnSlM0fjtdHQgSrvwzLb3XJm/u8vPtkfrGhHMp6cs5d9vSJMv8+L5MePt7ZmgHx6ZQg",
-	"JcCjM+ohudZKrh/z6teGlgNfQ38a/QB7870WejejAbxroXf5TwbMyzJ89yNu2Ry6LHWjSz93OtrqKt1y",
-	"mVq/VH87CjQeM63Nqo2nzUzjttpoJ1BnG21OL68Ozc+fkGwXqFvlps4LsZDe0VnwVL1yijwPlJpFwZsn",
-	"xZ7U4Bbb5ZGtT9sXEPJlyenKN2G7eeCYyaf44DN7WGvTDelokP06rlSnPr703LIzsH79n+E9WKGIFSHz",
-	"zyyi8g528avh11ZL5ZpZO5E0nbALsNbyEJqucncaDRBh/gOQzu9UKiXBG6pfIGNUi67NRdabbxTHdAvE",
-	"k+2SdQk4k/tqB99/vf9vAAAA//8o3UFf0jIAAA==",
+	"H4sIAAAAAAAC/+xc+XLjNpp/FRR2qzKzS1m2W91JvH95bG+ixHZ7fXRqNupyQeQnCTEJMAAot6bL7z6F",
+	"gwdISJa75SOJK1VpiweO7z5+xGcc8yznDJiSeO8zngFJQJg/jy7JVP+bgIwFzRXlDO/hI6aoWiBFpohP",
+	"kJoBigshgCk0ByEpZ+VlAZIXIgYcYRnPICN6LLXIAe9hqQRlU3x3dxfhnAiSgXKTDhPIcq6AxYufYdGd",
+	"/iCleq4pMBBEQYJuYIHUjCiUkRuQbubfC5AKSTIBpDgSoMRiC+0jAXlKFiN2S9XMPClJBmYEAaoQzL7O",
+	"BZ1SRlK9g5wzCVsjhiNM9fSWPDjCjGR6I43l9vR6V+01wsPJCVHxrLur9yxdIJLn6cLbAPUJDA2il9RF",
+	"mR4R5NIFTnp2zvtWdsoZLFnduSEOerM9QKdcoROe0AmFZEPL0xOvtcarPCEKToi8CYgFzzKCJOTESkVK",
+	"pdJrmVBIE6mFoDBvR4gLNML/NcJowgUiaYqyQpFxCu5RHGH4RLI81ZM35oh4rgWOclbt5fcCxKLeip3g",
+	"OtPru0fk7U0j7/ua6Qczwqagf+ZCz6MomJskttv7jIEVGd77FccCiAIcudlwhBNIwV5hsRkmwR+j9qQR",
+	"drvrEO5/HYG0BiV0MgGBxqBuAZjhYwKSCkgQYYn5TWJVkBRJZSenCjIZ2GS1ACIEWejfN5QlzY2cCT6n",
+	"VhTOYUqlssTFET4giqR8OlSQBXdiyd3exzABprRYilIE+fg3iFVk/h4e6qu5m1Oa7YjGtLLaoB5dPxvb",
+	"VSC7wyggjlpJNW30bszu3NKikm314u1S9OINu8+tenf5nRFGJyCtJa5o+58CJngP/0e/ttR9J0H9E/dG",
+	"l+StFdZDr1iWLNLAqqxYrb+mpkgHJCERi2tRsC4Lf5mBmoGwNsXOiW5BAOLaOuYpYQySmhVjzlMgrLPT",
+	"cvyoWnhoxw0pu8ghDuve3AnahBjCKFFAd/rI30ZAFxIqtd+5LiW3NjAXmbZAH07w2nJ+xejvRS2mhlYN",
+	"UfXM1zzrST1BaPTSQF9Xitl4LfSCgixPtdZr2iQJ1esh6ZlHs+7OvbWfVZ6+7SYksoYtQRPBs5W7+ozj",
+	"vMB7eAdHmGZEm038e0EWW5T3Y84UoQxEQuWN7E8g4YLs6VVLhSOcQcbFAu/h3R8ovusIRUuOnDJ7zGvT",
+	"bYVkfaBwG9Ale/O6UqTqj5b0zQlNtVu6royW9/S99rYtc0vF637J6Jq+zp7bsyuuSNoV3kt9GbEiG1sz",
+	"vcTIUqZgCiI81ZEQXATCUn0ZAZtDynNw8RwkaLwwTr723lvoUksXTwBRiYh2ZeMUohHLSDyjDHoCSKIv",
+	"IdpxKWAmiTmz4m/jwhaDeRLQ2pP22OVICXga+2H/eHi4fzl8f3p9dH7+/jykiAkoQtOAK9+vtBJRNuEi",
+	"MxtGZMwLVS+/6bXDeuyZueU8zkBKMg1s9sciIwxVW23c9OjobZyyOUlpgoAlOadMhU2WcZvXNFnH/bun",
+	"IxPnxVwISC09TPAvQcxBoJRP73fujkvlfkM6/yOQVM0OZhDfdHU5KazoXWcBph26m5Ux12MgylBG05RK",
+	"0MKml2j5aZXj3SCgKxGGsGacA5H1+BNC00KAN50f81KSIhXnaGd7S//3du/t4M3unpZ6BrGq/tBrFjAp",
+	"JCRdCkZYKqKKe0MFS7cL+2yb7m6I5fQ+h5yLYMAC8Y1c5ajuX5PlZceF2RhJUw9IPDNCThlIaQkZaXNT",
+	"DYkDC38KslxUc5TBNr8x+UHlVIJx9TGfHsMc0i490/Jyy6hRRrMiQ+Z2KVDanANTgurgTVClgGmi6Fta",
+	"56ipB5TrSmBcTE06NeE4wrdEMFzK8UffPJgHVuupXWaILlWU3FU/l9+YhEbvgTCXOFg/IXOIzWVUJixo",
+	"RmyZwKWWfDJi5T2bbNTvNDMb/x3vlg5AdQIyYv7rjRi19XYreg05IpLTD7Yg05SEJM50oDTfIWk+IztB",
+	"QdhcqiZdZP0AP9PiaWMXUZllmVFDbK4W2g2ncvojD/FfX0VX58fGS2gal+EW2j8benZxplS+1++nPCbp",
+	"jEu19932d9th/+xlA95PfFFkGRELzcMLqw6oQd56tp+LMXygQiH9P51xuwgi9FZnBZUTDRTv7J1SW1eu",
+	"oT/f6YdzAV3p+YrKYB6abYR3Rjg0GU2WZkGNCK3k38od7ey+gcHbd9/24Lvvx72d3eRNjwzevusNdt+9",
+	"2xnsfDvY3t5ePxU7beRgK6e9KcYwpyIY1DTKSt2qYHUP5SB0BAAJ4syjYTNiqmb8Ff9wdIkjfHZl/v/+",
+	"Qv9zeHR8dHmEPzbiv3uzCPu7E8Yv8pUbd3ZjbkX32gXWOMIJUWRMpP6zStV8Q999Z7XNd1kZrWIQX/+i",
+	"SvMbWuERvWNHIvypRyDvVeVrY6ca1uWYhko3DD6p65xM4VrxG2Ch3OcGmBFTAUpQmFM2NRTUbyL9piap",
+	"MPGFX4WExU/5/x8M3w1/O1qc7F5tn17+883xL1eD978M1cnlTzcni53Z6eHV7vHl/y1Of/vnp9PDozen",
+	"h/u3Jwc/fR8SuXBGuSoeqZgbqjItNcMnoIjmeCCgIGNI5VdUEg4KqXiG3DiBNejiXsj+HkKe8kWmjZJ7",
+	"pEnpQvaASLWyXhJzpj0gZeprNnDuhkPN4QL7+BdnsHIX5gEvfieKxMAUiCV7aWmQG8BR4+Ma/DwLdwnO",
+	"iFA6d6gsU+aej0wG7oIXIgDx3FKsE7N8gVys4PzmebiUPWuUSEoq3kO9tj1dn3hfHONsMoLZbCyyZuDR",
+	"6p7Z1ojuTlFV9p86jap1I41h5vpDEcoKqRDjCo3LOZKNBQuPGhd8rb9/KA1C4m8zBxCQLI/Sm8XRQHlL",
+	"Sh5TPWO7NvwAoTsLkGfT4a3wk6TO8FnDL67jdSs/eo9YXBR5zoWmj9+rXJv7ouLRNVGhQlIzm6UZSEWy",
+	"vFmb0vLQ03fW6jgs8YgujOu878oHwfJfqVF1QrC6JrVEKNwDgVedrAfpckykKo3OQ8myXFUsne/xFk1R",
+	"W99TPEA9tN94ekP8pRpiqfX4arJSkB/uMVZJ9uZsr93q0t7zw4WiUxspfY8ujez0SU77E5rCCzCBjcT4",
+	"4Pxo//JIl7KO9g+/MCtexS9XmZAdg4T+dnU1PPy7R7e3b7fhu8H2dg92vx/3BjvJoEe+3XnXGwzevXv7",
+	"djDYDtYj2vXgejVeklvReHW+25EPi3kKCMiaXrFpkxCZKNfRrwArAWFY0kiyhWxU3g/6lL+mx/I2Vnmt",
+	"WrAceOHB1iEMhdiAv9icwm/MTlfs07f92JlKVEuWTptasIqHMnsNo7Bax/2tRJvT+UUYphAuEfnPfA1L",
+	"O3q7rkLWorpqae1EYmWCcE/q9RXh8xdamS/AW/jCt8KMPB6WoxaZdXAc+pJp6u19tqXgWFOsUyY7PDjp",
+	"ZMuuapHSGJyfcgDM/ZzEM0C7W9pnFiJ1gYnc6/dvb2+3iLm9xcW0796V/ePhwdHpxVFvd2t7a6ay1OyX",
+	"KovNCs87L5tquGqiWZlhJKd4D7/RXXMc4ZyomSF5nyQZZX0TFuvfebBIcw4xZzFNXYRcUTMK4BQ92Ayq",
+	"wMxTOgemcSwO6beFTDxeYyF9mKdOEUrUFRdlRLmF3ttnR6zRvrUxvX4jI+IGEkQkyggjU0iq25FZCS+U",
+	"Be+58jYVI2bLev9jV5qLgkFUvV2uLKNS6lcq/Fe1DT3tiFmYa4JuZzSt91Ouf7xA3GAHMyDMvIFSmChU",
+	"MMWLeAbJVt2kDaI/BSADQHHg4RtoYkhKuJreS2k5ShyRwynqATQVDCyaIYL0ZlJAShAmLR40QqREX4xY",
+	"CmTuWC0VFw1CVDBe29StjNEwMSKep4uymy2xD57/tStTORfKx1M6FhmOORZlSwDNNZCyBjNXWMgJSSWE",
+	"sJjdWrVmXMnSNXm+ZEVGdh62no8VbugfPFmU5gas6Tcciw15+79JW+2sx74X41rmUne+u3b9ovLrAWME",
+	"dre3Nz23AeuaqVu9kVIkrTgaxLsDzyIdkaNELJDm612EBxtclgXmBRY0dNguUZJLz/v948+7X0I50K02",
+	"V+VXC9rO2vpDavzbYHf36WhQS/hdhN8+DfUVCI0JdJA3i64xsz/BvrsJQsGEhk4ZGJJ+Xtp+QmncmhS6",
+	"i0rn6TyeXsYUVAj6mFFWz6E4mlO4rQJu97p1QBUKygtZfDv7AyiHbcGPqMhNpHCAdu9/fm4h8djzA6g2",
+	"QZssSvm0wok9hEfNeqDmkYcmqyFjHf5UcLVHZFA1xxLudOhTIuAsJXSvvViLEtY9+6+3aICI0lbbZRE+",
+	"OS5a5Ni8v/Mp8XS+bhUHjitKldXQp/ZoDVZ7onDRFYVaUVzkuXiooujqfqMqsdJ4ldn9YyqHV0H4Y5mv",
+	"igWGLTODml3KDguqdcBs3UJfkZT6jNBIIfv2Ej7cY1GaM9ulpnQO/1q6UhvvuyzPZZExSGnqWQVjlE2b",
+	"SRrENzr6pkqiBHJgCbCYQlCejnVqCVI+pjx5mO4Aky/9DRFNixbBymU2SeaVsoJk02wy2hVirMuvJzRV",
+	"+tPIRVns7XL6rKGQrbQslMu4cZZ/J/rxEWntYdmW6u6T2NJ/EC8zeDH2opKKWoDuoiXFmwNThkAEsUbQ",
+	"2UC5+sJin26AP1rSEtpS/Ui/9YX8Y2W41frW8vg7jzTvSxHLJ0lYK2dCUgEkWSD4RKWSJoMn5WqQf3gB",
+	"raXBHGRAJaJMy95UgJRPlt9ezrpL0Vl3ISGxKyau7mihly9C358tB6ZyRRpcGZO2K2r5s/7n8s9hcmdt",
+	"kjkJYG9JBY7cb5nsk8stk/FjurDdLMmVa8BtK9F0blUrpChoCLXQdXaDJ7EopxyVczybZRn8dZ1sRzSb",
+	"SPNgsKbD+K4kI/1VgSlvFrbVOjwMhbHPIdnR/Q69cfbKk0R9S1xrFDr8JzSee6xvnjFjvbFC3NYtVVVd",
+	"v2bwV618hlSZ1F7CnNEUhCFeOdCj921iQDtTKpVtjDUOydlCv8x0v7JxSTtGnlGlH26AGXMBErTbtBDn",
+	"MU9sL7JsWY6YCT8y/yQfRN2xNfprpZgwHzln23fux4g1oR+tRqCJXs6uLkP9OAM4fJlWpXFa0lo2qGl/",
+	"1skmMhBT6BnB+O8vM0MOrfm0BcXHs4F/WjNVxa9agya8YLbQuvMkoTNUp22Y5jkkz20pX2bS4ADh6aIE",
+	"XYfShyXtCGfFCbP5pq4Jti24tdX6kfI8OWQVwKSmbMTM2W/G7npWmkoki7IP3YSES3uoUwWZ0BDxEVuG",
+	"EW9/OGyNN62PJTM23uBEai+g7xnI9Bg8nAUDh93QFjlC7rMp+/19Rewm1hqyXC0iMx5xnZqkeq2cwRaR",
+	"F0tBGy819Hyo2X/MItKrlX85weireX9J5v0qT1bVhEy1cEVfqGDlwajecTWy1WKOkCziGXLnm5Qf6zcO",
+	"/IlGzGLnyj4TuA/WqwNxKhpYE1s33OwbVElIJ4jIkQnHzbodpI8aEshZoYwHSvgtCxlT09x0u3jublS5",
+	"OyrdThQ312rgoJWnN8+1qprGgZV5AnbuS0YpVhaZ3P9c/vUzZcldVYK8v6MW6lfbg1fNMTd0QuP6qNil",
+	"nbXut6NrQB8bg6K/wdZ0K0Lf6M+RvonQN9XhE/rHPPvm7zgKueHmrlc64k237tY6lKFLlcDxDC+phfIU",
+	"fYgPNnd/Of6k21MM6cTy1mLJ5VBZ5UGKVA60PBB9SRr0gNA1eikd09Bnlk8c9wa/5AsIq//9WBHHIOWk",
+	"SP94gfETdGUPOJukNHaN12aW7H99/XVN2mcMt5/HMBuCqde28aZShBWOYr1obu1+8jlkfF6P7n/w+yCf",
+	"dMXE13ilzfuc8De69vT41sl1X162WbP33VpLZaR1jY/VQQT+C5aeXyPJWnNanbpVTfNaKTsRqEY2ylrc",
+	"bZa/SnUrkLGXgPwFtfcF9fdDGeFrp//Vjr1gO6YtU8AcPRxzEI5GwsCDETPdrC8GHoyYByLoAA/qr/E9",
+	"Q2o+8K+RCPUoy4AFrwb2jw516B7l9SwFgcd1Ca/Ahz9Xqu4Vh8qChl1DiY3SD75m4evhMJoOyc5gN2Lt",
+	"tz2npI/vPt79ewCHGydfi3EAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -7,6 +7,47 @@ import (
 	"time"
 )
 
+// Defines values for ApplyChangeAction.
+const (
+	Create    ApplyChangeAction = "create"
+	Delete    ApplyChangeAction = "delete"
+	Unchanged ApplyChangeAction = "unchanged"
+	Update    ApplyChangeAction = "update"
+)
+
+// Defines values for ApplyChangeKind.
+const (
+	ApplyChangeKindCatalogItem  ApplyChangeKind = "CatalogItem"
+	ApplyChangeKindProvider     ApplyChangeKind = "Provider"
+	ApplyChangeKindRegistration ApplyChangeKind = "Registration"
+)
+
+// Defines values for HealthStatus.
+const (
+	Ok          HealthStatus = "ok"
+	Unavailable HealthStatus = "unavailable"
+)
+
+// Defines values for LogLevelLevel.
+const (
+	LogLevelLevelDebug LogLevelLevel = "debug"
+	LogLevelLevelError LogLevelLevel = "error"
+	LogLevelLevelInfo  LogLevelLevel = "info"
+	LogLevelLevelWarn  LogLevelLevel = "warn"
+)
+
+// Defines values for ManifestApiVersion.
+const (
+	DcmIov1alpha1 ManifestApiVersion = "dcm.io/v1alpha1"
+)
+
+// Defines values for ManifestKind.
+const (
+	ManifestKindCatalogItem  ManifestKind = "CatalogItem"
+	ManifestKindProvider     ManifestKind = "Provider"
+	ManifestKindRegistration ManifestKind = "Registration"
+)
+
 // Defines values for ProviderType.
 const (
 	Container      ProviderType = "container"
@@ -14,6 +55,51 @@ const (
 	VirtualMachine ProviderType = "virtual_machine"
 )
 
+// ApplyChange defines model for ApplyChange.
+type ApplyChange struct {
+	Action ApplyChangeAction `json:"action"`
+
+	// Fields Fields that differ between the desired and the actual state
+	Fields *[]string       `json:"fields,omitempty"`
+	Kind   ApplyChangeKind `json:"kind"`
+
+	// Name Identifier of the object, the ID of providers and registrations and the name of catalog items
+	Name string `json:"name"`
+}
+
+// ApplyChangeAction defines model for ApplyChange.Action.
+type ApplyChangeAction string
+
+// ApplyChangeKind defines model for ApplyChange.Kind.
+type ApplyChangeKind string
+
+// ApplyRequest defines model for ApplyRequest.
+type ApplyRequest struct {
+	Manifests []Manifest `json:"manifests"`
+}
+
+// ApplyResult defines model for ApplyResult.
+type ApplyResult struct {
+	Changes []ApplyChange `json:"changes"`
+
+	// DryRun Whether the changes were only planned
+	DryRun bool `json:"dry_run"`
+}
+
+// CatalogItemSpec defines model for CatalogItemSpec.
+type CatalogItemSpec struct {
+	Active      *bool   `json:"active,omitempty"`
+	Description *string `json:"description,omitempty"`
+	DisplayName string  `json:"display_name"`
+
+	// Name Unique name of the catalog item
+	Name         string `json:"name"`
+	ResourceKind string `json:"resource_kind"`
+
+	// Template Parameters of the resources created from the catalog item
+	Template *map[string]string `json:"template,omitempty"`
+}
+
 // CatalogView defines model for CatalogView.
 type CatalogView struct {
 	CatalogItems *[]struct {
@@ -27,33 +113,66 @@ type CatalogView struct {
 	Total *int `json:"total,omitempty"`
 }
 
-// Error400 defines model for Error400.
-type Error400 struct {
-	// Code Error code
-	Code *int `json:"code,omitempty"`
+// Error Error envelope returned by all operations. The code is a stable,
+// machine-readable identifier of the error condition.
+type Error struct {
+	// Code Machine-readable error code
+	Code string `json:"code"`
+
+	// Details Additional information about the error
+	Details *[]map[string]interface{} `json:"details,omitempty"`
+
+	// Message Human readable description of the error
+	Message string `json:"message"`
+
+	// RequestId Identifier of the request, for correlation with server logs
+	RequestId *string `json:"request_id,omitempty"`
+}
+
+// HealthCheck defines model for HealthCheck.
+type HealthCheck struct {
+	// DurationMs Duration of the check in milliseconds
+	DurationMs *int64 `json:"duration_ms,omitempty"`
 
-	// Error Invalid input
-	Error string `json:"error"`
+	// Error Reason of the failure of the check
+	Error  *string      `json:"error,omitempty"`
+	Status HealthStatus `json:"status"`
 }
 
-// Error404 defines model for Error404.
-type Error404 struct {
-	// Code Error code
-	Code *int `json:"code,omitempty"`
+// HealthReport defines model for HealthReport.
+type HealthReport struct {
+	// Checks Result of each readiness check, by component
+	Checks *map[string]HealthCheck `json:"checks,omitempty"`
+	Status HealthStatus            `json:"status"`
+}
+
+// HealthStatus defines model for HealthStatus.
+type HealthStatus string
 
-	// Error Resource not found
-	Error string `json:"error"`
+// LogLevel defines model for LogLevel.
+type LogLevel struct {
+	// Level Minimum level of the log entries written by the service
+	Level LogLevelLevel `json:"level"`
 }
 
-// Error500 defines model for Error500.
-type Error500 struct {
-	// Code Error code
-	Code *int `json:"code,omitempty"`
+// LogLevelLevel Minimum level of the log entries written by the service
+type LogLevelLevel string
 
-	// Error Internal service error
-	Error string `json:"error"`
+// Manifest Desired state of an object. The spec of a Provider has the fields of
+// Provider, the spec of a Registration the fields of RegistrationSpec and
+// the spec of a CatalogItem the fields of CatalogItemSpec.
+type Manifest struct {
+	ApiVersion ManifestApiVersion     `json:"apiVersion"`
+	Kind       ManifestKind           `json:"kind"`
+	Spec       map[string]interface{} `json:"spec"`
 }
 
+// ManifestApiVersion defines model for Manifest.ApiVersion.
+type ManifestApiVersion string
+
+// ManifestKind defines model for Manifest.Kind.
+type ManifestKind string
+
 // Provider defines model for Provider.
 type Provider struct {
 	// ApiHost Host URL for the provider API
@@ -65,6 +184,9 @@ type Provider struct {
 	// Endpoint Endpoint of the Service Provider
 	Endpoint string `json:"endpoint"`
 
+	// Etag Entity tag of the current version of the provider
+	Etag *string `json:"etag,omitempty"`
+
 	// Id Unique identifier for the Service Provider
 	Id string `json:"id"`
 
@@ -103,13 +225,51 @@ type ProviderMetadata struct {
 	Zone string `json:"zone"`
 }
 
+// ProviderMetadataPatch Partial provider metadata, all fields are optional
+type ProviderMetadataPatch struct {
+	Labels              *map[string]string `json:"labels,omitempty"`
+	Region              *string            `json:"region,omitempty"`
+	ResourceConstraints *map[string]string `json:"resource_constraints,omitempty"`
+	Zone                *string            `json:"zone,omitempty"`
+}
+
+// ProviderPatch Partial Service Provider, all fields are optional
+type ProviderPatch struct {
+	// ApiHost Host URL for the provider API
+	ApiHost *string `json:"apiHost,omitempty"`
+
+	// Description Summary of Service Provider
+	Description *string `json:"description,omitempty"`
+
+	// Endpoint Endpoint of the Service Provider
+	Endpoint *string `json:"endpoint,omitempty"`
+
+	// Etag Only apply the update if it matches the current etag
+	Etag *string `json:"etag,omitempty"`
+
+	// Id Immutable, must not be updated
+	Id *string `json:"id,omitempty"`
+
+	// Name Name of the Service Provider
+	Name *string `json:"name,omitempty"`
+
+	// Operations Operations performed on the provider endpoint
+	Operations *[]string `json:"operations,omitempty"`
+
+	// Type Immutable, must not be updated
+	Type *string `json:"type,omitempty"`
+}
+
 // RegisteredProvider defines model for RegisteredProvider.
 type RegisteredProvider struct {
 	// CatalogItem Associated catalog item
 	CatalogItem *string `json:"catalog_item,omitempty"`
 
 	// Endpoint Provider endpoint
-	Endpoint *string           `json:"endpoint,omitempty"`
+	Endpoint *string `json:"endpoint,omitempty"`
+
+	// Etag Entity tag of the current version of the registration
+	Etag     *string           `json:"etag,omitempty"`
 	Metadata *ProviderMetadata `json:"metadata,omitempty"`
 
 	// Operations Supported operations
@@ -131,6 +291,27 @@ type RegisteredProvider struct {
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
 
+// RegistrationPatch Partial registration, all fields are optional
+type RegistrationPatch struct {
+	// Endpoint Provider endpoint URL
+	Endpoint *string `json:"endpoint,omitempty"`
+
+	// Etag Only apply the update if it matches the current etag
+	Etag *string `json:"etag,omitempty"`
+
+	// Metadata Partial provider metadata, all fields are optional
+	Metadata *ProviderMetadataPatch `json:"metadata,omitempty"`
+
+	// Operations Supported operations
+	Operations *[]string `json:"operations,omitempty"`
+
+	// ResourceKind Immutable, must not be updated
+	ResourceKind *string `json:"resource_kind,omitempty"`
+
+	// ServiceId Immutable, must not be updated
+	ServiceId *string `json:"service_id,omitempty"`
+}
+
 // RegistrationRequest defines model for RegistrationRequest.
 type RegistrationRequest struct {
 	// Endpoint Provider endpoint URL
@@ -146,6 +327,9 @@ type RegistrationRequest struct {
 
 // RegistrationResponse defines model for RegistrationResponse.
 type RegistrationResponse struct {
+	// Etag Entity tag of the registration after the update
+	Etag *string `json:"etag,omitempty"`
+
 	// Message Status message
 	Message *string `json:"message,omitempty"`
 
@@ -162,6 +346,20 @@ type RegistrationResponse struct {
 	Status *string `json:"status,omitempty"`
 }
 
+// RegistrationSpec defines model for RegistrationSpec.
+type RegistrationSpec struct {
+	// Endpoint Provider endpoint URL
+	Endpoint   string           `json:"endpoint"`
+	Metadata   ProviderMetadata `json:"metadata"`
+	Operations []string         `json:"operations"`
+
+	// ResourceKind Resource kind the provider is registered for
+	ResourceKind string `json:"resource_kind"`
+
+	// ServiceId Unique service identifier (UUID)
+	ServiceId string `json:"service_id"`
+}
+
 // RegistryView defines model for RegistryView.
 type RegistryView struct {
 	Providers *[]struct {
@@ -182,16 +380,102 @@ type RegistryView struct {
 	Total *int `json:"total,omitempty"`
 }
 
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// IfNoneMatch defines model for IfNoneMatch.
+type IfNoneMatch = string
+
+// UpdateMask defines model for UpdateMask.
+type UpdateMask = string
+
+// ApplyManifestsParams defines parameters for ApplyManifests.
+type ApplyManifestsParams struct {
+	// DryRun Report the changes without applying them
+	DryRun *bool `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+
+	// Prune Delete the managed objects missing from the manifests
+	Prune *bool `form:"prune,omitempty" json:"prune,omitempty"`
+}
+
 // ListProvidersParams defines parameters for ListProviders.
 type ListProvidersParams struct {
 	Type *string `form:"type,omitempty" json:"type,omitempty"`
 }
 
+// CreateProviderParams defines parameters for CreateProvider.
+type CreateProviderParams struct {
+	// IdempotencyKey Client generated key that makes the request safe to retry. A replay
+	// with the same key returns the original response.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetProviderParams defines parameters for GetProvider.
+type GetProviderParams struct {
+	// IfNoneMatch Return 304 Not Modified if the current etag of the resource matches
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// PatchProviderParams defines parameters for PatchProvider.
+type PatchProviderParams struct {
+	// UpdateMask Comma separated list of fields to update, or "*" for all mutable fields
+	UpdateMask *UpdateMask `form:"update_mask,omitempty" json:"update_mask,omitempty"`
+
+	// IfMatch Only apply the request if the current etag of the resource matches
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// ApplyProviderParams defines parameters for ApplyProvider.
+type ApplyProviderParams struct {
+	// IfMatch Only apply the request if the current etag of the resource matches
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// RegisterProviderParams defines parameters for RegisterProvider.
+type RegisterProviderParams struct {
+	// IfMatch Only apply the request if the current etag of the resource matches
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+
+	// IdempotencyKey Client generated key that makes the request safe to retry. A replay
+	// with the same key returns the original response.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetRegisteredProviderParams defines parameters for GetRegisteredProvider.
+type GetRegisteredProviderParams struct {
+	// IfNoneMatch Return 304 Not Modified if the current etag of the resource matches
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// PatchRegisteredProviderParams defines parameters for PatchRegisteredProvider.
+type PatchRegisteredProviderParams struct {
+	// UpdateMask Comma separated list of fields to update, or "*" for all mutable fields
+	UpdateMask *UpdateMask `form:"update_mask,omitempty" json:"update_mask,omitempty"`
+
+	// IfMatch Only apply the request if the current etag of the resource matches
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// ApplyManifestsJSONRequestBody defines body for ApplyManifests for application/json ContentType.
+type ApplyManifestsJSONRequestBody = ApplyRequest
+
+// SetLogLevelJSONRequestBody defines body for SetLogLevel for application/json ContentType.
+type SetLogLevelJSONRequestBody = LogLevel
+
 // CreateProviderJSONRequestBody defines body for CreateProvider for application/json ContentType.
 type CreateProviderJSONRequestBody = Provider
 
+// PatchProviderApplicationMergePatchPlusJSONRequestBody defines body for PatchProvider for application/merge-patch+json ContentType.
+type PatchProviderApplicationMergePatchPlusJSONRequestBody = ProviderPatch
+
 // ApplyProviderJSONRequestBody defines body for ApplyProvider for application/json ContentType.
 type ApplyProviderJSONRequestBody = Provider
 
 // RegisterProviderJSONRequestBody defines body for RegisterProvider for application/json ContentType.
 type RegisterProviderJSONRequestBody = RegistrationRequest
+
+// PatchRegisteredProviderApplicationMergePatchPlusJSONRequestBody defines body for PatchRegisteredProvider for application/merge-patch+json ContentType.
+type PatchRegisteredProviderApplicationMergePatchPlusJSONRequestBody = RegistrationPatch
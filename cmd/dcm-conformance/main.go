@@ -0,0 +1,148 @@
+// dcm-conformance checks that a provider implements the operations it
+// declares, as described by the provider contract
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/pkg/providertest"
+	"github.com/spf13/cobra"
+)
+
+const (
+	formatText  = "text"
+	formatJSON  = "json"
+	formatJUnit = "junit"
+)
+
+type options struct {
+	endpoint   string
+	operations []string
+	body       string
+	format     string
+	reportFile string
+	timeout    time.Duration
+}
+
+// errFailed makes the command exit with 1 once the report is written
+var errFailed = errors.New("provider is not conformant")
+
+func newRootCmd() *cobra.Command {
+	var opts options
+	cmd := &cobra.Command{
+		Use:   "dcm-conformance --endpoint URL",
+		Short: "Check a provider against the DCM provider contract",
+		Long: `Check a provider against the DCM provider contract.
+
+The declared operations are exercised against the endpoint of the
+registration: CREATE, then READ, LIST and DELETE of the created resource, then
+READ expecting 404. Every response is validated against the contract. The
+command exits with 1 when a check fails.`,
+		Example: `  dcm-conformance --endpoint http://localhost:8082/api/file
+  dcm-conformance --endpoint http://localhost:8082/api/file --operations CREATE,READ --format junit --report report.xml`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), cmd.OutOrStdout(), opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.endpoint, "endpoint", "", "endpoint of the registration of the provider")
+	flags.StringSliceVar(&opts.operations, "operations", []string{providertest.OperationCreate, providertest.OperationRead, providertest.OperationDelete},
+		"operations declared by the provider")
+	flags.StringVar(&opts.body, "body", "", "JSON body of the creation request (default: a random name)")
+	flags.StringVar(&opts.format, "format", formatText, "report format: text, json or junit")
+	flags.StringVar(&opts.reportFile, "report", "", "file the report is written to (default: stdout)")
+	flags.DurationVar(&opts.timeout, "request-timeout", 30*time.Second, "timeout of each request to the provider")
+	_ = cmd.MarkFlagRequired("endpoint")
+	return cmd
+}
+
+func run(ctx context.Context, stdout io.Writer, opts options) error {
+	cfg := providertest.Config{
+		Endpoint:   opts.endpoint,
+		Operations: opts.operations,
+		HTTPClient: &http.Client{Timeout: opts.timeout},
+	}
+	if opts.body != "" {
+		if err := json.Unmarshal([]byte(opts.body), &cfg.CreateBody); err != nil {
+			return fmt.Errorf("invalid --body: %w", err)
+		}
+	}
+	write, err := writer(opts.format)
+	if err != nil {
+		return err
+	}
+
+	report, err := providertest.Run(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	out := stdout
+	if opts.reportFile != "" {
+		file, err := os.Create(opts.reportFile)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+	if err := write(report, out); err != nil {
+		return fmt.Errorf("writing the report: %w", err)
+	}
+	if opts.reportFile != "" && opts.format != formatText {
+		// Keep a summary on the terminal
+		_ = writeText(report, stdout)
+	}
+
+	if !report.Passed() {
+		return errFailed
+	}
+	return nil
+}
+
+func writer(format string) (func(*providertest.Report, io.Writer) error, error) {
+	switch format {
+	case formatText:
+		return writeText, nil
+	case formatJSON:
+		return (*providertest.Report).WriteJSON, nil
+	case formatJUnit:
+		return (*providertest.Report).WriteJUnit, nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q, use text, json or junit", format)
+	}
+}
+
+func writeText(report *providertest.Report, w io.Writer) error {
+	fmt.Fprintf(w, "Provider %s (%s)\n", report.Endpoint, strings.Join(report.Operations, ", "))
+	for _, result := range report.Results {
+		line := fmt.Sprintf("  %-7s %s", strings.ToUpper(string(result.Status)), result.Name)
+		if result.Message != "" {
+			line += ": " + result.Message
+		}
+		fmt.Fprintln(w, line)
+	}
+	_, err := fmt.Fprintf(w, "%d passed, %d failed, %d skipped in %s\n",
+		report.Count(providertest.StatusPassed), report.Count(providertest.StatusFailed), report.Count(providertest.StatusSkipped),
+		report.Duration.Round(time.Millisecond))
+	return err
+}
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	api "github.com/dcm-project/service-provider-api/api/v1alpha1"
+	"github.com/dcm-project/service-provider-api/pkg/client"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+// documentSeparator splits the documents of a YAML stream
+var documentSeparator = regexp.MustCompile(`(?m)^---[ \t]*$`)
+
+func newApplyCmd() *cobra.Command {
+	var files []string
+	var dryRun, prune bool
+	cmd := &cobra.Command{
+		Use:   "apply -f FILE|DIR|-",
+		Short: "Apply provider, registration and catalog item manifests",
+		Long: `Apply makes the server state match the manifests: the objects that differ
+are created or updated. With --prune the objects previously applied and
+missing from the manifests are deleted, objects created by other means are
+never deleted. The changes are applied in a single transaction, nothing is
+changed when one of them fails.
+
+Manifests are YAML or JSON documents, a file may hold several YAML documents
+separated by ---. Directories are read for .yaml, .yml and .json files.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var manifests []api.Manifest
+			for _, path := range files {
+				m, err := readManifests(path)
+				if err != nil {
+					return err
+				}
+				manifests = append(manifests, m...)
+			}
+
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			params := &api.ApplyManifestsParams{DryRun: &dryRun, Prune: &prune}
+			resp, err := c.ApplyManifestsWithResponse(cmd.Context(), params, api.ApplyRequest{Manifests: manifests})
+			if err != nil {
+				return err
+			}
+			if err := client.CheckResponse(resp.StatusCode(), resp.Body); err != nil {
+				return err
+			}
+			return printObject(cmd.OutOrStdout(), resp.JSON200, applyTable(resp.JSON200))
+		},
+	}
+	cmd.Flags().StringArrayVarP(&files, "filename", "f", nil, "manifest file or directory, - reads stdin")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only show the changes")
+	cmd.Flags().BoolVar(&prune, "prune", false, "delete the applied objects missing from the manifests")
+	_ = cmd.MarkFlagRequired("filename")
+	return cmd
+}
+
+func applyTable(result *api.ApplyResult) func() table {
+	return func() table {
+		t := table{headers: []string{"KIND", "NAME", "ACTION", "FIELDS"}}
+		for _, change := range result.Changes {
+			var fields string
+			if change.Fields != nil {
+				fields = strings.Join(*change.Fields, ",")
+			}
+			action := string(change.Action)
+			if result.DryRun {
+				action += " (dry run)"
+			}
+			t.rows = append(t.rows, []string{string(change.Kind), change.Name, action, fields})
+		}
+		return t
+	}
+}
+
+// readManifests reads the manifests of a file, of the files of a directory
+// or of stdin for "-"
+func readManifests(path string) ([]api.Manifest, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		return decodeManifests(path, data)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return decodeManifests(path, data)
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var manifests []api.Manifest
+	for _, entry := range entries {
+		if entry.IsDir() || !slices.Contains([]string{".yaml", ".yml", ".json"}, filepath.Ext(entry.Name())) {
+			continue
+		}
+		m, err := readManifests(filepath.Join(path, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, m...)
+	}
+	return manifests, nil
+}
+
+func decodeManifests(path string, data []byte) ([]api.Manifest, error) {
+	var manifests []api.Manifest
+	for i, document := range documentSeparator.Split(string(data), -1) {
+		if strings.TrimSpace(document) == "" {
+			continue
+		}
+		var manifest api.Manifest
+		if err := yaml.UnmarshalStrict([]byte(document), &manifest); err != nil {
+			return nil, fmt.Errorf("parsing %s, document %d: %w", path, i+1, err)
+		}
+		manifests = append(manifests, manifest)
+	}
+	return manifests, nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/dcm-project/service-provider-api/pkg/client"
+)
+
+// newClient returns an API client for the resolved context
+func newClient() (*client.ClientWithResponses, error) {
+	ctx, err := resolveContext()
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := newTLSConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	httpClient := &http.Client{Transport: transport, Timeout: opts.timeout}
+
+	token := ctx.Token
+	if ctx.TokenFile != "" {
+		data, err := os.ReadFile(ctx.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading token file: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+
+	clientOpts := []client.ClientOption{client.WithHTTPClient(httpClient)}
+	if token != "" {
+		clientOpts = append(clientOpts, client.WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+			req.Header.Set("Authorization", "Bearer "+token)
+			return nil
+		}))
+	}
+	return client.NewClientWithResponses(ctx.Server, clientOpts...)
+}
+
+func newTLSConfig(ctx Context) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: ctx.InsecureSkipTLSVerify, //nolint:gosec // requested explicitly by the user
+	}
+
+	if ctx.CertificateAuthority != "" {
+		pem, err := os.ReadFile(ctx.CertificateAuthority)
+		if err != nil {
+			return nil, fmt.Errorf("reading certificate authority: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in %s", ctx.CertificateAuthority)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if ctx.ClientCertificate != "" || ctx.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(ctx.ClientCertificate, ctx.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
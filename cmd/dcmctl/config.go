@@ -0,0 +1,290 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	configEnv     = "DCMCONFIG"
+	defaultServer = "http://localhost:8081"
+)
+
+// Config is the dcmctl configuration file. Like a kubeconfig, it holds named
+// contexts and the name of the context used by default.
+type Config struct {
+	CurrentContext string         `json:"current-context,omitempty"`
+	Contexts       []NamedContext `json:"contexts,omitempty"`
+}
+
+type NamedContext struct {
+	Name    string  `json:"name"`
+	Context Context `json:"context"`
+}
+
+// Context holds the server and credentials used to reach it
+type Context struct {
+	Server                string `json:"server"`
+	Token                 string `json:"token,omitempty"`
+	TokenFile             string `json:"token-file,omitempty"`
+	CertificateAuthority  string `json:"certificate-authority,omitempty"`
+	ClientCertificate     string `json:"client-certificate,omitempty"`
+	ClientKey             string `json:"client-key,omitempty"`
+	InsecureSkipTLSVerify bool   `json:"insecure-skip-tls-verify,omitempty"`
+}
+
+func configPath() (string, error) {
+	if opts.configFile != "" {
+		return opts.configFile, nil
+	}
+	if path := os.Getenv(configEnv); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".dcm", "config"), nil
+}
+
+// loadConfig reads the configuration file, a missing file is an empty
+// configuration
+func loadConfig() (*Config, string, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, "", err
+	}
+
+	cfg := &Config{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, path, nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, "", fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return cfg, path, nil
+}
+
+func saveConfig(cfg *Config, path string) error {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	// The file may hold tokens
+	return os.WriteFile(path, data, 0o600)
+}
+
+func (c *Config) context(name string) (*Context, bool) {
+	for i := range c.Contexts {
+		if c.Contexts[i].Name == name {
+			return &c.Contexts[i].Context, true
+		}
+	}
+	return nil, false
+}
+
+// resolveContext returns the context selected by --context or the current
+// context, overridden by the connection flags
+func resolveContext() (Context, error) {
+	cfg, path, err := loadConfig()
+	if err != nil {
+		return Context{}, err
+	}
+
+	var resolved Context
+	name := opts.context
+	if name == "" {
+		name = cfg.CurrentContext
+	}
+	if name != "" {
+		ctx, ok := cfg.context(name)
+		if !ok {
+			return Context{}, fmt.Errorf("context %q not found in %s", name, path)
+		}
+		resolved = *ctx
+	}
+
+	overrides := []struct {
+		flag  string
+		value *string
+		set   string
+	}{
+		{"server", &resolved.Server, opts.server},
+		{"token", &resolved.Token, opts.token},
+		{"token-file", &resolved.TokenFile, opts.tokenFile},
+		{"certificate-authority", &resolved.CertificateAuthority, opts.caFile},
+		{"client-certificate", &resolved.ClientCertificate, opts.clientCert},
+		{"client-key", &resolved.ClientKey, opts.clientKey},
+	}
+	for _, o := range overrides {
+		if rootCmd.PersistentFlags().Changed(o.flag) {
+			*o.value = o.set
+		}
+	}
+	if rootCmd.PersistentFlags().Changed("insecure-skip-tls-verify") {
+		resolved.InsecureSkipTLSVerify = opts.insecureSkipTLSVerify
+	}
+	if resolved.Server == "" {
+		resolved.Server = defaultServer
+	}
+	return resolved, nil
+}
+
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the contexts of the dcmctl configuration file",
+	}
+	cmd.AddCommand(
+		newGetContextsCmd(),
+		newUseContextCmd(),
+		newSetContextCmd(),
+		newDeleteContextCmd(),
+	)
+	return cmd
+}
+
+func newGetContextsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get-contexts",
+		Short: "List the contexts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, _, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			sort.Slice(cfg.Contexts, func(i, j int) bool { return cfg.Contexts[i].Name < cfg.Contexts[j].Name })
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "CURRENT\tNAME\tSERVER")
+			for _, c := range cfg.Contexts {
+				current := ""
+				if c.Name == cfg.CurrentContext {
+					current = "*"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\n", current, c.Name, c.Context.Server)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func newUseContextCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use-context NAME",
+		Short: "Set the current context",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, path, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			if _, ok := cfg.context(args[0]); !ok {
+				return fmt.Errorf("context %q not found in %s", args[0], path)
+			}
+			cfg.CurrentContext = args[0]
+			if err := saveConfig(cfg, path); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Switched to context %q.\n", args[0])
+			return nil
+		},
+	}
+}
+
+func newSetContextCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-context NAME",
+		Short: "Create or update a context from the connection flags",
+		Example: `  dcmctl config set-context prod --server https://dcm.example.com --token-file ~/.dcm/token
+  dcmctl config set-context prod --insecure-skip-tls-verify=false`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, path, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			ctx, ok := cfg.context(args[0])
+			if !ok {
+				cfg.Contexts = append(cfg.Contexts, NamedContext{Name: args[0]})
+				ctx = &cfg.Contexts[len(cfg.Contexts)-1].Context
+			}
+
+			// Only the flags set on the command line change the context
+			saved := *ctx
+			flags := rootCmd.PersistentFlags()
+			for flag, value := range map[string]*string{
+				"server":                &saved.Server,
+				"token":                 &saved.Token,
+				"token-file":            &saved.TokenFile,
+				"certificate-authority": &saved.CertificateAuthority,
+				"client-certificate":    &saved.ClientCertificate,
+				"client-key":            &saved.ClientKey,
+			} {
+				if flags.Changed(flag) {
+					*value, _ = flags.GetString(flag)
+				}
+			}
+			if flags.Changed("insecure-skip-tls-verify") {
+				saved.InsecureSkipTLSVerify = opts.insecureSkipTLSVerify
+			}
+			*ctx = saved
+			if cfg.CurrentContext == "" {
+				cfg.CurrentContext = args[0]
+			}
+
+			if err := saveConfig(cfg, path); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Context %q saved.\n", args[0])
+			return nil
+		},
+	}
+}
+
+func newDeleteContextCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete-context NAME",
+		Short: "Delete a context",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, path, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			contexts := cfg.Contexts[:0]
+			for _, c := range cfg.Contexts {
+				if c.Name != args[0] {
+					contexts = append(contexts, c)
+				}
+			}
+			if len(contexts) == len(cfg.Contexts) {
+				return fmt.Errorf("context %q not found in %s", args[0], path)
+			}
+			cfg.Contexts = contexts
+			if cfg.CurrentContext == args[0] {
+				cfg.CurrentContext = ""
+			}
+			if err := saveConfig(cfg, path); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Context %q deleted.\n", args[0])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,67 @@
+// dcmctl is the command-line client of the DCM Service Provider API
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// globalOptions are the flags shared by all commands
+type globalOptions struct {
+	configFile string
+	context    string
+	output     string
+
+	server                string
+	token                 string
+	tokenFile             string
+	caFile                string
+	clientCert            string
+	clientKey             string
+	insecureSkipTLSVerify bool
+	timeout               time.Duration
+}
+
+var opts globalOptions
+
+var rootCmd = &cobra.Command{
+	Use:           "dcmctl",
+	Short:         "Command-line client of the DCM Service Provider API",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	flags := rootCmd.PersistentFlags()
+	flags.StringVar(&opts.configFile, "dcmconfig", "", "path to the dcmctl configuration file (default $DCMCONFIG or ~/.dcm/config)")
+	flags.StringVar(&opts.context, "context", "", "name of the context to use instead of the current context")
+	flags.StringVarP(&opts.output, "output", "o", outputTable, "output format: table, json or yaml")
+
+	flags.StringVarP(&opts.server, "server", "s", "", "URL of the Service Provider API")
+	flags.StringVar(&opts.token, "token", "", "bearer token for authentication")
+	flags.StringVar(&opts.tokenFile, "token-file", "", "file containing the bearer token for authentication")
+	flags.StringVar(&opts.caFile, "certificate-authority", "", "file of the CA certificates used to verify the server")
+	flags.StringVar(&opts.clientCert, "client-certificate", "", "file of the client certificate for TLS authentication")
+	flags.StringVar(&opts.clientKey, "client-key", "", "file of the client key for TLS authentication")
+	flags.BoolVar(&opts.insecureSkipTLSVerify, "insecure-skip-tls-verify", false, "do not verify the server certificate")
+	flags.DurationVar(&opts.timeout, "request-timeout", 30*time.Second, "timeout of each request to the server")
+
+	rootCmd.AddCommand(
+		newProvidersCmd(),
+		newRegistrationsCmd(),
+		newCatalogCmd(),
+		newRegistryCmd(),
+		newApplyCmd(),
+		newConfigCmd(),
+	)
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
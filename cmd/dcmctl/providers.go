@@ -0,0 +1,256 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	api "github.com/dcm-project/service-provider-api/api/v1alpha1"
+	"github.com/dcm-project/service-provider-api/pkg/client"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+func newProvidersCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "providers",
+		Aliases: []string{"provider"},
+		Short:   "Manage service providers",
+	}
+	cmd.AddCommand(
+		newProvidersListCmd(),
+		newProvidersGetCmd(),
+		newProvidersCreateCmd(),
+		newProvidersApplyCmd(),
+		newProvidersDeleteCmd(),
+	)
+	return cmd
+}
+
+func providersTable(providers ...api.Provider) func() table {
+	return func() table {
+		t := table{headers: []string{"ID", "NAME", "TYPE", "ENDPOINT", "OPERATIONS"}}
+		for _, p := range providers {
+			t.rows = append(t.rows, []string{p.Id, p.Name, string(p.Type), p.Endpoint, strings.Join(p.Operations, ",")})
+		}
+		return t
+	}
+}
+
+func newProvidersListCmd() *cobra.Command {
+	var providerType string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the providers",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			params := &api.ListProvidersParams{}
+			if providerType != "" {
+				params.Type = &providerType
+			}
+			resp, err := c.ListProvidersWithResponse(cmd.Context(), params)
+			if err != nil {
+				return err
+			}
+			if err := client.CheckResponse(resp.StatusCode(), resp.Body); err != nil {
+				return err
+			}
+
+			var providers []api.Provider
+			if resp.JSON200 != nil && resp.JSON200.Providers != nil {
+				providers = *resp.JSON200.Providers
+			}
+			return printObject(cmd.OutOrStdout(), providers, providersTable(providers...))
+		},
+	}
+	cmd.Flags().StringVar(&providerType, "type", "", "only list the providers of this type")
+	return cmd
+}
+
+func newProvidersGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get ID",
+		Short: "Show a provider",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := uuid.Parse(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid provider ID: %w", err)
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			resp, err := c.GetProviderWithResponse(cmd.Context(), id, &api.GetProviderParams{})
+			if err != nil {
+				return err
+			}
+			if err := client.CheckResponse(resp.StatusCode(), resp.Body); err != nil {
+				return err
+			}
+			return printObject(cmd.OutOrStdout(), resp.JSON200, providersTable(*resp.JSON200))
+		},
+	}
+}
+
+// providerFlags build a provider from the command line when no manifest is
+// given
+type providerFlags struct {
+	file        string
+	name        string
+	kind        string
+	endpoint    string
+	apiHost     string
+	description string
+	operations  []string
+}
+
+func (f *providerFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.file, "filename", "f", "", "JSON or YAML file of the provider, - for stdin")
+	cmd.Flags().StringVar(&f.name, "name", "", "name of the provider")
+	cmd.Flags().StringVar(&f.kind, "type", "", "type of the provider")
+	cmd.Flags().StringVar(&f.endpoint, "endpoint", "", "endpoint of the provider")
+	cmd.Flags().StringVar(&f.apiHost, "api-host", "", "host URL of the provider API")
+	cmd.Flags().StringVar(&f.description, "description", "", "description of the provider")
+	cmd.Flags().StringSliceVar(&f.operations, "operation", nil, "operation supported by the provider, can be repeated")
+}
+
+func (f *providerFlags) provider(id string) (api.Provider, error) {
+	if f.file != "" {
+		var provider api.Provider
+		if err := readManifest(f.file, &provider); err != nil {
+			return provider, err
+		}
+		if id != "" && provider.Id == "" {
+			provider.Id = id
+		}
+		return provider, nil
+	}
+
+	if f.name == "" || f.kind == "" || f.endpoint == "" {
+		return api.Provider{}, errors.New("either --filename or --name, --type and --endpoint are required")
+	}
+	if id == "" {
+		id = uuid.NewString()
+	}
+	operations := f.operations
+	if operations == nil {
+		operations = []string{}
+	}
+	return api.Provider{
+		Id:          id,
+		Name:        f.name,
+		Type:        api.ProviderType(f.kind),
+		Endpoint:    f.endpoint,
+		ApiHost:     f.apiHost,
+		Description: f.description,
+		Operations:  operations,
+	}, nil
+}
+
+func newProvidersCreateCmd() *cobra.Command {
+	var flags providerFlags
+	var idempotencyKey string
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a provider",
+		Example: `  dcmctl providers create -f provider.yaml
+  dcmctl providers create --name vm-east --type vm --endpoint https://vm-east.example.com --operation create,delete`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			provider, err := flags.provider("")
+			if err != nil {
+				return err
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			params := &api.CreateProviderParams{}
+			if idempotencyKey != "" {
+				params.IdempotencyKey = &idempotencyKey
+			}
+			resp, err := c.CreateProviderWithResponse(cmd.Context(), params, provider)
+			if err != nil {
+				return err
+			}
+			if err := client.CheckResponse(resp.StatusCode(), resp.Body); err != nil {
+				return err
+			}
+			return printObject(cmd.OutOrStdout(), resp.JSON201, providersTable(*resp.JSON201))
+		},
+	}
+	flags.register(cmd)
+	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "key making the request safe to retry")
+	return cmd
+}
+
+func newProvidersApplyCmd() *cobra.Command {
+	var flags providerFlags
+	var ifMatch string
+	cmd := &cobra.Command{
+		Use:   "apply ID",
+		Short: "Replace a provider",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := uuid.Parse(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid provider ID: %w", err)
+			}
+			provider, err := flags.provider(id.String())
+			if err != nil {
+				return err
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			params := &api.ApplyProviderParams{}
+			if ifMatch != "" {
+				params.IfMatch = &ifMatch
+			}
+			resp, err := c.ApplyProviderWithResponse(cmd.Context(), id, params, provider)
+			if err != nil {
+				return err
+			}
+			if err := client.CheckResponse(resp.StatusCode(), resp.Body); err != nil {
+				return err
+			}
+			return printObject(cmd.OutOrStdout(), resp.JSON200, providersTable(*resp.JSON200))
+		},
+	}
+	flags.register(cmd)
+	cmd.Flags().StringVar(&ifMatch, "if-match", "", "only replace the provider if its etag matches")
+	return cmd
+}
+
+func newProvidersDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete ID",
+		Short: "Delete a provider",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := uuid.Parse(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid provider ID: %w", err)
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			resp, err := c.DeleteProviderWithResponse(cmd.Context(), id)
+			if err != nil {
+				return err
+			}
+			if err := client.CheckResponse(resp.StatusCode(), resp.Body); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Provider %s deleted.\n", id)
+			return nil
+		},
+	}
+}
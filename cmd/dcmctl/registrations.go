@@ -0,0 +1,195 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	api "github.com/dcm-project/service-provider-api/api/v1alpha1"
+	"github.com/dcm-project/service-provider-api/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func newRegistrationsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "registrations",
+		Aliases: []string{"registration"},
+		Short:   "Manage the registrations of providers in the resource registry",
+	}
+	cmd.AddCommand(
+		newRegisterCmd(),
+		newUnregisterCmd(),
+		newRegistrationsListCmd(),
+		newRegistrationsGetCmd(),
+	)
+	return cmd
+}
+
+func registrationsTable(registrations ...api.RegisteredProvider) func() table {
+	return func() table {
+		t := table{headers: []string{"SERVICE ID", "KIND", "ENDPOINT", "STATUS", "ZONE", "REGION", "OPERATIONS"}}
+		for _, r := range registrations {
+			var zone, region string
+			if r.Metadata != nil {
+				zone, region = r.Metadata.Zone, r.Metadata.Region
+			}
+			var operations string
+			if r.Operations != nil {
+				operations = strings.Join(*r.Operations, ",")
+			}
+			t.rows = append(t.rows, []string{
+				valueOrEmpty(r.ServiceId), valueOrEmpty(r.ResourceKind), valueOrEmpty(r.Endpoint),
+				valueOrEmpty(r.Status), zone, region, operations,
+			})
+		}
+		return t
+	}
+}
+
+func newRegisterCmd() *cobra.Command {
+	var (
+		file           string
+		request        api.RegistrationRequest
+		labels         map[string]string
+		constraints    map[string]string
+		ifMatch        string
+		idempotencyKey string
+	)
+	cmd := &cobra.Command{
+		Use:   "register KIND",
+		Short: "Register a provider for a resource kind",
+		Example: `  dcmctl registrations register vm -f registration.yaml
+  dcmctl registrations register vm --service-id 6f1c... --endpoint https://vm-east.example.com --zone east-1 --region us-east --operation create,delete`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if file != "" {
+				if err := readManifest(file, &request); err != nil {
+					return err
+				}
+			} else {
+				if request.ServiceId == "" || request.Endpoint == "" {
+					return errors.New("either --filename or --service-id and --endpoint are required")
+				}
+				if len(labels) > 0 {
+					request.Metadata.Labels = &labels
+				}
+				if len(constraints) > 0 {
+					request.Metadata.ResourceConstraints = &constraints
+				}
+				if request.Operations == nil {
+					request.Operations = []string{}
+				}
+			}
+
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			params := &api.RegisterProviderParams{}
+			if ifMatch != "" {
+				params.IfMatch = &ifMatch
+			}
+			if idempotencyKey != "" {
+				params.IdempotencyKey = &idempotencyKey
+			}
+			resp, err := c.RegisterProviderWithResponse(cmd.Context(), args[0], params, request)
+			if err != nil {
+				return err
+			}
+			if err := client.CheckResponse(resp.StatusCode(), resp.Body); err != nil {
+				return err
+			}
+			return printObject(cmd.OutOrStdout(), resp.JSON200, func() table {
+				r := resp.JSON200
+				return table{
+					headers: []string{"SERVICE ID", "STATUS", "ETAG", "MESSAGE"},
+					rows:    [][]string{{valueOrEmpty(r.ServiceId), valueOrEmpty(r.Status), valueOrEmpty(r.Etag), valueOrEmpty(r.Message)}},
+				}
+			})
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&file, "filename", "f", "", "JSON or YAML file of the registration request, - for stdin")
+	flags.StringVar(&request.ServiceId, "service-id", "", "unique identifier (UUID) of the service")
+	flags.StringVar(&request.Endpoint, "endpoint", "", "endpoint of the provider")
+	flags.StringVar(&request.Metadata.Zone, "zone", "", "deployment zone of the provider")
+	flags.StringVar(&request.Metadata.Region, "region", "", "deployment region of the provider")
+	flags.StringSliceVar(&request.Operations, "operation", nil, "operation supported by the provider, can be repeated")
+	flags.StringToStringVar(&labels, "label", nil, "label of the provider as key=value, can be repeated")
+	flags.StringToStringVar(&constraints, "resource-constraint", nil, "resource constraint as key=value, can be repeated")
+	flags.StringVar(&ifMatch, "if-match", "", "only update the registration if its etag matches")
+	flags.StringVar(&idempotencyKey, "idempotency-key", "", "key making the request safe to retry")
+	return cmd
+}
+
+func newUnregisterCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unregister KIND SERVICE_ID",
+		Short: "Remove the registration of a provider",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			resp, err := c.UnregisterProviderWithResponse(cmd.Context(), args[0], args[1])
+			if err != nil {
+				return err
+			}
+			if err := client.CheckResponse(resp.StatusCode(), resp.Body); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Service %s unregistered from %s.\n", args[1], args[0])
+			return nil
+		},
+	}
+}
+
+func newRegistrationsListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list KIND",
+		Short: "List the providers registered for a resource kind",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			resp, err := c.ListRegisteredProvidersWithResponse(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			if err := client.CheckResponse(resp.StatusCode(), resp.Body); err != nil {
+				return err
+			}
+
+			var registrations []api.RegisteredProvider
+			if resp.JSON200 != nil {
+				registrations = *resp.JSON200
+			}
+			return printObject(cmd.OutOrStdout(), registrations, registrationsTable(registrations...))
+		},
+	}
+}
+
+func newRegistrationsGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get KIND SERVICE_ID",
+		Short: "Show the registration of a provider",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			resp, err := c.GetRegisteredProviderWithResponse(cmd.Context(), args[0], args[1], &api.GetRegisteredProviderParams{})
+			if err != nil {
+				return err
+			}
+			if err := client.CheckResponse(resp.StatusCode(), resp.Body); err != nil {
+				return err
+			}
+			return printObject(cmd.OutOrStdout(), resp.JSON200, registrationsTable(*resp.JSON200))
+		},
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/dcm-project/service-provider-api/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func newCatalogCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "catalog",
+		Short: "Show the service catalog with the providers of each item",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			resp, err := c.GetCatalogWithResponse(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if err := client.CheckResponse(resp.StatusCode(), resp.Body); err != nil {
+				return err
+			}
+
+			return printObject(cmd.OutOrStdout(), resp.JSON200, func() table {
+				t := table{headers: []string{"NAME", "DISPLAY NAME", "KIND", "PROVIDERS"}}
+				if resp.JSON200.CatalogItems == nil {
+					return t
+				}
+				for _, item := range *resp.JSON200.CatalogItems {
+					var providers string
+					if item.AvailableProviders != nil {
+						providers = strings.Join(*item.AvailableProviders, ",")
+					}
+					t.rows = append(t.rows, []string{
+						valueOrEmpty(item.Name), valueOrEmpty(item.DisplayName), valueOrEmpty(item.ResourceKind), providers,
+					})
+				}
+				return t
+			})
+		},
+	}
+}
+
+func newRegistryCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "registry",
+		Short: "Show the resource registry with the registrations of each provider",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			resp, err := c.GetRegistryWithResponse(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if err := client.CheckResponse(resp.StatusCode(), resp.Body); err != nil {
+				return err
+			}
+
+			return printObject(cmd.OutOrStdout(), resp.JSON200, func() table {
+				t := table{headers: []string{"SERVICE ID", "STATUS", "ZONE", "REGION", "LABELS", "KINDS"}}
+				if resp.JSON200.Providers == nil {
+					return t
+				}
+				for _, p := range *resp.JSON200.Providers {
+					var zone, region, labels string
+					if p.Metadata != nil {
+						zone, region, labels = p.Metadata.Zone, p.Metadata.Region, formatMap(p.Metadata.Labels)
+					}
+					var kinds []string
+					if p.Registrations != nil {
+						for _, r := range *p.Registrations {
+							kinds = append(kinds, valueOrEmpty(r.ResourceKind))
+						}
+					}
+					t.rows = append(t.rows, []string{
+						valueOrEmpty(p.ServiceId), valueOrEmpty(p.Status), zone, region, labels, strings.Join(kinds, ","),
+					})
+				}
+				return t
+			})
+		},
+	}
+}
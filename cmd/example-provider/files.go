@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/pkg/provider"
+	"github.com/google/uuid"
+)
+
+const contentKey = "content"
+
+// FileHandler stores each resource as a JSON file of a directory
+type FileHandler struct {
+	dir string
+}
+
+var (
+	_ provider.ResourceHandler = (*FileHandler)(nil)
+	_ provider.Validator       = (*FileHandler)(nil)
+	_ provider.HealthChecker   = (*FileHandler)(nil)
+)
+
+func NewFileHandler(dir string) (*FileHandler, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileHandler{dir: dir}, nil
+}
+
+// Validate checks that the content of the file, if any, is a string
+func (h *FileHandler) Validate(request provider.CreateRequest) error {
+	if content, ok := request.Spec[contentKey]; ok {
+		if _, ok := content.(string); !ok {
+			return fmt.Errorf("spec.%s must be a string", contentKey)
+		}
+	}
+	return nil
+}
+
+func (h *FileHandler) Create(ctx context.Context, request provider.CreateRequest) (*provider.Resource, error) {
+	resource := provider.Resource{
+		ID:          uuid.New().String(),
+		Name:        request.Name,
+		CatalogItem: request.CatalogItem,
+		Status:      "READY",
+		Properties:  map[string]interface{}{contentKey: request.Spec[contentKey]},
+		CreatedAt:   time.Now().UTC(),
+	}
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(h.path(resource.ID), data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to create file: %w", err)
+	}
+
+	log.Printf("📄 Created file: %s (name: %s)", resource.ID, resource.Name)
+	return &resource, nil
+}
+
+func (h *FileHandler) Get(ctx context.Context, id string) (*provider.Resource, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("%w: %s", provider.ErrNotFound, id)
+	}
+	data, err := os.ReadFile(h.path(id))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", provider.ErrNotFound, id)
+		}
+		return nil, err
+	}
+	var resource provider.Resource
+	if err := json.Unmarshal(data, &resource); err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", id, err)
+	}
+	return &resource, nil
+}
+
+func (h *FileHandler) Delete(ctx context.Context, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return fmt.Errorf("%w: %s", provider.ErrNotFound, id)
+	}
+	if err := os.Remove(h.path(id)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%w: %s", provider.ErrNotFound, id)
+		}
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	log.Printf("🗑️  Deleted file: %s", id)
+	return nil
+}
+
+func (h *FileHandler) List(ctx context.Context) ([]provider.Resource, error) {
+	entries, err := os.ReadDir(h.dir)
+	if err != nil {
+		return nil, err
+	}
+	resources := []provider.Resource{}
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok {
+			continue
+		}
+		resource, err := h.Get(ctx, id)
+		if err != nil {
+			if errors.Is(err, provider.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		resources = append(resources, *resource)
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].CreatedAt.Before(resources[j].CreatedAt) })
+	return resources, nil
+}
+
+// Health checks that the storage directory is still there
+func (h *FileHandler) Health(ctx context.Context) error {
+	_, err := os.Stat(h.dir)
+	return err
+}
+
+func (h *FileHandler) path(id string) string {
+	return filepath.Join(h.dir, id+".json")
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dcm-project/service-provider-api/internal/backup"
+	"github.com/dcm-project/service-provider-api/internal/config"
+	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportOutput string
+	importFile   string
+	importMode   string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the registry and the catalog from the database to a JSON snapshot",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, _, err := openStore(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		out := cmd.OutOrStdout()
+		if exportOutput != "" && exportOutput != "-" {
+			f, err := os.OpenFile(exportOutput, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			out = f
+		}
+		return backup.Export(cmd.Context(), s, out)
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Restore the registry and the catalog in the database from a JSON snapshot",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mode, err := backup.ParseMode(importMode)
+		if err != nil {
+			return err
+		}
+
+		var in io.Reader = cmd.InOrStdin()
+		if importFile != "-" {
+			f, err := os.Open(importFile)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			in = f
+		}
+
+		s, cfg, err := openStore(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		summary, err := backup.Import(cmd.Context(), s, in, mode)
+		if err != nil {
+			return err
+		}
+		if err := notifyReplicas(cmd.Context(), cfg, s); err != nil {
+			return fmt.Errorf("notifying the replicas of the import: %w", err)
+		}
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(summary)
+	},
+}
+
+// openStore connects to the configured database, for the commands working
+// without the API service
+func openStore(ctx context.Context) (store.Store, *config.Config, error) {
+	cfg, err := config.Load(configFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	s, err := store.Open(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s, cfg, nil
+}
+
+// notifyReplicas flushes the caches of the running replicas, which are not
+// notified of the writes made directly to the database
+func notifyReplicas(ctx context.Context, cfg *config.Config, s store.Store) error {
+	ds, ok := s.(*store.DataStore)
+	if !ok || cfg.Database.Type != "pgsql" {
+		return nil
+	}
+	notifier := store.NewPGNotifier(ds.DB())
+	for _, cache := range []string{store.CacheProviders, store.CacheCatalog} {
+		if err := notifier.Notify(ctx, cache); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "file to write the snapshot to, stdout by default")
+	importCmd.Flags().StringVarP(&importFile, "filename", "f", "", "snapshot file to restore, - reads stdin")
+	importCmd.Flags().StringVar(&importMode, "mode", string(backup.ModeMerge), "merge keeps the objects missing from the snapshot, replace deletes them")
+	_ = importCmd.MarkFlagRequired("filename")
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"github.com/dcm-project/service-provider-api/internal/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the service configuration",
+}
+
+var configPrintCmd = &cobra.Command{
+	Use:   "print",
+	Short: "Print the effective configuration with secrets masked",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(configFile)
+		if err != nil {
+			return err
+		}
+
+		encoder := yaml.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent(2)
+		defer encoder.Close()
+		return encoder.Encode(cfg.Masked())
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configPrintCmd)
+}
@@ -2,75 +2,163 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	apiserver "github.com/dcm-project/service-provider-api/internal/api_server"
 	"github.com/dcm-project/service-provider-api/internal/config"
+	"github.com/dcm-project/service-provider-api/internal/logging"
+	"github.com/dcm-project/service-provider-api/internal/metrics"
 	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/dcm-project/service-provider-api/internal/tracing"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 )
 
 func main() {
-	// Initialize logger
+	// Initialize logger, replaced once the configuration is loaded
 	logger, _ := zap.NewDevelopment()
 	zap.ReplaceGlobals(logger)
-	defer logger.Sync()
 
-	err := runCmd.Execute()
+	err := rootCmd.Execute()
 	if err != nil {
 		os.Exit(1)
 	}
 }
 
+const tracingShutdownTimeout = 5 * time.Second
+
+var configFile string
+
+var rootCmd = &cobra.Command{
+	Use:          "service-provider-api",
+	Short:        "DCM service provider API",
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "path to a YAML configuration file, environment variables override its values")
+	// Running without a subcommand starts the API service, as before subcommands were added
+	rootCmd.RunE = runCmd.RunE
+	rootCmd.AddCommand(runCmd, configCmd, exportCmd, importCmd)
+}
+
 var runCmd = &cobra.Command{
 	Use:   "run",
 	Short: "Run the planner api",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(configFile)
+		if err != nil {
+			return err
+		}
+
+		logger, err := logging.New(cfg.Service.LogFormat, cfg.Service.LogLevel)
+		if err != nil {
+			return err
+		}
+		zap.ReplaceGlobals(logger)
+		defer func() { _ = logger.Sync() }()
 		defer zap.S().Info("API service stopped")
 
-		cfg, err := config.New()
+		shutdownTracing, err := tracing.Init(cmd.Context(), tracing.Options{
+			Enabled:     cfg.Tracing.Enabled,
+			Endpoint:    cfg.Tracing.Endpoint,
+			File:        cfg.Tracing.File,
+			SampleRatio: cfg.Tracing.SampleRatio,
+		})
 		if err != nil {
-			zap.S().Fatalw("reading configuration", "error", err)
+			return err
 		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+			defer cancel()
+			if err := shutdownTracing(ctx); err != nil {
+				zap.S().Warnw("flushing traces", "error", err)
+			}
+		}()
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGHUP, syscall.SIGTERM, syscall.SIGQUIT)
+		defer cancel()
 
 		zap.S().Info("Starting API service...")
 		zap.S().Info("Initializing data store")
-		db, err := store.InitDB(cfg)
+		store, err := store.Open(ctx, cfg)
 		if err != nil {
-			zap.S().Fatalw("initializing data store", "error", err)
+			return fmt.Errorf("initializing data store: %w", err)
 		}
-
-		store := store.NewStore(db)
 		defer store.Close()
 
-		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGHUP, syscall.SIGTERM, syscall.SIGQUIT)
+		if err := registerMetrics(cfg, store); err != nil {
+			return err
+		}
+		store = cacheStore(cfg, store)
 
-		go func() {
-			defer cancel()
-			listener, err := newListener(cfg.Service.Address)
-			if err != nil {
-				zap.S().Fatalw("creating listener", "error", err)
-			}
+		listener, err := newListener(cfg.Service.Address)
+		if err != nil {
+			return fmt.Errorf("creating listener: %w", err)
+		}
 
-			server := apiserver.New(cfg, store, listener)
-			if err := server.Run(ctx); err != nil {
-				zap.S().Fatalw("Error running server", "error", err)
+		var adminListener net.Listener
+		if cfg.Service.AdminAddress != "" {
+			adminListener, err = newListener(cfg.Service.AdminAddress)
+			if err != nil {
+				listener.Close()
+				return fmt.Errorf("creating admin listener: %w", err)
 			}
-		}()
+		}
 
-		<-ctx.Done()
+		server := apiserver.New(cfg, store, listener, adminListener)
+		if err := server.Run(ctx); err != nil {
+			return fmt.Errorf("running server: %w", err)
+		}
 
 		return nil
 	},
 }
 
+func registerMetrics(cfg *config.Config, s store.Store) error {
+	// The memory store has no connection pool
+	if ds, ok := s.(*store.DataStore); ok {
+		sqlDB, err := ds.DB().DB()
+		if err != nil {
+			return err
+		}
+		if err := metrics.RegisterDBStats(sqlDB, cfg.Database.Name); err != nil {
+			return err
+		}
+	}
+	return metrics.RegisterRegistrations(s.Provider())
+}
+
+// cacheStore puts the cache in front of a database store, the replicas
+// sharing a pgsql database notify each other of their writes
+func cacheStore(cfg *config.Config, s store.Store) store.Store {
+	ds, ok := s.(*store.DataStore)
+	if !ok || cfg.Database.CacheTTL == 0 {
+		return s
+	}
+	cacheCfg := store.CacheConfig{TTL: cfg.Database.CacheTTL, Recorder: metrics.CacheRecorder{}}
+	if cfg.Database.Type == "pgsql" {
+		cacheCfg.Notifier = store.NewPGNotifier(ds.DB())
+	}
+	return store.NewCachedStore(s, cacheCfg)
+}
+
 func newListener(address string) (net.Listener, error) {
 	if address == "" {
 		address = "localhost:0"
 	}
-	return net.Listen("tcp", address)
+	network, address := config.SplitNetwork(address)
+	if network == "unix" {
+		// Remove the socket left by a previous run
+		if err := os.Remove(address); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return net.Listen(network, address)
 }
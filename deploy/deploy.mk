@@ -5,9 +5,6 @@ deploy-db:
 	podman rm -f service-provider-db || true
 	podman volume rm podman_service-provider-db || true
 	podman-compose -f $(COMPOSE_FILE) up -d service-provider-db
-	test/scripts/wait_for_postgres.sh podman
-	podman exec -it service-provider-db psql -c 'ALTER ROLE admin WITH SUPERUSER'
-	podman exec -it service-provider-db createdb admin || true
 	@echo "✅ DB was deployed successfully on podman."
 
 kill-db:
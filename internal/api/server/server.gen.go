@@ -16,6 +16,47 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for ApplyChangeAction.
+const (
+	Create    ApplyChangeAction = "create"
+	Delete    ApplyChangeAction = "delete"
+	Unchanged ApplyChangeAction = "unchanged"
+	Update    ApplyChangeAction = "update"
+)
+
+// Defines values for ApplyChangeKind.
+const (
+	ApplyChangeKindCatalogItem  ApplyChangeKind = "CatalogItem"
+	ApplyChangeKindProvider     ApplyChangeKind = "Provider"
+	ApplyChangeKindRegistration ApplyChangeKind = "Registration"
+)
+
+// Defines values for HealthStatus.
+const (
+	Ok          HealthStatus = "ok"
+	Unavailable HealthStatus = "unavailable"
+)
+
+// Defines values for LogLevelLevel.
+const (
+	LogLevelLevelDebug LogLevelLevel = "debug"
+	LogLevelLevelError LogLevelLevel = "error"
+	LogLevelLevelInfo  LogLevelLevel = "info"
+	LogLevelLevelWarn  LogLevelLevel = "warn"
+)
+
+// Defines values for ManifestApiVersion.
+const (
+	DcmIov1alpha1 ManifestApiVersion = "dcm.io/v1alpha1"
+)
+
+// Defines values for ManifestKind.
+const (
+	ManifestKindCatalogItem  ManifestKind = "CatalogItem"
+	ManifestKindProvider     ManifestKind = "Provider"
+	ManifestKindRegistration ManifestKind = "Registration"
+)
+
 // Defines values for ProviderType.
 const (
 	Container      ProviderType = "container"
@@ -23,6 +64,51 @@ const (
 	VirtualMachine ProviderType = "virtual_machine"
 )
 
+// ApplyChange defines model for ApplyChange.
+type ApplyChange struct {
+	Action ApplyChangeAction `json:"action"`
+
+	// Fields Fields that differ between the desired and the actual state
+	Fields *[]string       `json:"fields,omitempty"`
+	Kind   ApplyChangeKind `json:"kind"`
+
+	// Name Identifier of the object, the ID of providers and registrations and the name of catalog items
+	Name string `json:"name"`
+}
+
+// ApplyChangeAction defines model for ApplyChange.Action.
+type ApplyChangeAction string
+
+// ApplyChangeKind defines model for ApplyChange.Kind.
+type ApplyChangeKind string
+
+// ApplyRequest defines model for ApplyRequest.
+type ApplyRequest struct {
+	Manifests []Manifest `json:"manifests"`
+}
+
+// ApplyResult defines model for ApplyResult.
+type ApplyResult struct {
+	Changes []ApplyChange `json:"changes"`
+
+	// DryRun Whether the changes were only planned
+	DryRun bool `json:"dry_run"`
+}
+
+// CatalogItemSpec defines model for CatalogItemSpec.
+type CatalogItemSpec struct {
+	Active      *bool   `json:"active,omitempty"`
+	Description *string `json:"description,omitempty"`
+	DisplayName string  `json:"display_name"`
+
+	// Name Unique name of the catalog item
+	Name         string `json:"name"`
+	ResourceKind string `json:"resource_kind"`
+
+	// Template Parameters of the resources created from the catalog item
+	Template *map[string]string `json:"template,omitempty"`
+}
+
 // CatalogView defines model for CatalogView.
 type CatalogView struct {
 	CatalogItems *[]struct {
@@ -36,33 +122,66 @@ type CatalogView struct {
 	Total *int `json:"total,omitempty"`
 }
 
-// Error400 defines model for Error400.
-type Error400 struct {
-	// Code Error code
-	Code *int `json:"code,omitempty"`
+// Error Error envelope returned by all operations. The code is a stable,
+// machine-readable identifier of the error condition.
+type Error struct {
+	// Code Machine-readable error code
+	Code string `json:"code"`
+
+	// Details Additional information about the error
+	Details *[]map[string]interface{} `json:"details,omitempty"`
+
+	// Message Human readable description of the error
+	Message string `json:"message"`
+
+	// RequestId Identifier of the request, for correlation with server logs
+	RequestId *string `json:"request_id,omitempty"`
+}
+
+// HealthCheck defines model for HealthCheck.
+type HealthCheck struct {
+	// DurationMs Duration of the check in milliseconds
+	DurationMs *int64 `json:"duration_ms,omitempty"`
+
+	// Error Reason of the failure of the check
+	Error  *string      `json:"error,omitempty"`
+	Status HealthStatus `json:"status"`
+}
 
-	// Error Invalid input
-	Error string `json:"error"`
+// HealthReport defines model for HealthReport.
+type HealthReport struct {
+	// Checks Result of each readiness check, by component
+	Checks *map[string]HealthCheck `json:"checks,omitempty"`
+	Status HealthStatus            `json:"status"`
 }
 
-// Error404 defines model for Error404.
-type Error404 struct {
-	// Code Error code
-	Code *int `json:"code,omitempty"`
+// HealthStatus defines model for HealthStatus.
+type HealthStatus string
 
-	// Error Resource not found
-	Error string `json:"error"`
+// LogLevel defines model for LogLevel.
+type LogLevel struct {
+	// Level Minimum level of the log entries written by the service
+	Level LogLevelLevel `json:"level"`
 }
 
-// Error500 defines model for Error500.
-type Error500 struct {
-	// Code Error code
-	Code *int `json:"code,omitempty"`
+// LogLevelLevel Minimum level of the log entries written by the service
+type LogLevelLevel string
 
-	// Error Internal service error
-	Error string `json:"error"`
+// Manifest Desired state of an object. The spec of a Provider has the fields of
+// Provider, the spec of a Registration the fields of RegistrationSpec and
+// the spec of a CatalogItem the fields of CatalogItemSpec.
+type Manifest struct {
+	ApiVersion ManifestApiVersion     `json:"apiVersion"`
+	Kind       ManifestKind           `json:"kind"`
+	Spec       map[string]interface{} `json:"spec"`
 }
 
+// ManifestApiVersion defines model for Manifest.ApiVersion.
+type ManifestApiVersion string
+
+// ManifestKind defines model for Manifest.Kind.
+type ManifestKind string
+
 // Provider defines model for Provider.
 type Provider struct {
 	// ApiHost Host URL for the provider API
@@ -74,6 +193,9 @@ type Provider struct {
 	// Endpoint Endpoint of the Service Provider
 	Endpoint string `json:"endpoint"`
 
+	// Etag Entity tag of the current version of the provider
+	Etag *string `json:"etag,omitempty"`
+
 	// Id Unique identifier for the Service Provider
 	Id string `json:"id"`
 
@@ -112,13 +234,51 @@ type ProviderMetadata struct {
 	Zone string `json:"zone"`
 }
 
+// ProviderMetadataPatch Partial provider metadata, all fields are optional
+type ProviderMetadataPatch struct {
+	Labels              *map[string]string `json:"labels,omitempty"`
+	Region              *string            `json:"region,omitempty"`
+	ResourceConstraints *map[string]string `json:"resource_constraints,omitempty"`
+	Zone                *string            `json:"zone,omitempty"`
+}
+
+// ProviderPatch Partial Service Provider, all fields are optional
+type ProviderPatch struct {
+	// ApiHost Host URL for the provider API
+	ApiHost *string `json:"apiHost,omitempty"`
+
+	// Description Summary of Service Provider
+	Description *string `json:"description,omitempty"`
+
+	// Endpoint Endpoint of the Service Provider
+	Endpoint *string `json:"endpoint,omitempty"`
+
+	// Etag Only apply the update if it matches the current etag
+	Etag *string `json:"etag,omitempty"`
+
+	// Id Immutable, must not be updated
+	Id *string `json:"id,omitempty"`
+
+	// Name Name of the Service Provider
+	Name *string `json:"name,omitempty"`
+
+	// Operations Operations performed on the provider endpoint
+	Operations *[]string `json:"operations,omitempty"`
+
+	// Type Immutable, must not be updated
+	Type *string `json:"type,omitempty"`
+}
+
 // RegisteredProvider defines model for RegisteredProvider.
 type RegisteredProvider struct {
 	// CatalogItem Associated catalog item
 	CatalogItem *string `json:"catalog_item,omitempty"`
 
 	// Endpoint Provider endpoint
-	Endpoint *string           `json:"endpoint,omitempty"`
+	Endpoint *string `json:"endpoint,omitempty"`
+
+	// Etag Entity tag of the current version of the registration
+	Etag     *string           `json:"etag,omitempty"`
 	Metadata *ProviderMetadata `json:"metadata,omitempty"`
 
 	// Operations Supported operations
@@ -140,6 +300,27 @@ type RegisteredProvider struct {
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
 
+// RegistrationPatch Partial registration, all fields are optional
+type RegistrationPatch struct {
+	// Endpoint Provider endpoint URL
+	Endpoint *string `json:"endpoint,omitempty"`
+
+	// Etag Only apply the update if it matches the current etag
+	Etag *string `json:"etag,omitempty"`
+
+	// Metadata Partial provider metadata, all fields are optional
+	Metadata *ProviderMetadataPatch `json:"metadata,omitempty"`
+
+	// Operations Supported operations
+	Operations *[]string `json:"operations,omitempty"`
+
+	// ResourceKind Immutable, must not be updated
+	ResourceKind *string `json:"resource_kind,omitempty"`
+
+	// ServiceId Immutable, must not be updated
+	ServiceId *string `json:"service_id,omitempty"`
+}
+
 // RegistrationRequest defines model for RegistrationRequest.
 type RegistrationRequest struct {
 	// Endpoint Provider endpoint URL
@@ -155,6 +336,9 @@ type RegistrationRequest struct {
 
 // RegistrationResponse defines model for RegistrationResponse.
 type RegistrationResponse struct {
+	// Etag Entity tag of the registration after the update
+	Etag *string `json:"etag,omitempty"`
+
 	// Message Status message
 	Message *string `json:"message,omitempty"`
 
@@ -171,6 +355,20 @@ type RegistrationResponse struct {
 	Status *string `json:"status,omitempty"`
 }
 
+// RegistrationSpec defines model for RegistrationSpec.
+type RegistrationSpec struct {
+	// Endpoint Provider endpoint URL
+	Endpoint   string           `json:"endpoint"`
+	Metadata   ProviderMetadata `json:"metadata"`
+	Operations []string         `json:"operations"`
+
+	// ResourceKind Resource kind the provider is registered for
+	ResourceKind string `json:"resource_kind"`
+
+	// ServiceId Unique service identifier (UUID)
+	ServiceId string `json:"service_id"`
+}
+
 // RegistryView defines model for RegistryView.
 type RegistryView struct {
 	Providers *[]struct {
@@ -191,70 +389,195 @@ type RegistryView struct {
 	Total *int `json:"total,omitempty"`
 }
 
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// IfNoneMatch defines model for IfNoneMatch.
+type IfNoneMatch = string
+
+// UpdateMask defines model for UpdateMask.
+type UpdateMask = string
+
+// ApplyManifestsParams defines parameters for ApplyManifests.
+type ApplyManifestsParams struct {
+	// DryRun Report the changes without applying them
+	DryRun *bool `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+
+	// Prune Delete the managed objects missing from the manifests
+	Prune *bool `form:"prune,omitempty" json:"prune,omitempty"`
+}
+
 // ListProvidersParams defines parameters for ListProviders.
 type ListProvidersParams struct {
 	Type *string `form:"type,omitempty" json:"type,omitempty"`
 }
 
+// CreateProviderParams defines parameters for CreateProvider.
+type CreateProviderParams struct {
+	// IdempotencyKey Client generated key that makes the request safe to retry. A replay
+	// with the same key returns the original response.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetProviderParams defines parameters for GetProvider.
+type GetProviderParams struct {
+	// IfNoneMatch Return 304 Not Modified if the current etag of the resource matches
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// PatchProviderParams defines parameters for PatchProvider.
+type PatchProviderParams struct {
+	// UpdateMask Comma separated list of fields to update, or "*" for all mutable fields
+	UpdateMask *UpdateMask `form:"update_mask,omitempty" json:"update_mask,omitempty"`
+
+	// IfMatch Only apply the request if the current etag of the resource matches
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// ApplyProviderParams defines parameters for ApplyProvider.
+type ApplyProviderParams struct {
+	// IfMatch Only apply the request if the current etag of the resource matches
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// RegisterProviderParams defines parameters for RegisterProvider.
+type RegisterProviderParams struct {
+	// IfMatch Only apply the request if the current etag of the resource matches
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+
+	// IdempotencyKey Client generated key that makes the request safe to retry. A replay
+	// with the same key returns the original response.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetRegisteredProviderParams defines parameters for GetRegisteredProvider.
+type GetRegisteredProviderParams struct {
+	// IfNoneMatch Return 304 Not Modified if the current etag of the resource matches
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// PatchRegisteredProviderParams defines parameters for PatchRegisteredProvider.
+type PatchRegisteredProviderParams struct {
+	// UpdateMask Comma separated list of fields to update, or "*" for all mutable fields
+	UpdateMask *UpdateMask `form:"update_mask,omitempty" json:"update_mask,omitempty"`
+
+	// IfMatch Only apply the request if the current etag of the resource matches
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// ApplyManifestsJSONRequestBody defines body for ApplyManifests for application/json ContentType.
+type ApplyManifestsJSONRequestBody = ApplyRequest
+
+// SetLogLevelJSONRequestBody defines body for SetLogLevel for application/json ContentType.
+type SetLogLevelJSONRequestBody = LogLevel
+
 // CreateProviderJSONRequestBody defines body for CreateProvider for application/json ContentType.
 type CreateProviderJSONRequestBody = Provider
 
+// PatchProviderApplicationMergePatchPlusJSONRequestBody defines body for PatchProvider for application/merge-patch+json ContentType.
+type PatchProviderApplicationMergePatchPlusJSONRequestBody = ProviderPatch
+
 // ApplyProviderJSONRequestBody defines body for ApplyProvider for application/json ContentType.
 type ApplyProviderJSONRequestBody = Provider
 
 // RegisterProviderJSONRequestBody defines body for RegisterProvider for application/json ContentType.
 type RegisterProviderJSONRequestBody = RegistrationRequest
 
+// PatchRegisteredProviderApplicationMergePatchPlusJSONRequestBody defines body for PatchRegisteredProvider for application/merge-patch+json ContentType.
+type PatchRegisteredProviderApplicationMergePatchPlusJSONRequestBody = RegistrationPatch
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Apply manifests
+	// (POST /admin/apply)
+	ApplyManifests(w http.ResponseWriter, r *http.Request, params ApplyManifestsParams)
 	// Get service catalog
 	// (GET /admin/catalog)
 	GetCatalog(w http.ResponseWriter, r *http.Request)
+	// Get the log level
+	// (GET /admin/loglevel)
+	GetLogLevel(w http.ResponseWriter, r *http.Request)
+	// Set the log level
+	// (PUT /admin/loglevel)
+	SetLogLevel(w http.ResponseWriter, r *http.Request)
 	// Get service registry
 	// (GET /admin/registry)
 	GetRegistry(w http.ResponseWriter, r *http.Request)
 	// Health check
 	// (GET /health)
 	ListHealth(w http.ResponseWriter, r *http.Request)
+	// Liveness check
+	// (GET /livez)
+	GetLiveness(w http.ResponseWriter, r *http.Request)
 	// List all providers
 	// (GET /providers)
 	ListProviders(w http.ResponseWriter, r *http.Request, params ListProvidersParams)
 	// Create a Service Provider
 	// (POST /providers)
-	CreateProvider(w http.ResponseWriter, r *http.Request)
+	CreateProvider(w http.ResponseWriter, r *http.Request, params CreateProviderParams)
 	// Delete a service Provider
 	// (DELETE /providers/{providerId})
 	DeleteProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID)
 	// Get a provider
 	// (GET /providers/{providerId})
-	GetProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID)
+	GetProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params GetProviderParams)
+	// Partially update a Service Provider
+	// (PATCH /providers/{providerId})
+	PatchProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params PatchProviderParams)
 	// Update a Service Provider
 	// (PUT /providers/{providerId})
-	ApplyProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID)
+	ApplyProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params ApplyProviderParams)
+	// Readiness check
+	// (GET /readyz)
+	GetReadiness(w http.ResponseWriter, r *http.Request)
 	// List registered providers
 	// (GET /resource/{resourceKind}/provider)
 	ListRegisteredProviders(w http.ResponseWriter, r *http.Request, resourceKind string)
 	// Register a service provider
 	// (POST /resource/{resourceKind}/provider)
-	RegisterProvider(w http.ResponseWriter, r *http.Request, resourceKind string)
+	RegisterProvider(w http.ResponseWriter, r *http.Request, resourceKind string, params RegisterProviderParams)
 	// Unregister a provider
 	// (DELETE /resource/{resourceKind}/provider/{providerId})
 	UnregisterProvider(w http.ResponseWriter, r *http.Request, resourceKind string, providerId string)
 	// Get registered provider
 	// (GET /resource/{resourceKind}/provider/{providerId})
-	GetRegisteredProvider(w http.ResponseWriter, r *http.Request, resourceKind string, providerId string)
+	GetRegisteredProvider(w http.ResponseWriter, r *http.Request, resourceKind string, providerId string, params GetRegisteredProviderParams)
+	// Partially update a registration
+	// (PATCH /resource/{resourceKind}/provider/{providerId})
+	PatchRegisteredProvider(w http.ResponseWriter, r *http.Request, resourceKind string, providerId string, params PatchRegisteredProviderParams)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
+// Apply manifests
+// (POST /admin/apply)
+func (_ Unimplemented) ApplyManifests(w http.ResponseWriter, r *http.Request, params ApplyManifestsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get service catalog
 // (GET /admin/catalog)
 func (_ Unimplemented) GetCatalog(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get the log level
+// (GET /admin/loglevel)
+func (_ Unimplemented) GetLogLevel(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set the log level
+// (PUT /admin/loglevel)
+func (_ Unimplemented) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get service registry
 // (GET /admin/registry)
 func (_ Unimplemented) GetRegistry(w http.ResponseWriter, r *http.Request) {
@@ -267,6 +590,12 @@ func (_ Unimplemented) ListHealth(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Liveness check
+// (GET /livez)
+func (_ Unimplemented) GetLiveness(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List all providers
 // (GET /providers)
 func (_ Unimplemented) ListProviders(w http.ResponseWriter, r *http.Request, params ListProvidersParams) {
@@ -275,7 +604,7 @@ func (_ Unimplemented) ListProviders(w http.ResponseWriter, r *http.Request, par
 
 // Create a Service Provider
 // (POST /providers)
-func (_ Unimplemented) CreateProvider(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) CreateProvider(w http.ResponseWriter, r *http.Request, params CreateProviderParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -287,13 +616,25 @@ func (_ Unimplemented) DeleteProvider(w http.ResponseWriter, r *http.Request, pr
 
 // Get a provider
 // (GET /providers/{providerId})
-func (_ Unimplemented) GetProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID) {
+func (_ Unimplemented) GetProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params GetProviderParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Partially update a Service Provider
+// (PATCH /providers/{providerId})
+func (_ Unimplemented) PatchProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params PatchProviderParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
 // Update a Service Provider
 // (PUT /providers/{providerId})
-func (_ Unimplemented) ApplyProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID) {
+func (_ Unimplemented) ApplyProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params ApplyProviderParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Readiness check
+// (GET /readyz)
+func (_ Unimplemented) GetReadiness(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -305,7 +646,7 @@ func (_ Unimplemented) ListRegisteredProviders(w http.ResponseWriter, r *http.Re
 
 // Register a service provider
 // (POST /resource/{resourceKind}/provider)
-func (_ Unimplemented) RegisterProvider(w http.ResponseWriter, r *http.Request, resourceKind string) {
+func (_ Unimplemented) RegisterProvider(w http.ResponseWriter, r *http.Request, resourceKind string, params RegisterProviderParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -317,7 +658,13 @@ func (_ Unimplemented) UnregisterProvider(w http.ResponseWriter, r *http.Request
 
 // Get registered provider
 // (GET /resource/{resourceKind}/provider/{providerId})
-func (_ Unimplemented) GetRegisteredProvider(w http.ResponseWriter, r *http.Request, resourceKind string, providerId string) {
+func (_ Unimplemented) GetRegisteredProvider(w http.ResponseWriter, r *http.Request, resourceKind string, providerId string, params GetRegisteredProviderParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Partially update a registration
+// (PATCH /resource/{resourceKind}/provider/{providerId})
+func (_ Unimplemented) PatchRegisteredProvider(w http.ResponseWriter, r *http.Request, resourceKind string, providerId string, params PatchRegisteredProviderParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -330,6 +677,42 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// ApplyManifests operation middleware
+func (siw *ServerInterfaceWrapper) ApplyManifests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ApplyManifestsParams
+
+	// ------------- Optional query parameter "dry_run" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dry_run", r.URL.Query(), &params.DryRun)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dry_run", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "prune" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "prune", r.URL.Query(), &params.Prune)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "prune", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ApplyManifests(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetCatalog operation middleware
 func (siw *ServerInterfaceWrapper) GetCatalog(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -345,6 +728,36 @@ func (siw *ServerInterfaceWrapper) GetCatalog(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// GetLogLevel operation middleware
+func (siw *ServerInterfaceWrapper) GetLogLevel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLogLevel(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SetLogLevel operation middleware
+func (siw *ServerInterfaceWrapper) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetLogLevel(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetRegistry operation middleware
 func (siw *ServerInterfaceWrapper) GetRegistry(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -375,6 +788,21 @@ func (siw *ServerInterfaceWrapper) ListHealth(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// GetLiveness operation middleware
+func (siw *ServerInterfaceWrapper) GetLiveness(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLiveness(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // ListProviders operation middleware
 func (siw *ServerInterfaceWrapper) ListProviders(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -407,8 +835,34 @@ func (siw *ServerInterfaceWrapper) ListProviders(w http.ResponseWriter, r *http.
 func (siw *ServerInterfaceWrapper) CreateProvider(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateProviderParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateProvider(w, r)
+		siw.Handler.CreateProvider(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -459,8 +913,32 @@ func (siw *ServerInterfaceWrapper) GetProvider(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProviderParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetProvider(w, r, providerId)
+		siw.Handler.GetProvider(w, r, providerId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -470,8 +948,8 @@ func (siw *ServerInterfaceWrapper) GetProvider(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ApplyProvider operation middleware
-func (siw *ServerInterfaceWrapper) ApplyProvider(w http.ResponseWriter, r *http.Request) {
+// PatchProvider operation middleware
+func (siw *ServerInterfaceWrapper) PatchProvider(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -485,34 +963,40 @@ func (siw *ServerInterfaceWrapper) ApplyProvider(w http.ResponseWriter, r *http.
 		return
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ApplyProvider(w, r, providerId)
-	}))
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchProviderParams
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	// ------------- Optional query parameter "update_mask" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "update_mask", r.URL.Query(), &params.UpdateMask)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "update_mask", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
-}
+	headers := r.Header
 
-// ListRegisteredProviders operation middleware
-func (siw *ServerInterfaceWrapper) ListRegisteredProviders(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
 
-	var err error
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
 
-	// ------------- Path parameter "resourceKind" -------------
-	var resourceKind string
+		params.IfMatch = &IfMatch
 
-	err = runtime.BindStyledParameterWithOptions("simple", "resourceKind", chi.URLParam(r, "resourceKind"), &resourceKind, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "resourceKind", Err: err})
-		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListRegisteredProviders(w, r, resourceKind)
+		siw.Handler.PatchProvider(w, r, providerId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -522,24 +1006,158 @@ func (siw *ServerInterfaceWrapper) ListRegisteredProviders(w http.ResponseWriter
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// RegisterProvider operation middleware
-func (siw *ServerInterfaceWrapper) RegisterProvider(w http.ResponseWriter, r *http.Request) {
+// ApplyProvider operation middleware
+func (siw *ServerInterfaceWrapper) ApplyProvider(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "resourceKind" -------------
-	var resourceKind string
+	// ------------- Path parameter "providerId" -------------
+	var providerId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "resourceKind", chi.URLParam(r, "resourceKind"), &resourceKind, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "providerId", chi.URLParam(r, "providerId"), &providerId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "resourceKind", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "providerId", Err: err})
 		return
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RegisterProvider(w, r, resourceKind)
-	}))
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ApplyProviderParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ApplyProvider(w, r, providerId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetReadiness operation middleware
+func (siw *ServerInterfaceWrapper) GetReadiness(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetReadiness(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListRegisteredProviders operation middleware
+func (siw *ServerInterfaceWrapper) ListRegisteredProviders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "resourceKind" -------------
+	var resourceKind string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "resourceKind", chi.URLParam(r, "resourceKind"), &resourceKind, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "resourceKind", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListRegisteredProviders(w, r, resourceKind)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RegisterProvider operation middleware
+func (siw *ServerInterfaceWrapper) RegisterProvider(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "resourceKind" -------------
+	var resourceKind string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "resourceKind", chi.URLParam(r, "resourceKind"), &resourceKind, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "resourceKind", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RegisterProviderParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RegisterProvider(w, r, resourceKind, params)
+	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		handler = middleware(handler)
@@ -607,8 +1225,99 @@ func (siw *ServerInterfaceWrapper) GetRegisteredProvider(w http.ResponseWriter,
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRegisteredProviderParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetRegisteredProvider(w, r, resourceKind, providerId)
+		siw.Handler.GetRegisteredProvider(w, r, resourceKind, providerId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PatchRegisteredProvider operation middleware
+func (siw *ServerInterfaceWrapper) PatchRegisteredProvider(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "resourceKind" -------------
+	var resourceKind string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "resourceKind", chi.URLParam(r, "resourceKind"), &resourceKind, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "resourceKind", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "providerId" -------------
+	var providerId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "providerId", chi.URLParam(r, "providerId"), &providerId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "providerId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchRegisteredProviderParams
+
+	// ------------- Optional query parameter "update_mask" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "update_mask", r.URL.Query(), &params.UpdateMask)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "update_mask", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchRegisteredProvider(w, r, resourceKind, providerId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -731,15 +1440,27 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/apply", wrapper.ApplyManifests)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/admin/catalog", wrapper.GetCatalog)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/loglevel", wrapper.GetLogLevel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/loglevel", wrapper.SetLogLevel)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/admin/registry", wrapper.GetRegistry)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/health", wrapper.ListHealth)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/livez", wrapper.GetLiveness)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/providers", wrapper.ListProviders)
 	})
@@ -752,9 +1473,15 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/providers/{providerId}", wrapper.GetProvider)
 	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/providers/{providerId}", wrapper.PatchProvider)
+	})
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/providers/{providerId}", wrapper.ApplyProvider)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/readyz", wrapper.GetReadiness)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/resource/{resourceKind}/provider", wrapper.ListRegisteredProviders)
 	})
@@ -767,10 +1494,76 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/resource/{resourceKind}/provider/{providerId}", wrapper.GetRegisteredProvider)
 	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/resource/{resourceKind}/provider/{providerId}", wrapper.PatchRegisteredProvider)
+	})
 
 	return r
 }
 
+type ApplyManifestsRequestObject struct {
+	Params ApplyManifestsParams
+	Body   *ApplyManifestsJSONRequestBody
+}
+
+type ApplyManifestsResponseObject interface {
+	VisitApplyManifestsResponse(w http.ResponseWriter) error
+}
+
+type ApplyManifests200JSONResponse ApplyResult
+
+func (response ApplyManifests200JSONResponse) VisitApplyManifestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApplyManifests400JSONResponse Error
+
+func (response ApplyManifests400JSONResponse) VisitApplyManifestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApplyManifests409JSONResponse Error
+
+func (response ApplyManifests409JSONResponse) VisitApplyManifestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApplyManifests422JSONResponse Error
+
+func (response ApplyManifests422JSONResponse) VisitApplyManifestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApplyManifests500JSONResponse Error
+
+func (response ApplyManifests500JSONResponse) VisitApplyManifestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApplyManifests502JSONResponse Error
+
+func (response ApplyManifests502JSONResponse) VisitApplyManifestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(502)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetCatalogRequestObject struct {
 }
 
@@ -787,7 +1580,7 @@ func (response GetCatalog200JSONResponse) VisitGetCatalogResponse(w http.Respons
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetCatalog500JSONResponse Error500
+type GetCatalog500JSONResponse Error
 
 func (response GetCatalog500JSONResponse) VisitGetCatalogResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
@@ -796,6 +1589,48 @@ func (response GetCatalog500JSONResponse) VisitGetCatalogResponse(w http.Respons
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetLogLevelRequestObject struct {
+}
+
+type GetLogLevelResponseObject interface {
+	VisitGetLogLevelResponse(w http.ResponseWriter) error
+}
+
+type GetLogLevel200JSONResponse LogLevel
+
+func (response GetLogLevel200JSONResponse) VisitGetLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetLogLevelRequestObject struct {
+	Body *SetLogLevelJSONRequestBody
+}
+
+type SetLogLevelResponseObject interface {
+	VisitSetLogLevelResponse(w http.ResponseWriter) error
+}
+
+type SetLogLevel200JSONResponse LogLevel
+
+func (response SetLogLevel200JSONResponse) VisitSetLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetLogLevel400JSONResponse Error
+
+func (response SetLogLevel400JSONResponse) VisitSetLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetRegistryRequestObject struct {
 }
 
@@ -812,7 +1647,7 @@ func (response GetRegistry200JSONResponse) VisitGetRegistryResponse(w http.Respo
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRegistry500JSONResponse Error500
+type GetRegistry500JSONResponse Error
 
 func (response GetRegistry500JSONResponse) VisitGetRegistryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
@@ -836,6 +1671,22 @@ func (response ListHealth200Response) VisitListHealthResponse(w http.ResponseWri
 	return nil
 }
 
+type GetLivenessRequestObject struct {
+}
+
+type GetLivenessResponseObject interface {
+	VisitGetLivenessResponse(w http.ResponseWriter) error
+}
+
+type GetLiveness200JSONResponse HealthReport
+
+func (response GetLiveness200JSONResponse) VisitGetLivenessResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type ListProvidersRequestObject struct {
 	Params ListProvidersParams
 }
@@ -853,7 +1704,7 @@ func (response ListProviders200JSONResponse) VisitListProvidersResponse(w http.R
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListProviders400JSONResponse Error400
+type ListProviders400JSONResponse Error
 
 func (response ListProviders400JSONResponse) VisitListProvidersResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
@@ -862,7 +1713,7 @@ func (response ListProviders400JSONResponse) VisitListProvidersResponse(w http.R
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListProviders500JSONResponse Error500
+type ListProviders500JSONResponse Error
 
 func (response ListProviders500JSONResponse) VisitListProvidersResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
@@ -872,7 +1723,8 @@ func (response ListProviders500JSONResponse) VisitListProvidersResponse(w http.R
 }
 
 type CreateProviderRequestObject struct {
-	Body *CreateProviderJSONRequestBody
+	Params CreateProviderParams
+	Body   *CreateProviderJSONRequestBody
 }
 
 type CreateProviderResponseObject interface {
@@ -888,7 +1740,7 @@ func (response CreateProvider201JSONResponse) VisitCreateProviderResponse(w http
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateProvider400JSONResponse Error400
+type CreateProvider400JSONResponse Error
 
 func (response CreateProvider400JSONResponse) VisitCreateProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
@@ -897,7 +1749,25 @@ func (response CreateProvider400JSONResponse) VisitCreateProviderResponse(w http
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateProvider500JSONResponse Error500
+type CreateProvider409JSONResponse Error
+
+func (response CreateProvider409JSONResponse) VisitCreateProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProvider422JSONResponse Error
+
+func (response CreateProvider422JSONResponse) VisitCreateProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProvider500JSONResponse Error
 
 func (response CreateProvider500JSONResponse) VisitCreateProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
@@ -906,6 +1776,15 @@ func (response CreateProvider500JSONResponse) VisitCreateProviderResponse(w http
 	return json.NewEncoder(w).Encode(response)
 }
 
+type CreateProvider502JSONResponse Error
+
+func (response CreateProvider502JSONResponse) VisitCreateProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(502)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type DeleteProviderRequestObject struct {
 	ProviderId openapi_types.UUID `json:"providerId"`
 }
@@ -923,7 +1802,7 @@ func (response DeleteProvider204JSONResponse) VisitDeleteProviderResponse(w http
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteProvider400JSONResponse Error400
+type DeleteProvider400JSONResponse Error
 
 func (response DeleteProvider400JSONResponse) VisitDeleteProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
@@ -932,7 +1811,7 @@ func (response DeleteProvider400JSONResponse) VisitDeleteProviderResponse(w http
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteProvider404JSONResponse Error404
+type DeleteProvider404JSONResponse Error
 
 func (response DeleteProvider404JSONResponse) VisitDeleteProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
@@ -941,7 +1820,7 @@ func (response DeleteProvider404JSONResponse) VisitDeleteProviderResponse(w http
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteProvider500JSONResponse Error500
+type DeleteProvider500JSONResponse Error
 
 func (response DeleteProvider500JSONResponse) VisitDeleteProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
@@ -952,22 +1831,45 @@ func (response DeleteProvider500JSONResponse) VisitDeleteProviderResponse(w http
 
 type GetProviderRequestObject struct {
 	ProviderId openapi_types.UUID `json:"providerId"`
+	Params     GetProviderParams
 }
 
 type GetProviderResponseObject interface {
 	VisitGetProviderResponse(w http.ResponseWriter) error
 }
 
-type GetProvider200JSONResponse Provider
+type GetProvider200ResponseHeaders struct {
+	ETag string
+}
+
+type GetProvider200JSONResponse struct {
+	Body    Provider
+	Headers GetProvider200ResponseHeaders
+}
 
 func (response GetProvider200JSONResponse) VisitGetProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetProvider304ResponseHeaders struct {
+	ETag string
 }
 
-type GetProvider400JSONResponse Error400
+type GetProvider304Response struct {
+	Headers GetProvider304ResponseHeaders
+}
+
+func (response GetProvider304Response) VisitGetProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(304)
+	return nil
+}
+
+type GetProvider400JSONResponse Error
 
 func (response GetProvider400JSONResponse) VisitGetProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
@@ -976,7 +1878,7 @@ func (response GetProvider400JSONResponse) VisitGetProviderResponse(w http.Respo
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetProvider404JSONResponse Error404
+type GetProvider404JSONResponse Error
 
 func (response GetProvider404JSONResponse) VisitGetProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
@@ -985,213 +1887,544 @@ func (response GetProvider404JSONResponse) VisitGetProviderResponse(w http.Respo
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetProvider500JSONResponse Error500
+type GetProvider500JSONResponse Error
 
 func (response GetProvider500JSONResponse) VisitGetProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchProviderRequestObject struct {
+	ProviderId openapi_types.UUID `json:"providerId"`
+	Params     PatchProviderParams
+	Body       *PatchProviderApplicationMergePatchPlusJSONRequestBody
+}
+
+type PatchProviderResponseObject interface {
+	VisitPatchProviderResponse(w http.ResponseWriter) error
+}
+
+type PatchProvider200ResponseHeaders struct {
+	ETag string
+}
+
+type PatchProvider200JSONResponse struct {
+	Body    Provider
+	Headers PatchProvider200ResponseHeaders
+}
+
+func (response PatchProvider200JSONResponse) VisitPatchProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PatchProvider400JSONResponse Error
+
+func (response PatchProvider400JSONResponse) VisitPatchProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchProvider404JSONResponse Error
+
+func (response PatchProvider404JSONResponse) VisitPatchProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchProvider412JSONResponse Error
+
+func (response PatchProvider412JSONResponse) VisitPatchProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchProvider500JSONResponse Error
+
+func (response PatchProvider500JSONResponse) VisitPatchProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchProvider502JSONResponse Error
+
+func (response PatchProvider502JSONResponse) VisitPatchProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(502)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApplyProviderRequestObject struct {
+	ProviderId openapi_types.UUID `json:"providerId"`
+	Params     ApplyProviderParams
+	Body       *ApplyProviderJSONRequestBody
+}
+
+type ApplyProviderResponseObject interface {
+	VisitApplyProviderResponse(w http.ResponseWriter) error
+}
+
+type ApplyProvider200ResponseHeaders struct {
+	ETag string
+}
+
+type ApplyProvider200JSONResponse struct {
+	Body    Provider
+	Headers ApplyProvider200ResponseHeaders
+}
+
+func (response ApplyProvider200JSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ApplyProvider400JSONResponse Error
+
+func (response ApplyProvider400JSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApplyProvider404JSONResponse Error
+
+func (response ApplyProvider404JSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApplyProvider412JSONResponse Error
+
+func (response ApplyProvider412JSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApplyProvider500JSONResponse Error
+
+func (response ApplyProvider500JSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApplyProvider502JSONResponse Error
+
+func (response ApplyProvider502JSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(502)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetReadinessRequestObject struct {
+}
+
+type GetReadinessResponseObject interface {
+	VisitGetReadinessResponse(w http.ResponseWriter) error
+}
+
+type GetReadiness200JSONResponse HealthReport
+
+func (response GetReadiness200JSONResponse) VisitGetReadinessResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetReadiness503JSONResponse HealthReport
+
+func (response GetReadiness503JSONResponse) VisitGetReadinessResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRegisteredProvidersRequestObject struct {
+	ResourceKind string `json:"resourceKind"`
+}
+
+type ListRegisteredProvidersResponseObject interface {
+	VisitListRegisteredProvidersResponse(w http.ResponseWriter) error
+}
+
+type ListRegisteredProviders200JSONResponse []RegisteredProvider
+
+func (response ListRegisteredProviders200JSONResponse) VisitListRegisteredProvidersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRegisteredProviders400JSONResponse Error
+
+func (response ListRegisteredProviders400JSONResponse) VisitListRegisteredProvidersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRegisteredProviders422JSONResponse Error
+
+func (response ListRegisteredProviders422JSONResponse) VisitListRegisteredProvidersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRegisteredProviders500JSONResponse Error
+
+func (response ListRegisteredProviders500JSONResponse) VisitListRegisteredProvidersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterProviderRequestObject struct {
+	ResourceKind string `json:"resourceKind"`
+	Params       RegisterProviderParams
+	Body         *RegisterProviderJSONRequestBody
+}
+
+type RegisterProviderResponseObject interface {
+	VisitRegisterProviderResponse(w http.ResponseWriter) error
+}
+
+type RegisterProvider200ResponseHeaders struct {
+	ETag string
+}
+
+type RegisterProvider200JSONResponse struct {
+	Body    RegistrationResponse
+	Headers RegisterProvider200ResponseHeaders
+}
+
+func (response RegisterProvider200JSONResponse) VisitRegisterProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RegisterProvider400JSONResponse Error
+
+func (response RegisterProvider400JSONResponse) VisitRegisterProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterProvider409JSONResponse Error
+
+func (response RegisterProvider409JSONResponse) VisitRegisterProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterProvider412JSONResponse Error
+
+func (response RegisterProvider412JSONResponse) VisitRegisterProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterProvider422JSONResponse Error
+
+func (response RegisterProvider422JSONResponse) VisitRegisterProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterProvider500JSONResponse Error
+
+func (response RegisterProvider500JSONResponse) VisitRegisterProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterProvider502JSONResponse Error
+
+func (response RegisterProvider502JSONResponse) VisitRegisterProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(502)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnregisterProviderRequestObject struct {
+	ResourceKind string `json:"resourceKind"`
+	ProviderId   string `json:"providerId"`
+}
+
+type UnregisterProviderResponseObject interface {
+	VisitUnregisterProviderResponse(w http.ResponseWriter) error
 }
 
-type ApplyProviderRequestObject struct {
-	ProviderId openapi_types.UUID `json:"providerId"`
-	Body       *ApplyProviderJSONRequestBody
+type UnregisterProvider204Response struct {
 }
 
-type ApplyProviderResponseObject interface {
-	VisitApplyProviderResponse(w http.ResponseWriter) error
+func (response UnregisterProvider204Response) VisitUnregisterProviderResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
 }
 
-type ApplyProvider201JSONResponse Provider
+type UnregisterProvider400JSONResponse Error
 
-func (response ApplyProvider201JSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
+func (response UnregisterProvider400JSONResponse) VisitUnregisterProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ApplyProvider400JSONResponse Error400
+type UnregisterProvider404JSONResponse Error
 
-func (response ApplyProvider400JSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
+func (response UnregisterProvider404JSONResponse) VisitUnregisterProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ApplyProvider404JSONResponse Error404
+type UnregisterProvider422JSONResponse Error
 
-func (response ApplyProvider404JSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
+func (response UnregisterProvider422JSONResponse) VisitUnregisterProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(422)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ApplyProvider500JSONResponse Error500
+type UnregisterProvider500JSONResponse Error
 
-func (response ApplyProvider500JSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
+func (response UnregisterProvider500JSONResponse) VisitUnregisterProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListRegisteredProvidersRequestObject struct {
+type GetRegisteredProviderRequestObject struct {
 	ResourceKind string `json:"resourceKind"`
+	ProviderId   string `json:"providerId"`
+	Params       GetRegisteredProviderParams
 }
 
-type ListRegisteredProvidersResponseObject interface {
-	VisitListRegisteredProvidersResponse(w http.ResponseWriter) error
+type GetRegisteredProviderResponseObject interface {
+	VisitGetRegisteredProviderResponse(w http.ResponseWriter) error
 }
 
-type ListRegisteredProviders200JSONResponse []RegisteredProvider
+type GetRegisteredProvider200ResponseHeaders struct {
+	ETag string
+}
 
-func (response ListRegisteredProviders200JSONResponse) VisitListRegisteredProvidersResponse(w http.ResponseWriter) error {
+type GetRegisteredProvider200JSONResponse struct {
+	Body    RegisteredProvider
+	Headers GetRegisteredProvider200ResponseHeaders
+}
+
+func (response GetRegisteredProvider200JSONResponse) VisitGetRegisteredProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type ListRegisteredProviders400JSONResponse Error400
+type GetRegisteredProvider304ResponseHeaders struct {
+	ETag string
+}
 
-func (response ListRegisteredProviders400JSONResponse) VisitListRegisteredProvidersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+type GetRegisteredProvider304Response struct {
+	Headers GetRegisteredProvider304ResponseHeaders
+}
 
-	return json.NewEncoder(w).Encode(response)
+func (response GetRegisteredProvider304Response) VisitGetRegisteredProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(304)
+	return nil
 }
 
-type ListRegisteredProviders500JSONResponse Error500
+type GetRegisteredProvider400JSONResponse Error
 
-func (response ListRegisteredProviders500JSONResponse) VisitListRegisteredProvidersResponse(w http.ResponseWriter) error {
+func (response GetRegisteredProvider400JSONResponse) VisitGetRegisteredProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RegisterProviderRequestObject struct {
-	ResourceKind string `json:"resourceKind"`
-	Body         *RegisterProviderJSONRequestBody
-}
-
-type RegisterProviderResponseObject interface {
-	VisitRegisterProviderResponse(w http.ResponseWriter) error
-}
-
-type RegisterProvider200JSONResponse RegistrationResponse
+type GetRegisteredProvider404JSONResponse Error
 
-func (response RegisterProvider200JSONResponse) VisitRegisterProviderResponse(w http.ResponseWriter) error {
+func (response GetRegisteredProvider404JSONResponse) VisitGetRegisteredProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RegisterProvider400JSONResponse Error400
+type GetRegisteredProvider422JSONResponse Error
 
-func (response RegisterProvider400JSONResponse) VisitRegisterProviderResponse(w http.ResponseWriter) error {
+func (response GetRegisteredProvider422JSONResponse) VisitGetRegisteredProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(422)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RegisterProvider500JSONResponse Error500
+type GetRegisteredProvider500JSONResponse Error
 
-func (response RegisterProvider500JSONResponse) VisitRegisterProviderResponse(w http.ResponseWriter) error {
+func (response GetRegisteredProvider500JSONResponse) VisitGetRegisteredProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UnregisterProviderRequestObject struct {
+type PatchRegisteredProviderRequestObject struct {
 	ResourceKind string `json:"resourceKind"`
 	ProviderId   string `json:"providerId"`
+	Params       PatchRegisteredProviderParams
+	Body         *PatchRegisteredProviderApplicationMergePatchPlusJSONRequestBody
 }
 
-type UnregisterProviderResponseObject interface {
-	VisitUnregisterProviderResponse(w http.ResponseWriter) error
+type PatchRegisteredProviderResponseObject interface {
+	VisitPatchRegisteredProviderResponse(w http.ResponseWriter) error
 }
 
-type UnregisterProvider204Response struct {
+type PatchRegisteredProvider200ResponseHeaders struct {
+	ETag string
 }
 
-func (response UnregisterProvider204Response) VisitUnregisterProviderResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
+type PatchRegisteredProvider200JSONResponse struct {
+	Body    RegisteredProvider
+	Headers PatchRegisteredProvider200ResponseHeaders
+}
+
+func (response PatchRegisteredProvider200JSONResponse) VisitPatchRegisteredProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type UnregisterProvider404JSONResponse Error404
+type PatchRegisteredProvider400JSONResponse Error
 
-func (response UnregisterProvider404JSONResponse) VisitUnregisterProviderResponse(w http.ResponseWriter) error {
+func (response PatchRegisteredProvider400JSONResponse) VisitPatchRegisteredProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UnregisterProvider500JSONResponse Error500
+type PatchRegisteredProvider404JSONResponse Error
 
-func (response UnregisterProvider500JSONResponse) VisitUnregisterProviderResponse(w http.ResponseWriter) error {
+func (response PatchRegisteredProvider404JSONResponse) VisitPatchRegisteredProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRegisteredProviderRequestObject struct {
-	ResourceKind string `json:"resourceKind"`
-	ProviderId   string `json:"providerId"`
-}
+type PatchRegisteredProvider412JSONResponse Error
 
-type GetRegisteredProviderResponseObject interface {
-	VisitGetRegisteredProviderResponse(w http.ResponseWriter) error
+func (response PatchRegisteredProvider412JSONResponse) VisitPatchRegisteredProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRegisteredProvider200JSONResponse RegisteredProvider
+type PatchRegisteredProvider422JSONResponse Error
 
-func (response GetRegisteredProvider200JSONResponse) VisitGetRegisteredProviderResponse(w http.ResponseWriter) error {
+func (response PatchRegisteredProvider422JSONResponse) VisitPatchRegisteredProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(422)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRegisteredProvider404JSONResponse Error404
+type PatchRegisteredProvider500JSONResponse Error
 
-func (response GetRegisteredProvider404JSONResponse) VisitGetRegisteredProviderResponse(w http.ResponseWriter) error {
+func (response PatchRegisteredProvider500JSONResponse) VisitPatchRegisteredProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRegisteredProvider500JSONResponse Error500
+type PatchRegisteredProvider502JSONResponse Error
 
-func (response GetRegisteredProvider500JSONResponse) VisitGetRegisteredProviderResponse(w http.ResponseWriter) error {
+func (response PatchRegisteredProvider502JSONResponse) VisitPatchRegisteredProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(502)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// Apply manifests
+	// (POST /admin/apply)
+	ApplyManifests(ctx context.Context, request ApplyManifestsRequestObject) (ApplyManifestsResponseObject, error)
 	// Get service catalog
 	// (GET /admin/catalog)
 	GetCatalog(ctx context.Context, request GetCatalogRequestObject) (GetCatalogResponseObject, error)
+	// Get the log level
+	// (GET /admin/loglevel)
+	GetLogLevel(ctx context.Context, request GetLogLevelRequestObject) (GetLogLevelResponseObject, error)
+	// Set the log level
+	// (PUT /admin/loglevel)
+	SetLogLevel(ctx context.Context, request SetLogLevelRequestObject) (SetLogLevelResponseObject, error)
 	// Get service registry
 	// (GET /admin/registry)
 	GetRegistry(ctx context.Context, request GetRegistryRequestObject) (GetRegistryResponseObject, error)
 	// Health check
 	// (GET /health)
 	ListHealth(ctx context.Context, request ListHealthRequestObject) (ListHealthResponseObject, error)
+	// Liveness check
+	// (GET /livez)
+	GetLiveness(ctx context.Context, request GetLivenessRequestObject) (GetLivenessResponseObject, error)
 	// List all providers
 	// (GET /providers)
 	ListProviders(ctx context.Context, request ListProvidersRequestObject) (ListProvidersResponseObject, error)
@@ -1204,9 +2437,15 @@ type StrictServerInterface interface {
 	// Get a provider
 	// (GET /providers/{providerId})
 	GetProvider(ctx context.Context, request GetProviderRequestObject) (GetProviderResponseObject, error)
+	// Partially update a Service Provider
+	// (PATCH /providers/{providerId})
+	PatchProvider(ctx context.Context, request PatchProviderRequestObject) (PatchProviderResponseObject, error)
 	// Update a Service Provider
 	// (PUT /providers/{providerId})
 	ApplyProvider(ctx context.Context, request ApplyProviderRequestObject) (ApplyProviderResponseObject, error)
+	// Readiness check
+	// (GET /readyz)
+	GetReadiness(ctx context.Context, request GetReadinessRequestObject) (GetReadinessResponseObject, error)
 	// List registered providers
 	// (GET /resource/{resourceKind}/provider)
 	ListRegisteredProviders(ctx context.Context, request ListRegisteredProvidersRequestObject) (ListRegisteredProvidersResponseObject, error)
@@ -1219,6 +2458,9 @@ type StrictServerInterface interface {
 	// Get registered provider
 	// (GET /resource/{resourceKind}/provider/{providerId})
 	GetRegisteredProvider(ctx context.Context, request GetRegisteredProviderRequestObject) (GetRegisteredProviderResponseObject, error)
+	// Partially update a registration
+	// (PATCH /resource/{resourceKind}/provider/{providerId})
+	PatchRegisteredProvider(ctx context.Context, request PatchRegisteredProviderRequestObject) (PatchRegisteredProviderResponseObject, error)
 }
 
 type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
@@ -1250,6 +2492,39 @@ type strictHandler struct {
 	options     StrictHTTPServerOptions
 }
 
+// ApplyManifests operation middleware
+func (sh *strictHandler) ApplyManifests(w http.ResponseWriter, r *http.Request, params ApplyManifestsParams) {
+	var request ApplyManifestsRequestObject
+
+	request.Params = params
+
+	var body ApplyManifestsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ApplyManifests(ctx, request.(ApplyManifestsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ApplyManifests")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ApplyManifestsResponseObject); ok {
+		if err := validResponse.VisitApplyManifestsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetCatalog operation middleware
 func (sh *strictHandler) GetCatalog(w http.ResponseWriter, r *http.Request) {
 	var request GetCatalogRequestObject
@@ -1274,6 +2549,61 @@ func (sh *strictHandler) GetCatalog(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetLogLevel operation middleware
+func (sh *strictHandler) GetLogLevel(w http.ResponseWriter, r *http.Request) {
+	var request GetLogLevelRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetLogLevel(ctx, request.(GetLogLevelRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetLogLevel")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetLogLevelResponseObject); ok {
+		if err := validResponse.VisitGetLogLevelResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetLogLevel operation middleware
+func (sh *strictHandler) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	var request SetLogLevelRequestObject
+
+	var body SetLogLevelJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetLogLevel(ctx, request.(SetLogLevelRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetLogLevel")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetLogLevelResponseObject); ok {
+		if err := validResponse.VisitSetLogLevelResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetRegistry operation middleware
 func (sh *strictHandler) GetRegistry(w http.ResponseWriter, r *http.Request) {
 	var request GetRegistryRequestObject
@@ -1322,6 +2652,30 @@ func (sh *strictHandler) ListHealth(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetLiveness operation middleware
+func (sh *strictHandler) GetLiveness(w http.ResponseWriter, r *http.Request) {
+	var request GetLivenessRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetLiveness(ctx, request.(GetLivenessRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetLiveness")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetLivenessResponseObject); ok {
+		if err := validResponse.VisitGetLivenessResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // ListProviders operation middleware
 func (sh *strictHandler) ListProviders(w http.ResponseWriter, r *http.Request, params ListProvidersParams) {
 	var request ListProvidersRequestObject
@@ -1349,9 +2703,11 @@ func (sh *strictHandler) ListProviders(w http.ResponseWriter, r *http.Request, p
 }
 
 // CreateProvider operation middleware
-func (sh *strictHandler) CreateProvider(w http.ResponseWriter, r *http.Request) {
+func (sh *strictHandler) CreateProvider(w http.ResponseWriter, r *http.Request, params CreateProviderParams) {
 	var request CreateProviderRequestObject
 
+	request.Params = params
+
 	var body CreateProviderJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
@@ -1406,10 +2762,11 @@ func (sh *strictHandler) DeleteProvider(w http.ResponseWriter, r *http.Request,
 }
 
 // GetProvider operation middleware
-func (sh *strictHandler) GetProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID) {
+func (sh *strictHandler) GetProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params GetProviderParams) {
 	var request GetProviderRequestObject
 
 	request.ProviderId = providerId
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.GetProvider(ctx, request.(GetProviderRequestObject))
@@ -1431,11 +2788,46 @@ func (sh *strictHandler) GetProvider(w http.ResponseWriter, r *http.Request, pro
 	}
 }
 
+// PatchProvider operation middleware
+func (sh *strictHandler) PatchProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params PatchProviderParams) {
+	var request PatchProviderRequestObject
+
+	request.ProviderId = providerId
+	request.Params = params
+
+	var body PatchProviderApplicationMergePatchPlusJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchProvider(ctx, request.(PatchProviderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchProvider")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PatchProviderResponseObject); ok {
+		if err := validResponse.VisitPatchProviderResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // ApplyProvider operation middleware
-func (sh *strictHandler) ApplyProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID) {
+func (sh *strictHandler) ApplyProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params ApplyProviderParams) {
 	var request ApplyProviderRequestObject
 
 	request.ProviderId = providerId
+	request.Params = params
 
 	var body ApplyProviderJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -1464,6 +2856,30 @@ func (sh *strictHandler) ApplyProvider(w http.ResponseWriter, r *http.Request, p
 	}
 }
 
+// GetReadiness operation middleware
+func (sh *strictHandler) GetReadiness(w http.ResponseWriter, r *http.Request) {
+	var request GetReadinessRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetReadiness(ctx, request.(GetReadinessRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetReadiness")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetReadinessResponseObject); ok {
+		if err := validResponse.VisitGetReadinessResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // ListRegisteredProviders operation middleware
 func (sh *strictHandler) ListRegisteredProviders(w http.ResponseWriter, r *http.Request, resourceKind string) {
 	var request ListRegisteredProvidersRequestObject
@@ -1491,10 +2907,11 @@ func (sh *strictHandler) ListRegisteredProviders(w http.ResponseWriter, r *http.
 }
 
 // RegisterProvider operation middleware
-func (sh *strictHandler) RegisterProvider(w http.ResponseWriter, r *http.Request, resourceKind string) {
+func (sh *strictHandler) RegisterProvider(w http.ResponseWriter, r *http.Request, resourceKind string, params RegisterProviderParams) {
 	var request RegisterProviderRequestObject
 
 	request.ResourceKind = resourceKind
+	request.Params = params
 
 	var body RegisterProviderJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -1551,11 +2968,12 @@ func (sh *strictHandler) UnregisterProvider(w http.ResponseWriter, r *http.Reque
 }
 
 // GetRegisteredProvider operation middleware
-func (sh *strictHandler) GetRegisteredProvider(w http.ResponseWriter, r *http.Request, resourceKind string, providerId string) {
+func (sh *strictHandler) GetRegisteredProvider(w http.ResponseWriter, r *http.Request, resourceKind string, providerId string, params GetRegisteredProviderParams) {
 	var request GetRegisteredProviderRequestObject
 
 	request.ResourceKind = resourceKind
 	request.ProviderId = providerId
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.GetRegisteredProvider(ctx, request.(GetRegisteredProviderRequestObject))
@@ -1576,3 +2994,38 @@ func (sh *strictHandler) GetRegisteredProvider(w http.ResponseWriter, r *http.Re
 		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
 	}
 }
+
+// PatchRegisteredProvider operation middleware
+func (sh *strictHandler) PatchRegisteredProvider(w http.ResponseWriter, r *http.Request, resourceKind string, providerId string, params PatchRegisteredProviderParams) {
+	var request PatchRegisteredProviderRequestObject
+
+	request.ResourceKind = resourceKind
+	request.ProviderId = providerId
+	request.Params = params
+
+	var body PatchRegisteredProviderApplicationMergePatchPlusJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchRegisteredProvider(ctx, request.(PatchRegisteredProviderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchRegisteredProvider")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PatchRegisteredProviderResponseObject); ok {
+		if err := validResponse.VisitPatchRegisteredProviderResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
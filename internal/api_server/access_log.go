@@ -0,0 +1,34 @@
+package apiserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.uber.org/zap"
+)
+
+// accessLogMiddleware logs every request once it has been served. The route
+// pattern is logged instead of the path to keep identifiers out of the key.
+func accessLogMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r)
+
+		route := r.URL.Path
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+		zap.S().Named("access").Infow("Request served",
+			"method", r.Method,
+			"route", route,
+			"status", ww.Status(),
+			"bytes", ww.BytesWritten(),
+			"latency", time.Since(start),
+			"request_id", middleware.GetReqID(r.Context()),
+		)
+	})
+}
@@ -0,0 +1,54 @@
+package apiserver
+
+import (
+	"net/http"
+	"strings"
+
+	handlers "github.com/dcm-project/service-provider-api/internal/handlers/v1alpha1"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// isAdminPath reports whether path is served by the admin listener, when one
+// is configured
+func isAdminPath(path string) bool {
+	return strings.HasPrefix(path, "/admin/") || path == "/metrics"
+}
+
+// filterPaths replies 404 to the requests for which serve returns false
+func filterPaths(next http.Handler, serve func(path string) bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !serve(r.URL.Path) {
+			handlers.WriteError(w, r, http.StatusNotFound, handlers.ErrCodeNotFound, "no route for "+r.URL.Path)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// publicHandler hides the admin routes of router
+func publicHandler(router http.Handler) http.Handler {
+	return filterPaths(router, func(path string) bool { return !isAdminPath(path) })
+}
+
+// adminHandler serves the admin routes of router and the pprof profiles
+func adminHandler(router http.Handler) http.Handler {
+	admin := chi.NewRouter()
+	admin.Mount("/debug", middleware.Profiler())
+	admin.Handle("/*", filterPaths(router, isAdminPath))
+	return admin
+}
+
+// getConfig serves the effective configuration with secrets masked
+func (s *Server) getConfig(w http.ResponseWriter, r *http.Request) {
+	out, err := yaml.Marshal(s.cfg.Masked())
+	if err != nil {
+		zap.S().Named("api_server").Errorw("Failed to marshal configuration", "error", err)
+		handlers.WriteError(w, r, http.StatusInternalServerError, handlers.ErrCodeInternal, "failed to marshal configuration")
+		return
+	}
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(out)
+}
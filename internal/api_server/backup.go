@@ -0,0 +1,55 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/internal/backup"
+	handlers "github.com/dcm-project/service-provider-api/internal/handlers/v1alpha1"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.uber.org/zap"
+)
+
+// exportSnapshot streams the snapshot of the registry and the catalog
+func (s *Server) exportSnapshot(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="dcm-snapshot-%s.json"`, time.Now().UTC().Format("20060102T150405Z")))
+
+	ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+	if err := backup.Export(r.Context(), s.store, ww); err != nil {
+		zap.S().Named("api_server").Errorw("Failed to export snapshot", "error", err, "bytes_written", ww.BytesWritten())
+		// Once the snapshot is being written the status is sent, the
+		// truncated snapshot is rejected by import
+		if ww.Status() == 0 {
+			w.Header().Del("Content-Disposition")
+			handlers.WriteError(w, r, http.StatusInternalServerError, handlers.ErrCodeInternal, err.Error())
+		}
+	}
+}
+
+// importSnapshot restores the snapshot in the request body, in the mode of
+// the mode query parameter
+func (s *Server) importSnapshot(w http.ResponseWriter, r *http.Request) {
+	mode, err := backup.ParseMode(r.URL.Query().Get("mode"))
+	if err != nil {
+		handlers.WriteError(w, r, http.StatusBadRequest, handlers.ErrCodeInvalidArgument, err.Error())
+		return
+	}
+
+	summary, err := backup.Import(r.Context(), s.store, r.Body, mode)
+	if err != nil {
+		if errors.Is(err, backup.ErrInvalidSnapshot) {
+			handlers.WriteError(w, r, http.StatusBadRequest, handlers.ErrCodeInvalidArgument, err.Error())
+			return
+		}
+		zap.S().Named("api_server").Errorw("Failed to import snapshot", "error", err)
+		handlers.WriteError(w, r, http.StatusInternalServerError, handlers.ErrCodeInternal, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(summary)
+}
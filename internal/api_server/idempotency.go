@@ -0,0 +1,166 @@
+package apiserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	handlers "github.com/dcm-project/service-provider-api/internal/handlers/v1alpha1"
+	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/dcm-project/service-provider-api/internal/store/model"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.uber.org/zap"
+)
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotentReplayedHeader  = "Idempotent-Replayed"
+	idempotencyCleanupPeriod  = time.Hour
+	maxIdempotencyRequestBody = 1 << 20
+)
+
+// idempotencyMiddleware makes the creations and registrations carrying an
+// Idempotency-Key header safe to retry. The response of the first request,
+// with its headers, is stored for ttl and replayed for later requests with the
+// same key and body. Reusing a key with a different request is rejected with
+// 422, and a retry that arrives while the original request is still running
+// is rejected with 409.
+func idempotencyMiddleware(s store.Idempotency, ttl time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(idempotencyKeyHeader)
+			if key == "" || !isIdempotentOperation(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			logger := zap.S().Named("idempotency")
+
+			// The body is buffered to be hashed, larger bodies are rejected
+			// instead of being truncated
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotencyRequestBody))
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				handlers.WriteError(w, r, http.StatusRequestEntityTooLarge, handlers.ErrCodeRequestTooLarge,
+					fmt.Sprintf("request body exceeds %d bytes", maxBytesErr.Limit))
+				return
+			}
+			if err != nil {
+				handlers.WriteError(w, r, http.StatusBadRequest, handlers.ErrCodeInvalidArgument, "failed to read request body")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			requestHash := hashRequest(r, body)
+
+			now := time.Now()
+			err = s.Create(r.Context(), model.IdempotencyRecord{
+				Key:         key,
+				RequestHash: requestHash,
+				CreatedAt:   now,
+				ExpiresAt:   now.Add(ttl),
+			})
+			if errors.Is(err, store.ErrAlreadyExists) {
+				replayIdempotentResponse(w, r, s, key, requestHash)
+				return
+			}
+			if err != nil {
+				logger.Errorw("Failed to store idempotency key", "key", key, "error", err)
+				handlers.WriteError(w, r, http.StatusInternalServerError, handlers.ErrCodeInternal, "failed to store idempotency key")
+				return
+			}
+
+			var response bytes.Buffer
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(&response)
+			next.ServeHTTP(ww, r)
+
+			// Use a fresh context, the request context may already be canceled
+			ctx := context.WithoutCancel(r.Context())
+			if ww.Status() >= http.StatusInternalServerError {
+				// Server errors are not stored so that the request can be retried
+				if err := s.Delete(ctx, key); err != nil {
+					logger.Errorw("Failed to release idempotency key", "key", key, "error", err)
+				}
+				return
+			}
+			if err := s.Complete(ctx, key, ww.Status(), ww.Header().Clone(), response.Bytes()); err != nil {
+				logger.Errorw("Failed to store idempotent response", "key", key, "error", err)
+			}
+		})
+	}
+}
+
+func replayIdempotentResponse(w http.ResponseWriter, r *http.Request, s store.Idempotency, key, requestHash string) {
+	record, err := s.Get(r.Context(), key)
+	if err != nil {
+		zap.S().Named("idempotency").Errorw("Failed to load idempotency key", "key", key, "error", err)
+		handlers.WriteError(w, r, http.StatusInternalServerError, handlers.ErrCodeInternal, "failed to load idempotency key")
+		return
+	}
+	if record.RequestHash != requestHash {
+		handlers.WriteError(w, r, http.StatusUnprocessableEntity, handlers.ErrCodeIdempotencyKeyReused, "idempotency key was already used with a different request")
+		return
+	}
+	if !record.Completed() {
+		handlers.WriteError(w, r, http.StatusConflict, handlers.ErrCodeIdempotencyKeyInUse, "a request with this idempotency key is in progress")
+		return
+	}
+
+	for name, values := range record.ResponseHeader {
+		w.Header()[name] = values
+	}
+	w.Header().Set(idempotentReplayedHeader, "true")
+	w.WriteHeader(record.StatusCode)
+	_, _ = w.Write(record.ResponseBody)
+}
+
+// isIdempotentOperation reports whether r is one of the operations taking an
+// Idempotency-Key header: CreateProvider (POST /providers) and
+// RegisterProvider (POST /resource/{resourceKind}/provider)
+func isIdempotentOperation(r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		return false
+	}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch len(parts) {
+	case 1:
+		return parts[0] == "providers"
+	case 3:
+		return parts[0] == "resource" && parts[2] == "provider"
+	}
+	return false
+}
+
+// hashRequest identifies a request by its method, path and body
+func hashRequest(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cleanupIdempotencyRecords periodically removes expired idempotency records
+func cleanupIdempotencyRecords(ctx context.Context, s store.Idempotency) {
+	ticker := time.NewTicker(idempotencyCleanupPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			deleted, err := s.DeleteExpired(ctx, time.Now())
+			if err != nil {
+				zap.S().Named("idempotency").Warnw("Failed to remove expired idempotency keys", "error", err)
+				continue
+			}
+			zap.S().Named("idempotency").Debugw("Removed expired idempotency keys", "count", deleted)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
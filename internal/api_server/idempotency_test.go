@@ -0,0 +1,157 @@
+package apiserver
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/dcm-project/service-provider-api/internal/store/model"
+)
+
+// countingHandler replies with status, an ETag and the request body, and
+// counts its calls
+type countingHandler struct {
+	status int
+	calls  int
+}
+
+func (h *countingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.calls++
+	body, _ := io.ReadAll(r.Body)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", `"1"`)
+	w.WriteHeader(h.status)
+	_, _ = w.Write(body)
+}
+
+func newIdempotentHandler(status int) (http.Handler, *countingHandler, store.Idempotency) {
+	s := store.NewMemoryStore().Idempotency()
+	next := &countingHandler{status: status}
+	return idempotencyMiddleware(s, time.Hour)(next), next, s
+}
+
+func post(h http.Handler, path, key, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	if key != "" {
+		r.Header.Set(idempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestIdempotencyReplaysResponse(t *testing.T) {
+	for _, path := range []string{"/providers", "/resource/vm/provider"} {
+		t.Run(path, func(t *testing.T) {
+			h, next, _ := newIdempotentHandler(http.StatusCreated)
+
+			first := post(h, path, "key-1", `{"name":"vm"}`)
+			replayed := post(h, path, "key-1", `{"name":"vm"}`)
+			if next.calls != 1 {
+				t.Errorf("expected the request to be handled once, got %d calls", next.calls)
+			}
+			if replayed.Code != http.StatusCreated || replayed.Body.String() != first.Body.String() {
+				t.Errorf("expected the first response to be replayed, got %d %s", replayed.Code, replayed.Body)
+			}
+			for _, name := range []string{"Content-Type", "ETag"} {
+				if got, want := replayed.Header().Get(name), first.Header().Get(name); got != want {
+					t.Errorf("expected the %s %q to be replayed, got %q", name, want, got)
+				}
+			}
+			if replayed.Header().Get(idempotentReplayedHeader) != "true" || first.Header().Get(idempotentReplayedHeader) != "" {
+				t.Errorf("expected only the replayed response to be marked")
+			}
+		})
+	}
+}
+
+func TestIdempotencyRejectsReusedKey(t *testing.T) {
+	h, next, _ := newIdempotentHandler(http.StatusCreated)
+
+	post(h, "/providers", "key-1", `{"name":"vm"}`)
+	if w := post(h, "/providers", "key-1", `{"name":"other"}`); w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), "IDEMPOTENCY_KEY_REUSED") {
+		t.Errorf("expected 422 reusing the key with another body, got %d %s", w.Code, w.Body)
+	}
+	if w := post(h, "/resource/vm/provider", "key-1", `{"name":"vm"}`); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422 reusing the key on another path, got %d", w.Code)
+	}
+	if next.calls != 1 {
+		t.Errorf("expected only the first request to be handled, got %d calls", next.calls)
+	}
+}
+
+func TestIdempotencyRejectsRequestInProgress(t *testing.T) {
+	h, next, s := newIdempotentHandler(http.StatusCreated)
+	r := httptest.NewRequest(http.MethodPost, "/providers", strings.NewReader(`{}`))
+	err := s.Create(context.Background(), model.IdempotencyRecord{
+		Key:         "key-1",
+		RequestHash: hashRequest(r, []byte(`{}`)),
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("claiming the key: %v", err)
+	}
+
+	if w := post(h, "/providers", "key-1", `{}`); w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), "IDEMPOTENCY_KEY_IN_USE") {
+		t.Errorf("expected 409 while the request is in progress, got %d %s", w.Code, w.Body)
+	}
+	if next.calls != 0 {
+		t.Errorf("expected the retry not to be handled, got %d calls", next.calls)
+	}
+}
+
+func TestIdempotencyDoesNotStoreServerErrors(t *testing.T) {
+	h, next, _ := newIdempotentHandler(http.StatusInternalServerError)
+
+	post(h, "/providers", "key-1", `{}`)
+	next.status = http.StatusCreated
+	if w := post(h, "/providers", "key-1", `{}`); w.Code != http.StatusCreated || w.Header().Get(idempotentReplayedHeader) != "" {
+		t.Errorf("expected the retry to be handled, got %d", w.Code)
+	}
+	if next.calls != 2 {
+		t.Errorf("expected the request to be handled twice, got %d calls", next.calls)
+	}
+}
+
+func TestIdempotencyRejectsLargeBodies(t *testing.T) {
+	h, next, _ := newIdempotentHandler(http.StatusCreated)
+
+	w := post(h, "/providers", "key-1", strings.Repeat("a", maxIdempotencyRequestBody+1))
+	if w.Code != http.StatusRequestEntityTooLarge || !strings.Contains(w.Body.String(), "REQUEST_TOO_LARGE") {
+		t.Errorf("expected 413, got %d %s", w.Code, w.Body)
+	}
+	if next.calls != 0 {
+		t.Errorf("expected the request not to be handled, got %d calls", next.calls)
+	}
+}
+
+func TestIdempotencyIgnoresOtherOperations(t *testing.T) {
+	for _, path := range []string{"/admin/apply", "/admin/import", "/providers/vm"} {
+		t.Run(path, func(t *testing.T) {
+			h, next, _ := newIdempotentHandler(http.StatusOK)
+
+			post(h, path, "key-1", `{}`)
+			if w := post(h, path, "key-1", `{}`); w.Header().Get(idempotentReplayedHeader) != "" {
+				t.Errorf("expected %s not to be replayed", path)
+			}
+			if next.calls != 2 {
+				t.Errorf("expected both requests to be handled, got %d calls", next.calls)
+			}
+		})
+	}
+
+	h, next, _ := newIdempotentHandler(http.StatusOK)
+	for i := 0; i < 2; i++ {
+		r := httptest.NewRequest(http.MethodGet, "/providers", nil)
+		r.Header.Set(idempotencyKeyHeader, "key-1")
+		h.ServeHTTP(httptest.NewRecorder(), r)
+	}
+	if next.calls != 2 {
+		t.Errorf("expected the GET requests to be handled, got %d calls", next.calls)
+	}
+}
@@ -0,0 +1,47 @@
+package apiserver
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/internal/api/server"
+	"github.com/dcm-project/service-provider-api/internal/metrics"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type operationKey struct{}
+
+// operation is filled by operationMiddleware with the operationId of the
+// request, so that middlewares running before routing can label with it
+type operation struct {
+	id string
+}
+
+// metricsMiddleware records the duration of every request
+func metricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		op := &operation{}
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r.WithContext(context.WithValue(r.Context(), operationKey{}, op)))
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		metrics.ObserveHTTPRequest(op.id, r.Method, status, time.Since(start))
+	})
+}
+
+// operationMiddleware is a strict middleware recording the operationId of
+// the request for metricsMiddleware
+func operationMiddleware(f server.StrictHandlerFunc, operationID string) server.StrictHandlerFunc {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		if op, ok := ctx.Value(operationKey{}).(*operation); ok {
+			op.id = operationID
+		}
+		return f(ctx, w, r, request)
+	}
+}
@@ -13,49 +13,85 @@ import (
 
 	api "github.com/dcm-project/service-provider-api/api/v1alpha1"
 	"github.com/dcm-project/service-provider-api/internal/api/server"
+	"github.com/dcm-project/service-provider-api/internal/apply"
+	"github.com/dcm-project/service-provider-api/internal/builtin"
 	"github.com/dcm-project/service-provider-api/internal/config"
+	"github.com/dcm-project/service-provider-api/internal/deploy"
+	"github.com/dcm-project/service-provider-api/internal/discovery"
 	handlers "github.com/dcm-project/service-provider-api/internal/handlers/v1alpha1"
+	"github.com/dcm-project/service-provider-api/internal/health"
+	"github.com/dcm-project/service-provider-api/internal/logging"
+	"github.com/dcm-project/service-provider-api/internal/metrics"
 	"github.com/dcm-project/service-provider-api/internal/service"
+	"github.com/dcm-project/service-provider-api/internal/serviceprovider"
 	"github.com/dcm-project/service-provider-api/internal/store"
 	"github.com/dcm-project/service-provider-api/pkg/registration"
+	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-resty/resty/v2"
 	oapimiddleware "github.com/oapi-codegen/nethttp-middleware"
 	httpSwagger "github.com/swaggo/http-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.uber.org/zap"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"kubevirt.io/client-go/kubecli"
 )
 
 const (
 	gracefulShutdownTimeout = 5 * time.Second
+	healthCheckTimeout      = 2 * time.Second
+	// vmProviderPath is the base path of the API of the built-in vm provider,
+	// an admin path as it creates and deletes VMs without authorization
+	vmProviderPath = "/admin/builtin/vm"
 )
 
 type Server struct {
-	cfg      *config.Config
-	store    store.Store
-	listener net.Listener
+	cfg           *config.Config
+	store         store.Store
+	listener      net.Listener
+	adminListener net.Listener
 }
 
-// New returns a new instance of a migration-planner server.
+// New returns a new instance of a migration-planner server. When adminListener
+// is not nil the admin routes, metrics and pprof are served on it only.
 func New(
 	cfg *config.Config,
 	store store.Store,
 	listener net.Listener,
+	adminListener net.Listener,
 ) *Server {
 	return &Server{
-		cfg:      cfg,
-		store:    store,
-		listener: listener,
+		cfg:           cfg,
+		store:         store,
+		listener:      listener,
+		adminListener: adminListener,
 	}
 }
 
-func oapiErrorHandler(w http.ResponseWriter, message string, statusCode int) {
-	http.Error(w, fmt.Sprintf("API Error: %s", message), statusCode)
+func oapiErrorHandler(ctx context.Context, err error, w http.ResponseWriter, r *http.Request, opts oapimiddleware.ErrorHandlerOpts) {
+	code := handlers.ErrCodeInvalidArgument
+	switch opts.StatusCode {
+	case http.StatusNotFound:
+		code = handlers.ErrCodeNotFound
+	case http.StatusInternalServerError:
+		code = handlers.ErrCodeInternal
+	}
+	handlers.WriteError(w, r, opts.StatusCode, code, err.Error())
+}
+
+func init() {
+	// PATCH requests use JSON merge patch bodies, see AEP-134
+	openapi3filter.RegisterBodyDecoder("application/merge-patch+json", openapi3filter.JSONBodyDecoder)
 }
 
 func (s *Server) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	zap.S().Named("api_server").Info("Initializing API server")
 	swagger, err := api.GetSwagger()
 	if err != nil {
@@ -65,15 +101,40 @@ func (s *Server) Run(ctx context.Context) error {
 	swagger.Servers = nil
 
 	oapiOpts := oapimiddleware.Options{
-		ErrorHandler: oapiErrorHandler,
+		ErrorHandlerWithOpts: oapiErrorHandler,
 	}
 	router := chi.NewRouter()
 
 	router.Use(
 		middleware.RequestID,
+		routeSpanMiddleware,
+		accessLogMiddleware,
+		metricsMiddleware,
 		middleware.Recoverer,
+		idempotencyMiddleware(s.store.Idempotency(), s.cfg.Service.IdempotencyTTL),
 	)
 
+	checker := health.NewChecker(healthCheckTimeout)
+	checker.Register("database", s.store.Ping)
+	checker.Register("migrations", s.store.CheckMigrations)
+	idempotencyCleanup := &health.Worker{}
+	checker.Register("idempotency_cleanup", idempotencyCleanup.Check)
+	go idempotencyCleanup.Run(func() { cleanupIdempotencyRecords(ctx, s.store.Idempotency()) })
+	if cached, ok := s.store.(*store.CachedStore); ok && cached.Notifier() != nil {
+		cacheInvalidation := &health.Worker{}
+		checker.Register("cache_invalidation", cacheInvalidation.Check)
+		go cacheInvalidation.Run(func() {
+			if err := cached.Listen(ctx); err != nil {
+				zap.S().Named("api_server").Errorw("Cache invalidation stopped", "error", err)
+			}
+		})
+	}
+
+	router.Handle("/metrics", metrics.Handler())
+	router.Get("/admin/config", s.getConfig)
+	router.Get("/admin/export", s.exportSnapshot)
+	router.Post("/admin/import", s.importSnapshot)
+
 	// Add Swagger UI endpoints BEFORE OpenAPI validation middleware
 	router.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL("/swagger.json"),
@@ -82,7 +143,7 @@ func (s *Server) Run(ctx context.Context) error {
 		w.Header().Set("Content-Type", "application/json")
 		swaggerJSON, err := json.Marshal(swagger)
 		if err != nil {
-			http.Error(w, "Failed to marshal swagger spec", http.StatusInternalServerError)
+			handlers.WriteError(w, r, http.StatusInternalServerError, handlers.ErrCodeInternal, "Failed to marshal swagger spec")
 			return
 		}
 		_, err = w.Write(swaggerJSON)
@@ -90,60 +151,184 @@ func (s *Server) Run(ctx context.Context) error {
 			return
 		}
 	})
-	restyClient := resty.New()
+	// Propagate the trace context to the providers
+	restyClient := resty.New().SetTransport(otelhttp.NewTransport(http.DefaultTransport))
 
-	h := handlers.NewServiceHandler(
-		service.NewProviderService(
-			s.store,
-			restyClient,
-		),
+	builtins := builtin.NewRegistry()
+	providerService := service.NewProviderService(
+		s.store,
+		restyClient,
+		builtins,
 	)
+	h := handlers.NewServiceHandler(providerService)
+
+	if s.cfg.KubeVirt.Enabled {
+		restConfig, err := s.getKubeConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load the kubernetes configuration of the vm provider: %w", err)
+		}
+		virtClient, err := kubecli.GetKubevirtClientFromRESTConfig(restConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create the kubevirt client of the vm provider: %w", err)
+		}
+		vmProvider := deploy.NewVMProvider(deploy.NewDeployService(virtClient, s.cfg.KubeVirt.Namespace), s.store.Catalog())
+		err = builtins.Add(builtin.Registration{
+			Name:         deploy.VMResourceKind,
+			ResourceKind: deploy.VMResourceKind,
+			Operations:   []string{"CREATE", "DELETE", "READ"},
+			Metadata:     server.ProviderMetadata{Zone: s.cfg.KubeVirt.Zone, Region: s.cfg.KubeVirt.Region},
+		}, vmProvider)
+		if err != nil {
+			return err
+		}
+		router.Mount(vmProviderPath, vmProvider.Handler())
+		zap.S().Named("api_server").Infow("Serving the built-in vm provider", "path", vmProviderPath, "namespace", s.cfg.KubeVirt.Namespace)
+	}
 
 	// Initialize registration handler and wire it to the service handler
-	registrationHandler, err := s.initializeRegistrationHandler()
+	registrationHandler, err := s.initializeRegistrationHandler(builtins)
 	if err != nil {
 		return fmt.Errorf("failed to initialize registration handler: %w", err)
 	}
 	h.SetRegistrationHandler(registrationHandler)
+	if err := builtins.Register(ctx, registrationHandler); err != nil {
+		// The providers are still served, they are registered again on restart
+		zap.S().Named("api_server").Errorw("Failed to register the built-in providers", "error", err)
+	}
+
+	if s.cfg.Discovery.Enabled {
+		kubeClient, err := s.getKubeClient()
+		if err != nil {
+			return fmt.Errorf("failed to create the kubernetes client of the discovery: %w", err)
+		}
+		controller := discovery.NewController(kubeClient, registrationHandler, discovery.Options{
+			Namespace:    s.cfg.Discovery.Namespace,
+			ResyncPeriod: s.cfg.Discovery.ResyncPeriod,
+		})
+		discoveryWorker := &health.Worker{}
+		checker.Register("discovery", discoveryWorker.Check)
+		go discoveryWorker.Run(func() {
+			if err := controller.Run(ctx); err != nil {
+				zap.S().Named("api_server").Errorw("Discovery stopped", "error", err)
+			}
+		})
+	}
+
+	if s.cfg.Discovery.ServiceProviders {
+		restConfig, err := s.getKubeConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load the kubernetes configuration of the service providers: %w", err)
+		}
+		dynamicClient, err := dynamic.NewForConfig(restConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create the kubernetes client of the service providers: %w", err)
+		}
+		controller := serviceprovider.NewController(dynamicClient, registrationHandler, serviceprovider.Options{
+			Namespace:    s.cfg.Discovery.Namespace,
+			ResyncPeriod: s.cfg.Discovery.ResyncPeriod,
+		})
+		serviceProviderWorker := &health.Worker{}
+		checker.Register("serviceproviders", serviceProviderWorker.Check)
+		go serviceProviderWorker.Run(func() {
+			if err := controller.Run(ctx); err != nil {
+				zap.S().Named("api_server").Errorw("Service provider controller stopped", "error", err)
+			}
+		})
+	}
 	h.SetStore(s.store)
+	h.SetApplier(apply.NewApplier(s.store, providerService, registrationHandler))
+	h.SetAtomicLevel(logging.Level())
+	h.SetHealthChecker(checker)
 
 	// Apply OpenAPI validation middleware to API routes only
 	router.Group(func(r chi.Router) {
 		r.Use(oapimiddleware.OapiRequestValidatorWithOptions(swagger, &oapiOpts))
-		server.HandlerFromMux(server.NewStrictHandler(h, nil), router)
+		server.HandlerFromMux(server.NewStrictHandlerWithOptions(h, []server.StrictMiddlewareFunc{operationMiddleware, tracingMiddleware}, server.StrictHTTPServerOptions{
+			RequestErrorHandlerFunc:  handlers.RequestErrorHandler,
+			ResponseErrorHandlerFunc: handlers.ResponseErrorHandler,
+		}), r)
 	})
 
-	srv := http.Server{Addr: s.cfg.Service.Address, Handler: router}
+	servers := []*http.Server{{Handler: tracingHandler(router)}}
+	listeners := []net.Listener{s.listener}
+	if s.adminListener != nil {
+		servers[0].Handler = tracingHandler(publicHandler(router))
+		servers = append(servers, &http.Server{Handler: tracingHandler(adminHandler(router))})
+		listeners = append(listeners, s.adminListener)
+	}
 
+	shutdownDone := make(chan struct{})
 	go func() {
+		defer close(shutdownDone)
 		<-ctx.Done()
 		zap.S().Named("api_server").Infof("Shutdown signal received: %s", ctx.Err())
+
+		// Fail readiness first so that load balancers stop routing requests
+		// before the connections are drained
+		checker.ShutDown()
+		time.Sleep(s.cfg.Service.ShutdownDelay)
+
 		ctxTimeout, cancel := context.WithTimeout(context.Background(), gracefulShutdownTimeout)
 		defer cancel()
 
-		srv.SetKeepAlivesEnabled(false)
-		_ = srv.Shutdown(ctxTimeout)
+		for _, srv := range servers {
+			srv.SetKeepAlivesEnabled(false)
+			_ = srv.Shutdown(ctxTimeout)
+		}
 	}()
 
-	zap.S().Named("api_server").Infof("Listening on %s...", s.listener.Addr().String())
-	if err := srv.Serve(s.listener); err != nil && !errors.Is(err, net.ErrClosed) {
-		return err
+	errs := make(chan error, len(servers))
+	for i, srv := range servers {
+		go func(srv *http.Server, listener net.Listener) {
+			zap.S().Named("api_server").Infof("Listening on %s...", listener.Addr().String())
+			err := srv.Serve(listener)
+			if errors.Is(err, net.ErrClosed) || errors.Is(err, http.ErrServerClosed) {
+				err = nil
+			}
+			errs <- err
+		}(srv, listeners[i])
+	}
+
+	var serveErr error
+	for range servers {
+		if err := <-errs; err != nil && serveErr == nil {
+			// Stop the other servers
+			serveErr = err
+			cancel()
+		}
 	}
 
-	return nil
+	// Serve returns as soon as the shutdown starts, wait for the connections
+	// to be drained
+	cancel()
+	<-shutdownDone
+	return serveErr
 }
 
-func (s *Server) initializeRegistrationHandler() (*registration.Handler, error) {
+func (s *Server) initializeRegistrationHandler(builtins *builtin.Registry) (*registration.Handler, error) {
 	// Initialize registration service with default config
 	cfg := service.DefaultRegistrationServiceConfig(s.store)
+	cfg.Builtins = builtins
 	return service.InitializeRegistrationService(cfg)
 }
 
 func (s *Server) getKubeClient() (*kubernetes.Clientset, error) {
-	kubeconfig := filepath.Join(os.Getenv("HOME"), ".kube", "config")
-	restConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	restConfig, err := s.getKubeConfig()
 	if err != nil {
 		return nil, err
 	}
 	return kubernetes.NewForConfig(restConfig)
 }
+
+// getKubeConfig returns the configured kubeconfig, or the in-cluster
+// configuration, or ~/.kube/config
+func (s *Server) getKubeConfig() (*rest.Config, error) {
+	kubeconfig := s.cfg.Discovery.Kubeconfig
+	if kubeconfig == "" {
+		if restConfig, err := rest.InClusterConfig(); err == nil {
+			return restConfig, nil
+		}
+		kubeconfig = filepath.Join(os.Getenv("HOME"), ".kube", "config")
+	}
+	return clientcmd.BuildConfigFromFlags("", kubeconfig)
+}
@@ -0,0 +1,60 @@
+package apiserver
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dcm-project/service-provider-api/internal/api/server"
+	"github.com/dcm-project/service-provider-api/internal/tracing"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracingHandler starts a server span for every request, continuing the trace
+// of the W3C traceparent header when present
+func tracingHandler(next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, "http.server",
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method
+		}),
+	)
+}
+
+// routeSpanMiddleware names the server span after the matched chi route
+// pattern once the request has been routed
+func routeSpanMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+
+		span := trace.SpanFromContext(r.Context())
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			span.SetName(r.Method + " " + rctx.RoutePattern())
+			span.SetAttributes(semconv.HTTPRoute(rctx.RoutePattern()))
+		}
+		if requestID := middleware.GetReqID(r.Context()); requestID != "" {
+			span.SetAttributes(attribute.String("request_id", requestID))
+		}
+	})
+}
+
+// tracingMiddleware is a strict middleware creating a span for the operation
+func tracingMiddleware(f server.StrictHandlerFunc, operationID string) server.StrictHandlerFunc {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		ctx, span := tracing.Tracer().Start(ctx, operationID,
+			trace.WithAttributes(attribute.String("operation_id", operationID)),
+		)
+		defer span.End()
+
+		response, err := f(ctx, w, r, request)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return response, err
+	}
+}
@@ -0,0 +1,509 @@
+package apply
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/dcm-project/service-provider-api/internal/api/server"
+	"github.com/dcm-project/service-provider-api/internal/service"
+	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/dcm-project/service-provider-api/internal/store/model"
+	storeregistration "github.com/dcm-project/service-provider-api/internal/store/registration"
+	"github.com/dcm-project/service-provider-api/pkg/registration"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// The markers of the objects written by apply are stored in their
+// annotations, which are not exposed by the API, so that they do not show in
+// the labels and are not lost when the labels are updated.
+const (
+	// ManagedByAnnotation marks the objects written by apply. Only these
+	// objects are deleted when pruning.
+	ManagedByAnnotation = "dcm.io/managed-by"
+	ManagedByValue      = "dcm-apply"
+
+	// KindAnnotation records the kind of the manifest a provider was applied
+	// from, providers and registrations being stored together
+	KindAnnotation = "dcm.io/managed-kind"
+)
+
+// ErrInvalidManifest is wrapped by the errors returned for invalid manifests
+var ErrInvalidManifest = errors.New("invalid manifest")
+
+// Error is returned when a change failed. The changes are applied in a
+// transaction, so none of them is kept.
+type Error struct {
+	Change server.ApplyChange
+	Err    error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("failed to %s %s %s: %v", e.Change.Action, e.Change.Kind, e.Change.Name, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Options of an apply
+type Options struct {
+	// DryRun plans the changes without applying them
+	DryRun bool
+	// Prune deletes the managed objects missing from the manifests
+	Prune bool
+}
+
+// Applier reconciles the stored providers, registrations and catalog items
+// with manifests. The providers are written through the provider service and
+// the registrations through the registration handler, so that they are
+// validated like the API requests.
+type Applier struct {
+	store        store.Store
+	providers    *service.ProviderService
+	registration *registration.Handler
+}
+
+func NewApplier(s store.Store, providers *service.ProviderService, registrationHandler *registration.Handler) *Applier {
+	return &Applier{
+		store:        s,
+		providers:    providers,
+		registration: registrationHandler,
+	}
+}
+
+// withStore returns a copy of the applier whose writes go to s
+func (a *Applier) withStore(s store.Store) *Applier {
+	return &Applier{
+		store:     s,
+		providers: a.providers.WithStore(s),
+		registration: a.registration.WithStores(
+			storeregistration.NewRegistrationRegistryAdapter(s),
+			storeregistration.NewRegistrationCatalogAdapter(s),
+		),
+	}
+}
+
+// plan accumulates the changes of an apply and runs them unless dry running
+type plan struct {
+	opts    Options
+	changes []server.ApplyChange
+	applied []server.ApplyChange
+}
+
+func (p *plan) add(kind server.ApplyChangeKind, name string, action server.ApplyChangeAction, fields []string, run func() error) error {
+	change := server.ApplyChange{Kind: kind, Name: name, Action: action}
+	if len(fields) > 0 {
+		change.Fields = &fields
+	}
+	p.changes = append(p.changes, change)
+
+	if p.opts.DryRun || action == server.Unchanged {
+		return nil
+	}
+	if err := run(); err != nil {
+		return &Error{Change: change, Err: err}
+	}
+	p.applied = append(p.applied, change)
+	return nil
+}
+
+// Apply reconciles the stored state with manifests and returns the changes,
+// in the order of the manifests followed by the deletions. The changes are
+// applied in a single transaction.
+func (a *Applier) Apply(ctx context.Context, manifests []server.Manifest, opts Options) ([]server.ApplyChange, error) {
+	logger := zap.S().Named("apply")
+
+	desired, err := decode(manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &plan{opts: opts, changes: []server.ApplyChange{}}
+	err = a.store.WithTx(ctx, func(tx store.Store) error {
+		return a.withStore(tx).apply(ctx, p, desired)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infow("Applied manifests", "manifests", len(manifests), "changes", len(p.applied), "dry_run", opts.DryRun, "prune", opts.Prune)
+	return p.changes, nil
+}
+
+// apply plans the changes of the desired state, and applies them unless dry
+// running
+func (a *Applier) apply(ctx context.Context, p *plan, desired *desiredState) error {
+	for _, item := range desired.catalogItems {
+		if err := a.applyCatalogItem(ctx, p, item); err != nil {
+			return err
+		}
+	}
+	for _, provider := range desired.providers {
+		if err := a.applyProvider(ctx, p, provider); err != nil {
+			return err
+		}
+	}
+	for _, spec := range desired.registrations {
+		if err := a.applyRegistration(ctx, p, spec); err != nil {
+			return err
+		}
+	}
+	if p.opts.Prune {
+		return a.prune(ctx, p, desired)
+	}
+	return nil
+}
+
+func (a *Applier) applyCatalogItem(ctx context.Context, p *plan, spec server.CatalogItemSpec) error {
+	item := model.CatalogItem{
+		Name:         spec.Name,
+		DisplayName:  spec.DisplayName,
+		Description:  valueOrZero(spec.Description),
+		ResourceKind: spec.ResourceKind,
+		Active:       spec.Active == nil || *spec.Active,
+		Annotations:  managedAnnotations(nil, server.ManifestKindCatalogItem),
+	}
+	if spec.Template != nil {
+		item.Template = *spec.Template
+	}
+
+	existing, err := a.store.Catalog().GetCatalogItem(ctx, spec.Name)
+	if errors.Is(err, store.ErrNotFound) {
+		return p.add(server.ApplyChangeKindCatalogItem, spec.Name, server.Create, nil, func() error {
+			return a.store.Catalog().CreateCatalogItem(ctx, &item)
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	item.Labels = existing.Labels
+	item.Annotations = managedAnnotations(existing.Annotations, server.ManifestKindCatalogItem)
+	var fields []string
+	fields = diff(fields, "display_name", existing.DisplayName, item.DisplayName)
+	fields = diff(fields, "description", existing.Description, item.Description)
+	fields = diff(fields, "resource_kind", existing.ResourceKind, item.ResourceKind)
+	fields = diff(fields, "active", existing.Active, item.Active)
+	if !maps.Equal(existing.Annotations, item.Annotations) {
+		fields = append(fields, "annotations")
+	}
+	if !maps.Equal(existing.Template, item.Template) {
+		fields = append(fields, "template")
+	}
+	return p.add(server.ApplyChangeKindCatalogItem, spec.Name, action(fields), fields, func() error {
+		return a.store.Catalog().UpdateCatalogItem(ctx, &item)
+	})
+}
+
+func (a *Applier) applyProvider(ctx context.Context, p *plan, spec server.Provider) error {
+	id := uuid.MustParse(spec.Id)
+	existing, err := a.store.Provider().Get(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return p.add(server.ApplyChangeKindProvider, spec.Id, server.Create, nil, func() error {
+			if _, err := a.providers.CreateProvider(ctx, &spec); err != nil {
+				return err
+			}
+			return a.annotate(ctx, id, server.ManifestKindProvider)
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	var fields []string
+	fields = diff(fields, "name", existing.Name, spec.Name)
+	fields = diff(fields, "type", existing.ProviderType, string(spec.Type))
+	fields = diff(fields, "description", existing.Description, spec.Description)
+	fields = diff(fields, "endpoint", existing.Endpoint, spec.Endpoint)
+	fields = diff(fields, "apiHost", existing.ApiHost, spec.ApiHost)
+	if !slices.Equal(existing.Operations, spec.Operations) {
+		fields = append(fields, "operations")
+	}
+	// The registration metadata and the labels, which are not part of a
+	// provider, are kept by the update
+	updated := len(fields) > 0
+	if !maps.Equal(existing.Annotations, managedAnnotations(existing.Annotations, server.ManifestKindProvider)) {
+		fields = append(fields, "annotations")
+	}
+	return p.add(server.ApplyChangeKindProvider, spec.Id, action(fields), fields, func() error {
+		if updated {
+			if _, err := a.providers.UpdateProvider(ctx, spec.Id, spec, existing.ETag()); err != nil {
+				return err
+			}
+		}
+		return a.annotate(ctx, id, server.ManifestKindProvider)
+	})
+}
+
+// applyRegistration registers a service, or updates its registration. The
+// registry holds a single resource kind per service ID, so a registration for
+// another kind replaces the stored one: it is reported as an update of the
+// resource kind, and the previous kind is unregistered first.
+func (a *Applier) applyRegistration(ctx context.Context, p *plan, spec server.RegistrationSpec) error {
+	id := uuid.MustParse(spec.ServiceId)
+	metadata := spec.Metadata
+	register := func(ifMatch string) func() error {
+		return func() error {
+			_, err := a.registration.RegisterIfMatch(ctx, ifMatch, spec.ServiceId, spec.ResourceKind, spec.Endpoint, metadata, spec.Operations)
+			if err != nil {
+				return err
+			}
+			return a.annotate(ctx, id, server.ManifestKindRegistration)
+		}
+	}
+
+	existing, err := a.store.Provider().Get(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return p.add(server.ApplyChangeKindRegistration, spec.ServiceId, server.Create, nil, register(""))
+	}
+	if err != nil {
+		return err
+	}
+
+	var fields []string
+	fields = diff(fields, "resource_kind", existing.ProviderType, spec.ResourceKind)
+	fields = diff(fields, "endpoint", existing.Endpoint, spec.Endpoint)
+	if !slices.Equal(existing.Operations, spec.Operations) {
+		fields = append(fields, "operations")
+	}
+	fields = diff(fields, "metadata.zone", existing.Zone, metadata.Zone)
+	fields = diff(fields, "metadata.region", existing.Region, metadata.Region)
+	if !maps.Equal(existing.Labels, mapOrNil(metadata.Labels)) {
+		fields = append(fields, "metadata.labels")
+	}
+	if !maps.Equal(existing.ResourceConstraints, mapOrNil(metadata.ResourceConstraints)) {
+		fields = append(fields, "metadata.resource_constraints")
+	}
+	if !maps.Equal(existing.Annotations, managedAnnotations(existing.Annotations, server.ManifestKindRegistration)) {
+		fields = append(fields, "annotations")
+	}
+	if existing.ProviderType != spec.ResourceKind {
+		return p.add(server.ApplyChangeKindRegistration, spec.ServiceId, server.Update, fields, func() error {
+			if err := a.registration.Unregister(ctx, spec.ServiceId, existing.ProviderType); err != nil {
+				return err
+			}
+			return register("")()
+		})
+	}
+	return p.add(server.ApplyChangeKindRegistration, spec.ServiceId, action(fields), fields, register(existing.ETag()))
+}
+
+// prune deletes the managed objects that are not in the desired state
+func (a *Applier) prune(ctx context.Context, p *plan, desired *desiredState) error {
+	providers, err := a.store.Provider().List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, provider := range providers {
+		id := provider.ID.String()
+		if !isManaged(provider.Annotations) || desired.serviceIDs[id] {
+			continue
+		}
+
+		if provider.Annotations[KindAnnotation] == string(server.ManifestKindRegistration) {
+			err = p.add(server.ApplyChangeKindRegistration, id, server.Delete, nil, func() error {
+				return a.registration.Unregister(ctx, id, provider.ProviderType)
+			})
+		} else {
+			err = p.add(server.ApplyChangeKindProvider, id, server.Delete, nil, func() error {
+				return a.providers.DeleteProvider(ctx, id)
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	items, err := a.store.Catalog().ListAllCatalogItems(ctx)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if !isManaged(item.Annotations) || desired.catalogNames[item.Name] {
+			continue
+		}
+		err := p.add(server.ApplyChangeKindCatalogItem, item.Name, server.Delete, nil, func() error {
+			return a.store.Catalog().DeleteCatalogItem(ctx, item.Name)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// desiredState is the content of the manifests by kind
+type desiredState struct {
+	providers     []server.Provider
+	registrations []server.RegistrationSpec
+	catalogItems  []server.CatalogItemSpec
+
+	serviceIDs   map[string]bool
+	catalogNames map[string]bool
+}
+
+// decode decodes and validates the spec of the manifests. All the invalid
+// manifests are reported.
+func decode(manifests []server.Manifest) (*desiredState, error) {
+	desired := &desiredState{
+		serviceIDs:   make(map[string]bool),
+		catalogNames: make(map[string]bool),
+	}
+	validator := registration.NewValidator()
+
+	var errs []error
+	for i, manifest := range manifests {
+		invalid := func(err error) {
+			errs = append(errs, fmt.Errorf("%w: manifests[%d] (%s): %v", ErrInvalidManifest, i, manifest.Kind, err))
+		}
+		if manifest.ApiVersion != server.DcmIov1alpha1 {
+			invalid(fmt.Errorf("unsupported apiVersion %q", manifest.ApiVersion))
+			continue
+		}
+
+		switch manifest.Kind {
+		case server.ManifestKindProvider:
+			var spec server.Provider
+			if err := decodeSpec(manifest.Spec, &spec); err != nil {
+				invalid(err)
+				continue
+			}
+			if _, err := uuid.Parse(spec.Id); err != nil {
+				invalid(fmt.Errorf("invalid id %q: %v", spec.Id, err))
+				continue
+			}
+			if spec.Name == "" || spec.Type == "" || spec.Endpoint == "" || spec.ApiHost == "" {
+				invalid(errors.New("name, type, endpoint and apiHost are required"))
+				continue
+			}
+			if desired.serviceIDs[spec.Id] {
+				invalid(fmt.Errorf("provider %s is declared more than once", spec.Id))
+				continue
+			}
+			desired.serviceIDs[spec.Id] = true
+			desired.providers = append(desired.providers, spec)
+
+		case server.ManifestKindRegistration:
+			var spec server.RegistrationSpec
+			if err := decodeSpec(manifest.Spec, &spec); err != nil {
+				invalid(err)
+				continue
+			}
+			if spec.ResourceKind == "" {
+				invalid(errors.New("resource_kind is required"))
+				continue
+			}
+			if err := validator.ValidateRegistration(spec.ServiceId, spec.ResourceKind, spec.Endpoint, spec.Metadata, spec.Operations); err != nil {
+				invalid(err)
+				continue
+			}
+			if desired.serviceIDs[spec.ServiceId] {
+				invalid(fmt.Errorf("provider %s is declared more than once", spec.ServiceId))
+				continue
+			}
+			desired.serviceIDs[spec.ServiceId] = true
+			desired.registrations = append(desired.registrations, spec)
+
+		case server.ManifestKindCatalogItem:
+			var spec server.CatalogItemSpec
+			if err := decodeSpec(manifest.Spec, &spec); err != nil {
+				invalid(err)
+				continue
+			}
+			if spec.Name == "" || spec.DisplayName == "" || spec.ResourceKind == "" {
+				invalid(errors.New("name, display_name and resource_kind are required"))
+				continue
+			}
+			if desired.catalogNames[spec.Name] {
+				invalid(fmt.Errorf("catalog item %s is declared more than once", spec.Name))
+				continue
+			}
+			desired.catalogNames[spec.Name] = true
+			desired.catalogItems = append(desired.catalogItems, spec)
+
+		default:
+			invalid(fmt.Errorf("unsupported kind %q", manifest.Kind))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return desired, nil
+}
+
+// decodeSpec decodes the free-form spec of a manifest, rejecting unknown
+// fields
+func decodeSpec(spec map[string]interface{}, out interface{}) error {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(out)
+}
+
+// annotate marks a provider as managed, after it is written. The annotations
+// are not part of the providers and registrations of the API.
+func (a *Applier) annotate(ctx context.Context, id uuid.UUID, kind server.ManifestKind) error {
+	provider, err := a.store.Provider().Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	annotations := managedAnnotations(provider.Annotations, kind)
+	if maps.Equal(provider.Annotations, annotations) {
+		return nil
+	}
+	provider.Annotations = annotations
+	_, err = a.store.Provider().Update(ctx, *provider)
+	return err
+}
+
+// managedAnnotations returns a copy of annotations with the markers of
+// managed objects
+func managedAnnotations(annotations map[string]string, kind server.ManifestKind) map[string]string {
+	managed := make(map[string]string, len(annotations)+2)
+	maps.Copy(managed, annotations)
+	managed[ManagedByAnnotation] = ManagedByValue
+	managed[KindAnnotation] = string(kind)
+	return managed
+}
+
+func isManaged(annotations map[string]string) bool {
+	return annotations[ManagedByAnnotation] == ManagedByValue
+}
+
+func diff[T comparable](fields []string, name string, actual, desired T) []string {
+	if actual != desired {
+		return append(fields, name)
+	}
+	return fields
+}
+
+func action(fields []string) server.ApplyChangeAction {
+	if len(fields) == 0 {
+		return server.Unchanged
+	}
+	return server.Update
+}
+
+func mapOrNil(m *map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	return *m
+}
+
+func valueOrZero[T any](value *T) T {
+	if value == nil {
+		var zero T
+		return zero
+	}
+	return *value
+}
@@ -0,0 +1,393 @@
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dcm-project/service-provider-api/internal/api/server"
+	"github.com/dcm-project/service-provider-api/internal/service"
+	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/dcm-project/service-provider-api/internal/store/model"
+	"github.com/go-resty/resty/v2"
+	"github.com/google/uuid"
+)
+
+// newApplier returns an applier on a memory store, and the URL of a healthy
+// provider API
+func newApplier(t *testing.T) (*Applier, store.Store, string) {
+	t.Helper()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(api.Close)
+
+	s := store.NewMemoryStore()
+	registrationHandler, err := service.InitializeRegistrationService(service.RegistrationServiceConfig{Store: s})
+	if err != nil {
+		t.Fatalf("creating the registration handler: %v", err)
+	}
+	return NewApplier(s, service.NewProviderService(s, resty.New(), nil), registrationHandler), s, api.URL
+}
+
+func manifest(t *testing.T, kind server.ManifestKind, spec interface{}) server.Manifest {
+	t.Helper()
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("encoding the spec: %v", err)
+	}
+	m := server.Manifest{ApiVersion: server.DcmIov1alpha1, Kind: kind}
+	if err := json.Unmarshal(data, &m.Spec); err != nil {
+		t.Fatalf("decoding the spec: %v", err)
+	}
+	return m
+}
+
+func providerSpec(apiHost string) server.Provider {
+	return server.Provider{
+		Id:          uuid.NewString(),
+		Name:        "vm-provider",
+		Type:        server.VirtualMachine,
+		Description: "VMs",
+		Endpoint:    apiHost + "/vms",
+		ApiHost:     apiHost,
+		Operations:  []string{"CREATE", "DELETE"},
+	}
+}
+
+func registrationSpec(resourceKind string) server.RegistrationSpec {
+	return server.RegistrationSpec{
+		ServiceId:    uuid.NewString(),
+		ResourceKind: resourceKind,
+		Endpoint:     "http://provider.example:8080/" + resourceKind,
+		Metadata:     server.ProviderMetadata{Zone: "zone-a", Region: "region-1"},
+		Operations:   []string{"CREATE", "READ"},
+	}
+}
+
+func catalogItemSpec(name string) server.CatalogItemSpec {
+	return server.CatalogItemSpec{Name: name, DisplayName: "Small VM", ResourceKind: "vm"}
+}
+
+// actions returns the actions of the changes by name
+func actions(changes []server.ApplyChange) map[string]server.ApplyChangeAction {
+	result := make(map[string]server.ApplyChangeAction, len(changes))
+	for _, change := range changes {
+		result[change.Name] = change.Action
+	}
+	return result
+}
+
+// change returns the change of the object with name
+func change(t *testing.T, changes []server.ApplyChange, name string) server.ApplyChange {
+	t.Helper()
+
+	for _, c := range changes {
+		if c.Name == name {
+			return c
+		}
+	}
+	t.Fatalf("expected a change of %s, got %+v", name, changes)
+	return server.ApplyChange{}
+}
+
+func fields(c server.ApplyChange) []string {
+	if c.Fields == nil {
+		return nil
+	}
+	return *c.Fields
+}
+
+func TestApplyPlansThenCreates(t *testing.T) {
+	ctx := context.Background()
+	applier, s, apiHost := newApplier(t)
+	provider := providerSpec(apiHost)
+	reg := registrationSpec("container")
+	manifests := []server.Manifest{
+		manifest(t, server.ManifestKindCatalogItem, catalogItemSpec("vm-small")),
+		manifest(t, server.ManifestKindProvider, provider),
+		manifest(t, server.ManifestKindRegistration, reg),
+	}
+
+	changes, err := applier.Apply(ctx, manifests, Options{DryRun: true})
+	if err != nil {
+		t.Fatalf("planning the manifests: %v", err)
+	}
+	want := map[string]server.ApplyChangeAction{"vm-small": server.Create, provider.Id: server.Create, reg.ServiceId: server.Create}
+	if got := actions(changes); !maps.Equal(got, want) {
+		t.Errorf("expected the creations to be planned, got %v", got)
+	}
+	if providers, _ := s.Provider().List(ctx); len(providers) != 0 {
+		t.Errorf("expected nothing to be written on a dry run, got %d providers", len(providers))
+	}
+
+	changes, err = applier.Apply(ctx, manifests, Options{})
+	if err != nil {
+		t.Fatalf("applying the manifests: %v", err)
+	}
+	if got := actions(changes); !maps.Equal(got, want) {
+		t.Errorf("expected the planned creations, got %v", got)
+	}
+	stored, err := s.Provider().Get(ctx, uuid.MustParse(provider.Id))
+	if err != nil {
+		t.Fatalf("getting the applied provider: %v", err)
+	}
+	if stored.Annotations[ManagedByAnnotation] != ManagedByValue || stored.Annotations[KindAnnotation] != string(server.ManifestKindProvider) {
+		t.Errorf("expected the provider to be marked as managed, got %v", stored.Annotations)
+	}
+	if len(stored.Labels) != 0 {
+		t.Errorf("expected the markers to stay out of the labels, got %v", stored.Labels)
+	}
+	registered, err := s.Provider().Get(ctx, uuid.MustParse(reg.ServiceId))
+	if err != nil {
+		t.Fatalf("getting the applied registration: %v", err)
+	}
+	if registered.ProviderType != "container" || registered.Annotations[KindAnnotation] != string(server.ManifestKindRegistration) {
+		t.Errorf("expected a managed container registration, got %s with %v", registered.ProviderType, registered.Annotations)
+	}
+
+	changes, err = applier.Apply(ctx, manifests, Options{})
+	if err != nil {
+		t.Fatalf("applying the manifests again: %v", err)
+	}
+	for _, c := range changes {
+		if c.Action != server.Unchanged {
+			t.Errorf("expected %s %s to be unchanged, got %s of %v", c.Kind, c.Name, c.Action, fields(c))
+		}
+	}
+}
+
+func TestApplyUpdatesChangedFields(t *testing.T) {
+	ctx := context.Background()
+	applier, s, apiHost := newApplier(t)
+	provider := providerSpec(apiHost)
+	reg := registrationSpec("container")
+	if _, err := applier.Apply(ctx, []server.Manifest{
+		manifest(t, server.ManifestKindProvider, provider),
+		manifest(t, server.ManifestKindRegistration, reg),
+	}, Options{}); err != nil {
+		t.Fatalf("applying the manifests: %v", err)
+	}
+
+	provider.Description = "virtual machines"
+	reg.Metadata.Zone = "zone-b"
+	changes, err := applier.Apply(ctx, []server.Manifest{
+		manifest(t, server.ManifestKindProvider, provider),
+		manifest(t, server.ManifestKindRegistration, reg),
+	}, Options{})
+	if err != nil {
+		t.Fatalf("applying the updated manifests: %v", err)
+	}
+	if c := change(t, changes, provider.Id); c.Action != server.Update || !slices.Equal(fields(c), []string{"description"}) {
+		t.Errorf("expected an update of the description, got %s of %v", c.Action, fields(c))
+	}
+	if c := change(t, changes, reg.ServiceId); c.Action != server.Update || !slices.Equal(fields(c), []string{"metadata.zone"}) {
+		t.Errorf("expected an update of the zone, got %s of %v", c.Action, fields(c))
+	}
+
+	stored, err := s.Provider().Get(ctx, uuid.MustParse(provider.Id))
+	if err != nil {
+		t.Fatalf("getting the provider: %v", err)
+	}
+	if stored.Description != "virtual machines" || stored.Annotations[ManagedByAnnotation] != ManagedByValue {
+		t.Errorf("expected the managed provider to be updated, got %q with %v", stored.Description, stored.Annotations)
+	}
+}
+
+func TestApplyAdoptsExistingProvider(t *testing.T) {
+	ctx := context.Background()
+	applier, s, apiHost := newApplier(t)
+	provider := providerSpec(apiHost)
+	if _, err := s.Provider().Create(ctx, model.Provider{
+		ID:           uuid.MustParse(provider.Id),
+		Name:         provider.Name,
+		ProviderType: string(provider.Type),
+		Description:  provider.Description,
+		Endpoint:     provider.Endpoint,
+		ApiHost:      provider.ApiHost,
+		Operations:   provider.Operations,
+		Labels:       map[string]string{"team": "infra"},
+	}); err != nil {
+		t.Fatalf("creating the provider: %v", err)
+	}
+
+	changes, err := applier.Apply(ctx, []server.Manifest{manifest(t, server.ManifestKindProvider, provider)}, Options{})
+	if err != nil {
+		t.Fatalf("applying the manifests: %v", err)
+	}
+	if c := change(t, changes, provider.Id); c.Action != server.Update || !slices.Equal(fields(c), []string{"annotations"}) {
+		t.Errorf("expected the provider to be marked as managed, got %s of %v", c.Action, fields(c))
+	}
+	stored, err := s.Provider().Get(ctx, uuid.MustParse(provider.Id))
+	if err != nil {
+		t.Fatalf("getting the provider: %v", err)
+	}
+	if stored.Labels["team"] != "infra" || stored.Annotations[ManagedByAnnotation] != ManagedByValue {
+		t.Errorf("expected the labels to be kept and the provider managed, got %v and %v", stored.Labels, stored.Annotations)
+	}
+}
+
+func TestApplyReportsResourceKindChange(t *testing.T) {
+	ctx := context.Background()
+	applier, s, _ := newApplier(t)
+	reg := registrationSpec("vm")
+	if _, err := applier.Apply(ctx, []server.Manifest{manifest(t, server.ManifestKindRegistration, reg)}, Options{}); err != nil {
+		t.Fatalf("applying the manifests: %v", err)
+	}
+
+	// The registry holds a single resource kind per service ID
+	reg.ResourceKind = "container"
+	manifests := []server.Manifest{manifest(t, server.ManifestKindRegistration, reg)}
+	planned, err := applier.Apply(ctx, manifests, Options{DryRun: true})
+	if err != nil {
+		t.Fatalf("planning the manifests: %v", err)
+	}
+	if c := change(t, planned, reg.ServiceId); c.Action != server.Update || !slices.Contains(fields(c), "resource_kind") {
+		t.Errorf("expected an update of the resource kind, got %s of %v", c.Action, fields(c))
+	}
+
+	applied, err := applier.Apply(ctx, manifests, Options{})
+	if err != nil {
+		t.Fatalf("applying the manifests: %v", err)
+	}
+	if c := change(t, applied, reg.ServiceId); c.Action != server.Update || !slices.Equal(fields(c), fields(planned[0])) {
+		t.Errorf("expected the planned update of %v, got %s of %v", fields(planned[0]), c.Action, fields(c))
+	}
+	stored, err := s.Provider().Get(ctx, uuid.MustParse(reg.ServiceId))
+	if err != nil {
+		t.Fatalf("getting the registration: %v", err)
+	}
+	if stored.ProviderType != "container" {
+		t.Errorf("expected the container resource kind, got %s", stored.ProviderType)
+	}
+}
+
+func TestApplyPrunesManagedObjects(t *testing.T) {
+	ctx := context.Background()
+	applier, s, apiHost := newApplier(t)
+	kept, pruned := providerSpec(apiHost), providerSpec(apiHost)
+	reg := registrationSpec("container")
+	if _, err := applier.Apply(ctx, []server.Manifest{
+		manifest(t, server.ManifestKindCatalogItem, catalogItemSpec("vm-small")),
+		manifest(t, server.ManifestKindCatalogItem, catalogItemSpec("vm-large")),
+		manifest(t, server.ManifestKindProvider, kept),
+		manifest(t, server.ManifestKindProvider, pruned),
+		manifest(t, server.ManifestKindRegistration, reg),
+	}, Options{}); err != nil {
+		t.Fatalf("applying the manifests: %v", err)
+	}
+	unmanaged := model.Provider{ID: uuid.New(), Name: "manual", ProviderType: "vm", Endpoint: apiHost, ApiHost: apiHost}
+	if _, err := s.Provider().Create(ctx, unmanaged); err != nil {
+		t.Fatalf("creating an unmanaged provider: %v", err)
+	}
+
+	manifests := []server.Manifest{
+		manifest(t, server.ManifestKindCatalogItem, catalogItemSpec("vm-small")),
+		manifest(t, server.ManifestKindProvider, kept),
+	}
+	changes, err := applier.Apply(ctx, manifests, Options{Prune: true, DryRun: true})
+	if err != nil {
+		t.Fatalf("planning the pruning: %v", err)
+	}
+	want := map[string]server.ApplyChangeAction{
+		"vm-small":    server.Unchanged,
+		"vm-large":    server.Delete,
+		kept.Id:       server.Unchanged,
+		pruned.Id:     server.Delete,
+		reg.ServiceId: server.Delete,
+	}
+	if got := actions(changes); !maps.Equal(got, want) {
+		t.Errorf("expected the missing managed objects to be deleted, got %v", got)
+	}
+	if _, err := s.Provider().Get(ctx, uuid.MustParse(pruned.Id)); err != nil {
+		t.Errorf("expected nothing to be deleted on a dry run, got %v", err)
+	}
+
+	if _, err := applier.Apply(ctx, manifests, Options{Prune: true}); err != nil {
+		t.Fatalf("pruning: %v", err)
+	}
+	for _, id := range []string{pruned.Id, reg.ServiceId} {
+		if _, err := s.Provider().Get(ctx, uuid.MustParse(id)); !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("expected %s to be pruned, got %v", id, err)
+		}
+	}
+	if _, err := s.Catalog().GetCatalogItem(ctx, "vm-large"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected vm-large to be pruned, got %v", err)
+	}
+	for _, id := range []uuid.UUID{uuid.MustParse(kept.Id), unmanaged.ID} {
+		if _, err := s.Provider().Get(ctx, id); err != nil {
+			t.Errorf("expected %s to be kept, got %v", id, err)
+		}
+	}
+}
+
+func TestApplyValidatesProviders(t *testing.T) {
+	ctx := context.Background()
+	applier, s, apiHost := newApplier(t)
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	for name, tc := range map[string]struct {
+		provider server.Provider
+		err      error
+	}{
+		"unhealthy api host": {
+			provider: func() server.Provider {
+				p := providerSpec(apiHost)
+				p.ApiHost = unreachable.URL
+				return p
+			}(),
+			err: service.ErrEndpointUnreachable,
+		},
+		"missing api host": {
+			provider: func() server.Provider {
+				p := providerSpec(apiHost)
+				p.ApiHost = ""
+				return p
+			}(),
+			err: ErrInvalidManifest,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := applier.Apply(ctx, []server.Manifest{
+				manifest(t, server.ManifestKindCatalogItem, catalogItemSpec("vm-small")),
+				manifest(t, server.ManifestKindProvider, tc.provider),
+			}, Options{})
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+			// The catalog item applied before the failure is rolled back
+			if _, err := s.Catalog().GetCatalogItem(ctx, "vm-small"); !errors.Is(err, store.ErrNotFound) {
+				t.Errorf("expected the apply to be rolled back, got %v", err)
+			}
+		})
+	}
+}
+
+func TestApplyRejectsInvalidManifests(t *testing.T) {
+	applier, _, apiHost := newApplier(t)
+	provider := providerSpec(apiHost)
+
+	_, err := applier.Apply(context.Background(), []server.Manifest{
+		{ApiVersion: "dcm.io/v2", Kind: server.ManifestKindProvider},
+		{ApiVersion: server.DcmIov1alpha1, Kind: "Application"},
+		manifest(t, server.ManifestKindProvider, provider),
+		manifest(t, server.ManifestKindRegistration, server.RegistrationSpec{ServiceId: provider.Id, ResourceKind: "vm"}),
+	}, Options{})
+	if !errors.Is(err, ErrInvalidManifest) {
+		t.Fatalf("expected ErrInvalidManifest, got %v", err)
+	}
+	for _, want := range []string{"manifests[0]", "manifests[1]", "manifests[3]"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %s to be reported, got %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,363 @@
+// Package backup exports the registry and the catalog to a JSON snapshot and
+// restores them from it.
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/dcm-project/service-provider-api/internal/store/model"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// FormatVersion is the version of the snapshot format written by Export.
+// Import rejects the snapshots of other versions.
+const FormatVersion = 1
+
+// Mode is how Import combines a snapshot with the stored state
+type Mode string
+
+const (
+	// ModeMerge saves the objects of the snapshot, keeping the other ones
+	ModeMerge Mode = "merge"
+	// ModeReplace deletes all the objects before saving the snapshot ones
+	ModeReplace Mode = "replace"
+)
+
+// ErrInvalidSnapshot is wrapped by the errors returned for snapshots that
+// cannot be imported
+var ErrInvalidSnapshot = errors.New("invalid snapshot")
+
+// ParseMode returns the mode named name, merge when empty
+func ParseMode(name string) (Mode, error) {
+	switch Mode(name) {
+	case "", ModeMerge:
+		return ModeMerge, nil
+	case ModeReplace:
+		return ModeReplace, nil
+	default:
+		return "", fmt.Errorf("unsupported import mode %q, use merge or replace", name)
+	}
+}
+
+// Snapshot is the exported state. Registrations are stored as providers with
+// registration metadata, they are part of Providers.
+type Snapshot struct {
+	Version         int              `json:"version"`
+	ExportedAt      time.Time        `json:"exported_at"`
+	Providers       []Provider       `json:"providers"`
+	CatalogItems    []CatalogItem    `json:"catalog_items"`
+	CatalogMappings []CatalogMapping `json:"catalog_mappings"`
+}
+
+type Provider struct {
+	ID                  uuid.UUID         `json:"id"`
+	Name                string            `json:"name"`
+	Type                string            `json:"type"`
+	Description         string            `json:"description"`
+	Endpoint            string            `json:"endpoint"`
+	ApiHost             string            `json:"api_host"`
+	Operations          []string          `json:"operations"`
+	Zone                string            `json:"zone,omitempty"`
+	Region              string            `json:"region,omitempty"`
+	Labels              map[string]string `json:"labels,omitempty"`
+	ResourceConstraints map[string]string `json:"resource_constraints,omitempty"`
+	Annotations         map[string]string `json:"annotations,omitempty"`
+	Version             int64             `json:"version"`
+	CreatedAt           time.Time         `json:"created_at"`
+	UpdatedAt           time.Time         `json:"updated_at"`
+}
+
+type CatalogItem struct {
+	ID           uuid.UUID         `json:"id"`
+	Name         string            `json:"name"`
+	DisplayName  string            `json:"display_name"`
+	Description  string            `json:"description"`
+	ResourceKind string            `json:"resource_kind"`
+	Active       bool              `json:"active"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+	Template     map[string]string `json:"template,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
+
+type CatalogMapping struct {
+	ID           uuid.UUID `json:"id"`
+	CatalogName  string    `json:"catalog_name"`
+	ServiceID    string    `json:"service_id"`
+	ResourceKind string    `json:"resource_kind"`
+	Endpoint     string    `json:"endpoint"`
+	Active       bool      `json:"active"`
+	RegisteredAt time.Time `json:"registered_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Summary counts the objects saved by Import
+type Summary struct {
+	Mode            Mode `json:"mode"`
+	Providers       int  `json:"providers"`
+	CatalogItems    int  `json:"catalog_items"`
+	CatalogMappings int  `json:"catalog_mappings"`
+}
+
+// Export writes the snapshot of the stored state to w. The state is read in a
+// single transaction so that the snapshot is consistent, and each object is
+// written as it is converted.
+func Export(ctx context.Context, s store.Store, w io.Writer) error {
+	var providers model.ProviderList
+	var items []model.CatalogItem
+	var mappings []model.CatalogProviderMapping
+	err := s.WithTx(ctx, func(tx store.Store) error {
+		var err error
+		if providers, err = tx.Provider().List(ctx); err != nil {
+			return err
+		}
+		if items, err = tx.Catalog().ListAllCatalogItems(ctx); err != nil {
+			return err
+		}
+		for _, active := range []bool{true, false} {
+			m, err := tx.Catalog().ListAllCatalogMappings(ctx, active)
+			if err != nil {
+				return err
+			}
+			mappings = append(mappings, m...)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sw := &streamWriter{w: w}
+	sw.printf(`{"version":%d,"exported_at":`, FormatVersion)
+	sw.encode(time.Now().UTC())
+	sw.printf(`,"providers":[`)
+	for i, p := range providers {
+		sw.separate(i)
+		sw.encode(fromProvider(p))
+	}
+	sw.printf(`],"catalog_items":[`)
+	for i, item := range items {
+		sw.separate(i)
+		sw.encode(fromCatalogItem(item))
+	}
+	sw.printf(`],"catalog_mappings":[`)
+	for i, mapping := range mappings {
+		sw.separate(i)
+		sw.encode(fromCatalogMapping(mapping))
+	}
+	sw.printf("]}\n")
+	if sw.err != nil {
+		return sw.err
+	}
+
+	zap.S().Named("backup").Infow("Exported snapshot", "providers", len(providers), "catalog_items", len(items), "catalog_mappings", len(mappings))
+	return nil
+}
+
+// Import restores the snapshot read from r in a single transaction, nothing
+// is saved if any object fails. The restored providers get a version newer
+// than both the snapshot and the stored one.
+func Import(ctx context.Context, s store.Store, r io.Reader, mode Mode) (*Summary, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	var snapshot Snapshot
+	if err := decoder.Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	if snapshot.Version != FormatVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d, expected %d", ErrInvalidSnapshot, snapshot.Version, FormatVersion)
+	}
+
+	summary := &Summary{Mode: mode}
+	err := s.WithTx(ctx, func(tx store.Store) error {
+		// The versions of the stored providers, read before replace deletes
+		// them, so that the restored ones get newer versions
+		stored, err := tx.Provider().List(ctx)
+		if err != nil {
+			return fmt.Errorf("listing providers: %w", err)
+		}
+		versions := make(map[uuid.UUID]int64, len(stored))
+		for _, p := range stored {
+			versions[p.ID] = p.Version
+		}
+
+		if mode == ModeReplace {
+			if err := tx.Catalog().DeleteAllCatalogMappings(ctx); err != nil {
+				return fmt.Errorf("deleting catalog mappings: %w", err)
+			}
+			if err := tx.Catalog().DeleteAllCatalogItems(ctx); err != nil {
+				return fmt.Errorf("deleting catalog items: %w", err)
+			}
+			if err := tx.Provider().DeleteAll(ctx); err != nil {
+				return fmt.Errorf("deleting providers: %w", err)
+			}
+		}
+
+		for _, p := range snapshot.Providers {
+			// The version moves forward, so that the entity tags read before
+			// the import no longer match
+			provider := p.toModel()
+			provider.Version = max(p.Version, versions[p.ID]) + 1
+			if err := tx.Provider().Upsert(ctx, provider); err != nil {
+				return fmt.Errorf("saving provider %s: %w", p.ID, err)
+			}
+			summary.Providers++
+		}
+		for _, item := range snapshot.CatalogItems {
+			if err := tx.Catalog().UpsertCatalogItem(ctx, item.toModel()); err != nil {
+				return fmt.Errorf("saving catalog item %s: %w", item.Name, err)
+			}
+			summary.CatalogItems++
+		}
+		for _, mapping := range snapshot.CatalogMappings {
+			if err := tx.Catalog().SaveCatalogMapping(ctx, mapping.toModel()); err != nil {
+				return fmt.Errorf("saving catalog mapping %s: %w", mapping.ID, err)
+			}
+			summary.CatalogMappings++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	zap.S().Named("backup").Infow("Imported snapshot", "mode", mode, "exported_at", snapshot.ExportedAt,
+		"providers", summary.Providers, "catalog_items", summary.CatalogItems, "catalog_mappings", summary.CatalogMappings)
+	return summary, nil
+}
+
+// streamWriter writes JSON incrementally, keeping the first error
+type streamWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (sw *streamWriter) printf(format string, args ...interface{}) {
+	if sw.err == nil {
+		_, sw.err = fmt.Fprintf(sw.w, format, args...)
+	}
+}
+
+func (sw *streamWriter) separate(i int) {
+	if i > 0 {
+		sw.printf(",")
+	}
+}
+
+func (sw *streamWriter) encode(v interface{}) {
+	if sw.err != nil {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		sw.err = err
+		return
+	}
+	_, sw.err = sw.w.Write(data)
+}
+
+func fromProvider(p model.Provider) Provider {
+	return Provider{
+		ID:                  p.ID,
+		Name:                p.Name,
+		Type:                p.ProviderType,
+		Description:         p.Description,
+		Endpoint:            p.Endpoint,
+		ApiHost:             p.ApiHost,
+		Operations:          []string(p.Operations),
+		Zone:                p.Zone,
+		Region:              p.Region,
+		Labels:              p.Labels,
+		ResourceConstraints: p.ResourceConstraints,
+		Annotations:         p.Annotations,
+		Version:             p.Version,
+		CreatedAt:           p.CreatedAt,
+		UpdatedAt:           p.UpdatedAt,
+	}
+}
+
+func (p Provider) toModel() model.Provider {
+	m := model.Provider{
+		ID:                  p.ID,
+		Name:                p.Name,
+		ProviderType:        p.Type,
+		Description:         p.Description,
+		Endpoint:            p.Endpoint,
+		ApiHost:             p.ApiHost,
+		Operations:          p.Operations,
+		Zone:                p.Zone,
+		Region:              p.Region,
+		Labels:              p.Labels,
+		ResourceConstraints: p.ResourceConstraints,
+		Annotations:         p.Annotations,
+	}
+	m.CreatedAt = p.CreatedAt
+	m.UpdatedAt = p.UpdatedAt
+	return m
+}
+
+func fromCatalogItem(item model.CatalogItem) CatalogItem {
+	return CatalogItem{
+		ID:           item.ID,
+		Name:         item.Name,
+		DisplayName:  item.DisplayName,
+		Description:  item.Description,
+		ResourceKind: item.ResourceKind,
+		Active:       item.Active,
+		Labels:       item.Labels,
+		Annotations:  item.Annotations,
+		Template:     item.Template,
+		CreatedAt:    item.CreatedAt,
+		UpdatedAt:    item.UpdatedAt,
+	}
+}
+
+func (item CatalogItem) toModel() *model.CatalogItem {
+	return &model.CatalogItem{
+		ID:           item.ID,
+		Name:         item.Name,
+		DisplayName:  item.DisplayName,
+		Description:  item.Description,
+		ResourceKind: item.ResourceKind,
+		Active:       item.Active,
+		Labels:       item.Labels,
+		Annotations:  item.Annotations,
+		Template:     item.Template,
+		CreatedAt:    item.CreatedAt,
+		UpdatedAt:    item.UpdatedAt,
+	}
+}
+
+func fromCatalogMapping(mapping model.CatalogProviderMapping) CatalogMapping {
+	return CatalogMapping{
+		ID:           mapping.ID,
+		CatalogName:  mapping.CatalogName,
+		ServiceID:    mapping.ServiceID,
+		ResourceKind: mapping.ResourceKind,
+		Endpoint:     mapping.Endpoint,
+		Active:       mapping.Active,
+		RegisteredAt: mapping.RegisteredAt,
+		UpdatedAt:    mapping.UpdatedAt,
+	}
+}
+
+func (mapping CatalogMapping) toModel() *model.CatalogProviderMapping {
+	return &model.CatalogProviderMapping{
+		ID:           mapping.ID,
+		CatalogName:  mapping.CatalogName,
+		ServiceID:    mapping.ServiceID,
+		ResourceKind: mapping.ResourceKind,
+		Endpoint:     mapping.Endpoint,
+		Active:       mapping.Active,
+		RegisteredAt: mapping.RegisteredAt,
+		UpdatedAt:    mapping.UpdatedAt,
+	}
+}
@@ -0,0 +1,221 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/dcm-project/service-provider-api/internal/store/model"
+	"github.com/dcm-project/service-provider-api/internal/store/storetest"
+)
+
+// seededStore returns a memory store with providers spread over resourceKinds
+// resource kinds, each with a catalog item and a mapping
+func seededStore(t *testing.T, providers, resourceKinds int) store.Store {
+	t.Helper()
+
+	s := store.NewMemoryStore()
+	storetest.Seed(t, s, providers, resourceKinds)
+	return s
+}
+
+func export(t *testing.T, s store.Store) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := Export(context.Background(), s, &buf); err != nil {
+		t.Fatalf("exporting: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func importSnapshot(t *testing.T, s store.Store, snapshot []byte, mode Mode) *Summary {
+	t.Helper()
+
+	summary, err := Import(context.Background(), s, bytes.NewReader(snapshot), mode)
+	if err != nil {
+		t.Fatalf("importing in %s mode: %v", mode, err)
+	}
+	return summary
+}
+
+func providersByID(t *testing.T, s store.Store) map[string]model.Provider {
+	t.Helper()
+
+	providers, err := s.Provider().List(context.Background())
+	if err != nil {
+		t.Fatalf("listing the providers: %v", err)
+	}
+	byID := map[string]model.Provider{}
+	for _, p := range providers {
+		byID[p.ID.String()] = p
+	}
+	return byID
+}
+
+func catalogItemNames(t *testing.T, s store.Store) []string {
+	t.Helper()
+
+	items, err := s.Catalog().ListAllCatalogItems(context.Background())
+	if err != nil {
+		t.Fatalf("listing the catalog items: %v", err)
+	}
+	var names []string
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func catalogMappingIDs(t *testing.T, s store.Store) []string {
+	t.Helper()
+
+	var ids []string
+	for _, active := range []bool{true, false} {
+		mappings, err := s.Catalog().ListAllCatalogMappings(context.Background(), active)
+		if err != nil {
+			t.Fatalf("listing the catalog mappings: %v", err)
+		}
+		for _, mapping := range mappings {
+			ids = append(ids, mapping.ID.String())
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	source := seededStore(t, 4, 2)
+	target := store.NewMemoryStore()
+
+	summary := importSnapshot(t, target, export(t, source), ModeMerge)
+	if *summary != (Summary{Mode: ModeMerge, Providers: 4, CatalogItems: 2, CatalogMappings: 2}) {
+		t.Errorf("unexpected summary %+v", *summary)
+	}
+
+	exported, imported := providersByID(t, source), providersByID(t, target)
+	if len(imported) != len(exported) {
+		t.Fatalf("expected %d providers, got %d", len(exported), len(imported))
+	}
+	for id, want := range exported {
+		got, ok := imported[id]
+		if !ok {
+			t.Errorf("expected provider %s to be imported", id)
+			continue
+		}
+		if got.Name != want.Name || got.ProviderType != want.ProviderType || got.Endpoint != want.Endpoint ||
+			got.ApiHost != want.ApiHost || !slices.Equal(got.Operations, want.Operations) ||
+			got.Zone != want.Zone || got.Region != want.Region || !maps.Equal(got.Labels, want.Labels) ||
+			!maps.Equal(got.Annotations, want.Annotations) || !got.CreatedAt.Equal(want.CreatedAt) {
+			t.Errorf("expected provider %s to be restored as %+v, got %+v", id, want, got)
+		}
+	}
+	if got, want := catalogItemNames(t, target), catalogItemNames(t, source); !slices.Equal(got, want) {
+		t.Errorf("expected the catalog items %v, got %v", want, got)
+	}
+	if got, want := catalogMappingIDs(t, target), catalogMappingIDs(t, source); !slices.Equal(got, want) {
+		t.Errorf("expected the catalog mappings %v, got %v", want, got)
+	}
+}
+
+func TestImportModes(t *testing.T) {
+	snapshot := export(t, seededStore(t, 2, 1))
+
+	tests := []struct {
+		mode Mode
+		// kept reports whether the objects missing from the snapshot are kept
+		kept bool
+	}{
+		{mode: ModeMerge, kept: true},
+		{mode: ModeReplace, kept: false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			s := seededStore(t, 3, 1)
+			existing := providersByID(t, s)
+			items := catalogItemNames(t, s)
+
+			importSnapshot(t, s, snapshot, tt.mode)
+
+			providers := providersByID(t, s)
+			wantProviders := 2
+			if tt.kept {
+				wantProviders += len(existing)
+			}
+			if len(providers) != wantProviders {
+				t.Errorf("expected %d providers, got %d", wantProviders, len(providers))
+			}
+			for id := range existing {
+				if _, ok := providers[id]; ok != tt.kept {
+					t.Errorf("expected provider %s to be kept: %v", id, tt.kept)
+				}
+			}
+			names := catalogItemNames(t, s)
+			for _, name := range items {
+				if slices.Contains(names, name) != tt.kept {
+					t.Errorf("expected catalog item %s to be kept: %v", name, tt.kept)
+				}
+			}
+		})
+	}
+}
+
+func TestImportBumpsVersions(t *testing.T) {
+	ctx := context.Background()
+	s := seededStore(t, 1, 1)
+	snapshot := export(t, s)
+
+	var provider model.Provider
+	for _, p := range providersByID(t, s) {
+		provider = p
+	}
+	// The provider is updated after the export, the snapshot holds an older
+	// version
+	for i := 0; i < 2; i++ {
+		updated, err := s.Provider().Update(ctx, provider)
+		if err != nil {
+			t.Fatalf("updating the provider: %v", err)
+		}
+		provider = *updated
+	}
+
+	for _, mode := range []Mode{ModeMerge, ModeReplace} {
+		importSnapshot(t, s, snapshot, mode)
+		restored := providersByID(t, s)[provider.ID.String()]
+		if restored.Version <= provider.Version {
+			t.Errorf("expected the version to move forward from %d in %s mode, got %d", provider.Version, mode, restored.Version)
+		}
+		if model.MatchETag(provider.ETag(), restored.ETag()) {
+			t.Errorf("expected the ETag %s read before the import not to match in %s mode", provider.ETag(), mode)
+		}
+		provider = restored
+	}
+}
+
+func TestImportRejectsInvalidSnapshots(t *testing.T) {
+	s := seededStore(t, 1, 1)
+	before := providersByID(t, s)
+
+	tests := map[string]string{
+		"malformed":       `{"version":1,`,
+		"unknown field":   `{"version":1,"applications":[]}`,
+		"unknown version": `{"version":2,"providers":[]}`,
+	}
+	for name, snapshot := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Import(context.Background(), s, strings.NewReader(snapshot), ModeReplace)
+			if !errors.Is(err, ErrInvalidSnapshot) {
+				t.Errorf("expected ErrInvalidSnapshot, got %v", err)
+			}
+			if got := providersByID(t, s); len(got) != len(before) {
+				t.Errorf("expected nothing to be deleted, got %d providers", len(got))
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Package builtin runs providers in the process of the API server. Built-in
+// providers are registered like external ones, with a builtin:// endpoint,
+// and are called directly instead of over HTTP.
+package builtin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/internal/api/server"
+	"github.com/dcm-project/service-provider-api/pkg/registration"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrNotFound is wrapped by the errors returned for resources that do not
+	// exist
+	ErrNotFound = errors.New("not found")
+
+	// ErrAlreadyExists is wrapped by the errors returned when creating a
+	// resource that already exists
+	ErrAlreadyExists = errors.New("already exists")
+
+	// ErrInvalidRequest is wrapped by the errors returned for requests the
+	// provider cannot serve
+	ErrInvalidRequest = errors.New("request is invalid")
+
+	// ErrUnknownProvider is returned for builtin:// endpoints without a
+	// built-in provider
+	ErrUnknownProvider = errors.New("unknown built-in provider")
+)
+
+// CreateRequest is the request of the creation of a resource
+type CreateRequest struct {
+	Name string
+	// CatalogItem is the name of the catalog item the resource is created from
+	CatalogItem string
+}
+
+// Resource is the state of a resource of a built-in provider
+type Resource struct {
+	ID          string
+	CatalogItem string
+	// Status is the provider specific status of the resource, e.g. the phase
+	// of a VM
+	Status string
+	// Properties are the provider specific attributes of the resource
+	Properties map[string]string
+	CreatedAt  time.Time
+}
+
+// Provider is a provider running in the API server
+type Provider interface {
+	Create(ctx context.Context, request CreateRequest) (*Resource, error)
+	Get(ctx context.Context, id string) (*Resource, error)
+	Delete(ctx context.Context, id string) error
+	// Health returns an error when the provider cannot serve requests
+	Health(ctx context.Context) error
+}
+
+// Registration is how a built-in provider is registered
+type Registration struct {
+	// Name identifies the provider, its endpoint is builtin://<name>
+	Name         string
+	ResourceKind string
+	Operations   []string
+	Metadata     server.ProviderMetadata
+}
+
+type entry struct {
+	registration Registration
+	provider     Provider
+}
+
+// Registry holds the built-in providers by name
+type Registry struct {
+	mu        sync.RWMutex
+	providers map[string]entry
+}
+
+func NewRegistry() *Registry {
+	return &Registry{providers: map[string]entry{}}
+}
+
+// Endpoint returns the registration endpoint of the built-in provider name
+func Endpoint(name string) string {
+	return registration.BuiltinScheme + "://" + name
+}
+
+// ServiceID returns the service ID of the built-in provider name, stable
+// across restarts
+func ServiceID(name string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(Endpoint(name))).String()
+}
+
+// Add adds a built-in provider, Register registers it
+func (r *Registry) Add(reg Registration, provider Provider) error {
+	if reg.Name == "" {
+		return fmt.Errorf("built-in provider name is required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.providers[reg.Name]; ok {
+		return fmt.Errorf("built-in provider %s is already added", reg.Name)
+	}
+	r.providers[reg.Name] = entry{registration: reg, provider: provider}
+	return nil
+}
+
+// Lookup returns the built-in provider of a registration endpoint. It returns
+// false for the endpoints of external providers, which are called over HTTP.
+func (r *Registry) Lookup(endpoint string) (Provider, bool) {
+	name, ok := providerName(endpoint)
+	if !ok {
+		return nil, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.providers[name]
+	return e.provider, ok
+}
+
+// Register registers the built-in providers with handler, the existing
+// registrations are updated. A provider failing to register does not prevent
+// the registration of the other ones, the errors are joined.
+func (r *Registry) Register(ctx context.Context, handler *registration.Handler) error {
+	r.mu.RLock()
+	entries := make([]entry, 0, len(r.providers))
+	for _, e := range r.providers {
+		entries = append(entries, e)
+	}
+	r.mu.RUnlock()
+	sort.Slice(entries, func(i, j int) bool { return entries[i].registration.Name < entries[j].registration.Name })
+
+	var errs []error
+	for _, e := range entries {
+		reg := e.registration
+		if _, err := handler.Register(ctx, ServiceID(reg.Name), reg.ResourceKind, Endpoint(reg.Name), reg.Metadata, reg.Operations); err != nil {
+			errs = append(errs, fmt.Errorf("registering built-in provider %s: %w", reg.Name, err))
+			continue
+		}
+		zap.S().Named("builtin").Infow("Registered built-in provider", "name", reg.Name, "resource_kind", reg.ResourceKind, "service_id", ServiceID(reg.Name))
+	}
+	return errors.Join(errs...)
+}
+
+// EndpointChecker returns an endpoint checker calling the Health of the
+// built-in providers for builtin:// endpoints, and next for the other ones.
+// next may be nil to skip the check of external endpoints.
+func (r *Registry) EndpointChecker(next registration.EndpointChecker) registration.EndpointChecker {
+	return &endpointChecker{registry: r, next: next}
+}
+
+type endpointChecker struct {
+	registry *Registry
+	next     registration.EndpointChecker
+}
+
+func (c *endpointChecker) CheckEndpoint(ctx context.Context, endpoint string) error {
+	if _, ok := providerName(endpoint); !ok {
+		if c.next == nil {
+			return nil
+		}
+		return c.next.CheckEndpoint(ctx, endpoint)
+	}
+
+	provider, ok := c.registry.Lookup(endpoint)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownProvider, endpoint)
+	}
+	return provider.Health(ctx)
+}
+
+// providerName returns the name of the provider of a builtin:// endpoint
+func providerName(endpoint string) (string, bool) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != registration.BuiltinScheme || u.Host == "" {
+		return "", false
+	}
+	return u.Host, true
+}
@@ -1,37 +1,346 @@
 package config
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/internal/logging"
 	"github.com/kelseyhightower/envconfig"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	maskedValue = "********"
+	unixScheme  = "unix://"
 )
 
 var singleConfig *Config = nil
 
 type Config struct {
-	Database *dbConfig
-	Service  *svcConfig
+	Database  *dbConfig        `yaml:"database"`
+	Service   *svcConfig       `yaml:"service"`
+	Tracing   *tracingConfig   `yaml:"tracing"`
+	Discovery *discoveryConfig `yaml:"discovery"`
+	KubeVirt  *kubeVirtConfig  `yaml:"kubevirt"`
 }
 
 type dbConfig struct {
-	Type     string `envconfig:"DB_TYPE" default:"pgsql"`
-	Hostname string `envconfig:"DB_HOST" default:"localhost"`
-	Port     string `envconfig:"DB_PORT" default:"5432"`
-	Name     string `envconfig:"DB_NAME" default:"service-provider"`
-	User     string `envconfig:"DB_USER" default:"admin"`
-	Password string `envconfig:"DB_PASS" default:"adminpass"`
+	Type     string `yaml:"type" envconfig:"DB_TYPE" default:"pgsql"`
+	Hostname string `yaml:"hostname" envconfig:"DB_HOST" default:"localhost"`
+	Port     string `yaml:"port" envconfig:"DB_PORT" default:"5432"`
+	Name     string `yaml:"name" envconfig:"DB_NAME" default:"service-provider"`
+	User     string `yaml:"user" envconfig:"DB_USER" default:"admin"`
+	Password string `yaml:"password" envconfig:"DB_PASS" default:"adminpass"`
+	// PasswordFile is the path of a file holding the password, such as a
+	// mounted secret, it replaces Password
+	PasswordFile string `yaml:"passwordFile" envconfig:"DB_PASS_FILE"`
+	// URL is a complete pgsql connection string, URL or key/value pairs, used
+	// instead of the connection fields and the TLS settings. The password of
+	// PasswordFile still applies.
+	URL string `yaml:"url" envconfig:"DB_URL"`
+	// SSLMode is the libpq sslmode of pgsql connections, and SSLRootCert the
+	// path of the CA certificate verifying the server
+	SSLMode     string `yaml:"sslMode" envconfig:"DB_SSLMODE" default:"prefer"`
+	SSLRootCert string `yaml:"sslRootCert" envconfig:"DB_SSLROOTCERT"`
+	// MaxOpenConns and MaxIdleConns size the connection pool, 0 open
+	// connections means unlimited
+	MaxOpenConns    int           `yaml:"maxOpenConns" envconfig:"DB_MAX_OPEN_CONNS" default:"100"`
+	MaxIdleConns    int           `yaml:"maxIdleConns" envconfig:"DB_MAX_IDLE_CONNS" default:"10"`
+	ConnMaxLifetime time.Duration `yaml:"connMaxLifetime" envconfig:"DB_CONN_MAX_LIFETIME" default:"30m"`
+	// ConnectTimeout is how long startup retries to connect while the
+	// database is not reachable, 0 fails on the first attempt
+	ConnectTimeout time.Duration `yaml:"connectTimeout" envconfig:"DB_CONNECT_TIMEOUT" default:"1m"`
+	// CacheTTL is how long the provider and catalog reads are cached, 0
+	// disables the cache. The replicas sharing a pgsql database invalidate
+	// each other's caches on writes, the TTL bounds the staleness otherwise.
+	CacheTTL time.Duration `yaml:"cacheTTL" envconfig:"DB_CACHE_TTL" default:"30s"`
 }
 
 type svcConfig struct {
-	Address  string `envconfig:"DCM_ADDRESS" default:":8081"`
-	BaseUrl  string `envconfig:"DCM_BASE_URL" default:"https://localhost:8081"`
-	LogLevel string `envconfig:"DCM_LOG_LEVEL" default:"info"`
+	Address string `yaml:"address" envconfig:"DCM_ADDRESS" default:":8081"`
+	// AdminAddress is an optional address, or unix:// socket path, serving the
+	// admin routes, metrics and pprof instead of Address
+	AdminAddress   string        `yaml:"adminAddress" envconfig:"DCM_ADMIN_ADDRESS"`
+	BaseUrl        string        `yaml:"baseUrl" envconfig:"DCM_BASE_URL" default:"https://localhost:8081"`
+	AltNames       []string      `yaml:"altNames" envconfig:"DCM_ALT_NAMES"`
+	LogLevel       string        `yaml:"logLevel" envconfig:"DCM_LOG_LEVEL" default:"info"`
+	LogFormat      string        `yaml:"logFormat" envconfig:"DCM_LOG_FORMAT" default:"console"`
+	IdempotencyTTL time.Duration `yaml:"idempotencyTTL" envconfig:"DCM_IDEMPOTENCY_TTL" default:"24h"`
+	// ShutdownDelay is the time the service reports itself as not ready before
+	// draining connections on shutdown
+	ShutdownDelay time.Duration `yaml:"shutdownDelay" envconfig:"DCM_SHUTDOWN_DELAY" default:"5s"`
 }
 
+type tracingConfig struct {
+	Enabled bool `yaml:"enabled" envconfig:"DCM_TRACING_ENABLED" default:"false"`
+	// Endpoint is the URL of an OTLP/HTTP collector, spans are written to File
+	// or to stdout when no collector is configured
+	Endpoint    string  `yaml:"endpoint" envconfig:"DCM_TRACING_ENDPOINT"`
+	File        string  `yaml:"file" envconfig:"DCM_TRACING_FILE"`
+	SampleRatio float64 `yaml:"sampleRatio" envconfig:"DCM_TRACING_SAMPLE_RATIO" default:"1"`
+}
+
+type discoveryConfig struct {
+	// Enabled registers the providers of the annotated Kubernetes Services
+	Enabled bool `yaml:"enabled" envconfig:"DCM_DISCOVERY_ENABLED" default:"false"`
+	// ServiceProviders registers the providers of the ServiceProvider custom
+	// resources
+	ServiceProviders bool `yaml:"serviceProviders" envconfig:"DCM_DISCOVERY_SERVICE_PROVIDERS" default:"false"`
+	// Namespace restricts the discovery to a namespace, all when empty
+	Namespace string `yaml:"namespace" envconfig:"DCM_DISCOVERY_NAMESPACE"`
+	// Kubeconfig is the path of the kubeconfig file of all the Kubernetes
+	// clients, the in-cluster configuration or ~/.kube/config are used when
+	// empty
+	Kubeconfig   string        `yaml:"kubeconfig" envconfig:"DCM_DISCOVERY_KUBECONFIG"`
+	ResyncPeriod time.Duration `yaml:"resyncPeriod" envconfig:"DCM_DISCOVERY_RESYNC_PERIOD" default:"10m"`
+}
+
+type kubeVirtConfig struct {
+	// Enabled serves the built-in vm provider, creating KubeVirt VMs
+	Enabled   bool   `yaml:"enabled" envconfig:"DCM_KUBEVIRT_ENABLED" default:"false"`
+	Namespace string `yaml:"namespace" envconfig:"DCM_KUBEVIRT_NAMESPACE" default:"default"`
+	// Zone and Region are the metadata of the registration of the provider
+	Zone   string `yaml:"zone" envconfig:"DCM_KUBEVIRT_ZONE" default:"local"`
+	Region string `yaml:"region" envconfig:"DCM_KUBEVIRT_REGION" default:"local"`
+}
+
+// New returns the configuration read from environment variables, or the
+// configuration previously returned by Load
 func New() (*Config, error) {
 	if singleConfig == nil {
-		singleConfig = new(Config)
-		if err := envconfig.Process("", singleConfig); err != nil {
+		return Load("")
+	}
+	return singleConfig, nil
+}
+
+// Load reads the configuration from the YAML file at path, when not empty, and
+// from environment variables. Environment variables take precedence over file
+// values, which take precedence over defaults. The result is validated.
+func Load(path string) (*Config, error) {
+	cfg := new(Config)
+	if err := envconfig.Process("", cfg); err != nil {
+		return nil, err
+	}
+
+	if path != "" {
+		fileCfg, err := readFile(path)
+		if err != nil {
 			return nil, err
 		}
+		overlay(reflect.ValueOf(cfg).Elem(), reflect.ValueOf(fileCfg).Elem())
 	}
-	return singleConfig, nil
+
+	if cfg.Database.PasswordFile != "" {
+		password, err := os.ReadFile(cfg.Database.PasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading the database password: %w", err)
+		}
+		cfg.Database.Password = strings.TrimRight(string(password), "\r\n")
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	singleConfig = cfg
+	return cfg, nil
+}
+
+func readFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading configuration file: %w", err)
+	}
+
+	fileCfg := new(Config)
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(fileCfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing configuration file %s: %w", path, err)
+	}
+	return fileCfg, nil
+}
+
+// overlay copies the non-zero fields of src into dst, except for the fields
+// whose environment variable is set
+func overlay(dst, src reflect.Value) {
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Type().Field(i)
+		dstField, srcField := dst.Field(i), src.Field(i)
+
+		if field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct {
+			if srcField.IsNil() {
+				continue
+			}
+			if dstField.IsNil() {
+				dstField.Set(reflect.New(field.Type.Elem()))
+			}
+			overlay(dstField.Elem(), srcField.Elem())
+			continue
+		}
+
+		if key, ok := field.Tag.Lookup("envconfig"); ok {
+			if _, set := os.LookupEnv(key); set {
+				continue
+			}
+		}
+		if !srcField.IsZero() {
+			dstField.Set(srcField)
+		}
+	}
+}
+
+// SplitNetwork returns the network and address to listen on for a configured
+// address, either host:port or unix:///path/to/socket
+func SplitNetwork(address string) (network, addr string) {
+	if path, ok := strings.CutPrefix(address, unixScheme); ok {
+		return "unix", path
+	}
+	return "tcp", address
+}
+
+// Validate checks the configuration and returns all problems found, each
+// prefixed with the path of the offending field in the configuration file
+func (c *Config) Validate() error {
+	var errs []error
+	invalid := func(field, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", field, fmt.Sprintf(format, args...)))
+	}
+
+	switch c.Database.Type {
+	case "pgsql":
+		if c.Database.URL != "" {
+			break
+		}
+		if c.Database.Hostname == "" {
+			invalid("database.hostname", "is required for pgsql")
+		}
+		if port, err := strconv.Atoi(c.Database.Port); err != nil || port < 1 || port > 65535 {
+			invalid("database.port", "%q is not a valid port", c.Database.Port)
+		}
+		switch c.Database.SSLMode {
+		case "", "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+		default:
+			invalid("database.sslMode", "%q is not supported, use disable, allow, prefer, require, verify-ca or verify-full", c.Database.SSLMode)
+		}
+	case "sqlite":
+		if c.Database.Name == "" {
+			invalid("database.name", "is required for sqlite")
+		}
+	case "memory":
+	default:
+		invalid("database.type", "%q is not supported, use pgsql, sqlite or memory", c.Database.Type)
+	}
+	if c.Database.MaxOpenConns < 0 {
+		invalid("database.maxOpenConns", "must not be negative")
+	}
+	if c.Database.MaxIdleConns < 0 {
+		invalid("database.maxIdleConns", "must not be negative")
+	}
+	if c.Database.ConnMaxLifetime < 0 {
+		invalid("database.connMaxLifetime", "must not be negative")
+	}
+	if c.Database.ConnectTimeout < 0 {
+		invalid("database.connectTimeout", "must not be negative")
+	}
+	if c.Database.CacheTTL < 0 {
+		invalid("database.cacheTTL", "must not be negative")
+	}
+
+	if _, _, err := net.SplitHostPort(c.Service.Address); err != nil {
+		invalid("service.address", "%q is not a valid listen address: %v", c.Service.Address, err)
+	}
+	if c.Service.AdminAddress != "" {
+		if network, address := SplitNetwork(c.Service.AdminAddress); network == "unix" && address == "" {
+			invalid("service.adminAddress", "%q has an empty socket path", c.Service.AdminAddress)
+		} else if _, _, err := net.SplitHostPort(address); network == "tcp" && err != nil {
+			invalid("service.adminAddress", "%q is not a valid listen address: %v", c.Service.AdminAddress, err)
+		}
+	}
+	if u, err := url.Parse(c.Service.BaseUrl); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		invalid("service.baseUrl", "%q is not a valid http or https URL", c.Service.BaseUrl)
+	}
+	if _, err := zapcore.ParseLevel(c.Service.LogLevel); err != nil {
+		invalid("service.logLevel", "%q is not a valid log level", c.Service.LogLevel)
+	}
+	if c.Service.LogFormat != logging.FormatJSON && c.Service.LogFormat != logging.FormatConsole {
+		invalid("service.logFormat", "%q is not supported, use json or console", c.Service.LogFormat)
+	}
+	if c.Service.IdempotencyTTL <= 0 {
+		invalid("service.idempotencyTTL", "must be positive")
+	}
+	if c.Service.ShutdownDelay < 0 {
+		invalid("service.shutdownDelay", "must not be negative")
+	}
+
+	if c.Tracing.Endpoint != "" {
+		if u, err := url.Parse(c.Tracing.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			invalid("tracing.endpoint", "%q is not a valid http or https URL", c.Tracing.Endpoint)
+		}
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		invalid("tracing.sampleRatio", "must be between 0 and 1")
+	}
+
+	if c.Discovery.ResyncPeriod < 0 {
+		invalid("discovery.resyncPeriod", "must not be negative")
+	}
+	if c.KubeVirt.Enabled {
+		if c.KubeVirt.Namespace == "" {
+			invalid("kubevirt.namespace", "is required when kubevirt is enabled")
+		}
+		if c.KubeVirt.Zone == "" {
+			invalid("kubevirt.zone", "is required when kubevirt is enabled")
+		}
+		if c.KubeVirt.Region == "" {
+			invalid("kubevirt.region", "is required when kubevirt is enabled")
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Masked returns a copy of the configuration with secrets replaced, suitable
+// for printing and logging
+func (c *Config) Masked() *Config {
+	masked := *c
+	database := *c.Database
+	if database.Password != "" {
+		database.Password = maskedValue
+	}
+	if database.URL != "" {
+		database.URL = maskURL(database.URL)
+	}
+	masked.Database = &database
+	return &masked
+}
+
+// maskURL masks the password of a connection string, or the whole key/value
+// connection string when it holds a password
+func maskURL(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.Scheme != "" {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), maskedValue)
+		}
+		if query := u.Query(); query.Has("password") {
+			query.Set("password", maskedValue)
+			u.RawQuery = query.Encode()
+		}
+		// Keep the mask readable, it is escaped as any other value
+		return strings.ReplaceAll(u.String(), url.QueryEscape(maskedValue), maskedValue)
+	}
+	if strings.Contains(dsn, "password") {
+		return maskedValue
+	}
+	return dsn
 }
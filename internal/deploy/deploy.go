@@ -1,15 +1,192 @@
 package deploy
 
 import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/internal/builtin"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	virtv1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
 )
 
+const (
+	// CatalogItemLabel is set on the VMs to the name of their catalog item
+	CatalogItemLabel = "dcm.io/catalog-item"
+	// ManagedByLabel marks the VMs created by DCM
+	ManagedByLabel = "dcm.io/managed-by"
+	ManagedByValue = "dcm"
+
+	// PhaseStopped is reported for the VMs without a VM instance
+	PhaseStopped = "Stopped"
+)
+
+// The errors wrap the ones of the built-in providers
+var (
+	// ErrNotFound is returned when the VM does not exist
+	ErrNotFound = fmt.Errorf("vm %w", builtin.ErrNotFound)
+
+	// ErrAlreadyExists is returned when creating a VM that already exists
+	ErrAlreadyExists = fmt.Errorf("vm %w", builtin.ErrAlreadyExists)
+
+	// ErrInvalidRequest is returned for VM names and catalog items that
+	// cannot be used to create a VM
+	ErrInvalidRequest = fmt.Errorf("vm %w", builtin.ErrInvalidRequest)
+)
+
+// VM is the state of a VirtualMachine and of its instance
+type VM struct {
+	Name        string    `json:"name"`
+	Namespace   string    `json:"namespace"`
+	CatalogItem string    `json:"catalog_item"`
+	Running     bool      `json:"running"`
+	Phase       string    `json:"phase"`
+	IP          string    `json:"ip,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// DeployService manages the KubeVirt VirtualMachines of a namespace
 type DeployService struct {
-	client kubecli.KubevirtClient
+	client    kubecli.KubevirtClient
+	namespace string
 }
 
-func NewDeployService(client kubecli.KubevirtClient) *DeployService {
+func NewDeployService(client kubecli.KubevirtClient, namespace string) *DeployService {
 	return &DeployService{
-		client: client,
+		client:    client,
+		namespace: namespace,
+	}
+}
+
+// CreateVM creates and starts a VM with the size and the image of template
+func (s *DeployService) CreateVM(ctx context.Context, name, catalogItem string, template VMTemplate) (*VM, error) {
+	runStrategy := virtv1.RunStrategyAlways
+	memory := template.Memory
+
+	vm := &virtv1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: s.namespace,
+			Labels: map[string]string{
+				CatalogItemLabel: catalogItem,
+				ManagedByLabel:   ManagedByValue,
+			},
+		},
+		Spec: virtv1.VirtualMachineSpec{
+			RunStrategy: &runStrategy,
+			Template: &virtv1.VirtualMachineInstanceTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{CatalogItemLabel: catalogItem},
+				},
+				Spec: virtv1.VirtualMachineInstanceSpec{
+					Domain: virtv1.DomainSpec{
+						CPU:    &virtv1.CPU{Cores: template.CPU},
+						Memory: &virtv1.Memory{Guest: &memory},
+						Devices: virtv1.Devices{
+							Disks: []virtv1.Disk{{
+								Name:       "rootdisk",
+								DiskDevice: virtv1.DiskDevice{Disk: &virtv1.DiskTarget{Bus: virtv1.DiskBusVirtio}},
+							}},
+							Interfaces: []virtv1.Interface{*virtv1.DefaultMasqueradeNetworkInterface()},
+						},
+					},
+					Networks: []virtv1.Network{*virtv1.DefaultPodNetwork()},
+					Volumes: []virtv1.Volume{{
+						Name: "rootdisk",
+						VolumeSource: virtv1.VolumeSource{
+							ContainerDisk: &virtv1.ContainerDiskSource{Image: template.Image},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	created, err := s.client.VirtualMachine(s.namespace).Create(ctx, vm, metav1.CreateOptions{})
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("%w: %s", ErrAlreadyExists, name)
+		}
+		return nil, err
+	}
+	zap.S().Named("deploy").Infow("Created VM", "name", name, "namespace", s.namespace, "catalog_item", catalogItem)
+	return s.status(ctx, created)
+}
+
+// GetVM returns the state of the VM
+func (s *DeployService) GetVM(ctx context.Context, name string) (*VM, error) {
+	vm, err := s.client.VirtualMachine(s.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, notFound(err, name)
+	}
+	return s.status(ctx, vm)
+}
+
+// DeleteVM deletes the VM and its instance
+func (s *DeployService) DeleteVM(ctx context.Context, name string) error {
+	if err := s.client.VirtualMachine(s.namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		return notFound(err, name)
+	}
+	zap.S().Named("deploy").Infow("Deleted VM", "name", name, "namespace", s.namespace)
+	return nil
+}
+
+// StartVM starts a stopped VM
+func (s *DeployService) StartVM(ctx context.Context, name string) error {
+	if err := s.client.VirtualMachine(s.namespace).Start(ctx, name, &virtv1.StartOptions{}); err != nil {
+		return notFound(err, name)
+	}
+	return nil
+}
+
+// StopVM stops a running VM, its instance is deleted
+func (s *DeployService) StopVM(ctx context.Context, name string) error {
+	if err := s.client.VirtualMachine(s.namespace).Stop(ctx, name, &virtv1.StopOptions{}); err != nil {
+		return notFound(err, name)
+	}
+	return nil
+}
+
+// Ping checks that the VirtualMachines of the namespace can be listed
+func (s *DeployService) Ping(ctx context.Context) error {
+	_, err := s.client.VirtualMachine(s.namespace).List(ctx, metav1.ListOptions{Limit: 1})
+	return err
+}
+
+// status returns the state of vm, with the phase and the IP address of its
+// instance when there is one
+func (s *DeployService) status(ctx context.Context, vm *virtv1.VirtualMachine) (*VM, error) {
+	result := &VM{
+		Name:        vm.Name,
+		Namespace:   vm.Namespace,
+		CatalogItem: vm.Labels[CatalogItemLabel],
+		Phase:       PhaseStopped,
+		CreatedAt:   vm.CreationTimestamp.Time,
+	}
+	if runStrategy, err := vm.RunStrategy(); err == nil {
+		result.Running = runStrategy != virtv1.RunStrategyHalted
+	}
+
+	vmi, err := s.client.VirtualMachineInstance(s.namespace).Get(ctx, vm.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Phase = string(vmi.Status.Phase)
+	if len(vmi.Status.Interfaces) > 0 {
+		result.IP = vmi.Status.Interfaces[0].IP
+	}
+	return result, nil
+}
+
+func notFound(err error, name string) error {
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
 	}
+	return err
 }
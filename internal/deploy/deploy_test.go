@@ -0,0 +1,234 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/dcm-project/service-provider-api/internal/store/model"
+	"go.uber.org/mock/gomock"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	virtv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+)
+
+const namespace = "vms"
+
+var (
+	vmResource  = schema.GroupResource{Group: "kubevirt.io", Resource: "virtualmachines"}
+	vmiResource = schema.GroupResource{Group: "kubevirt.io", Resource: "virtualmachineinstances"}
+)
+
+// newMocks returns a DeployService of a mock client, and the mocks of its
+// VirtualMachines and VirtualMachineInstances
+func newMocks(t *testing.T) (*DeployService, *kubecli.MockVirtualMachineInterface, *kubecli.MockVirtualMachineInstanceInterface) {
+	ctrl := gomock.NewController(t)
+	client := kubecli.NewMockKubevirtClient(ctrl)
+	vms := kubecli.NewMockVirtualMachineInterface(ctrl)
+	vmis := kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+	client.EXPECT().VirtualMachine(namespace).Return(vms).AnyTimes()
+	client.EXPECT().VirtualMachineInstance(namespace).Return(vmis).AnyTimes()
+	return NewDeployService(client, namespace), vms, vmis
+}
+
+// newCatalog returns a catalog with the vm-small catalog item and a catalog
+// item of another kind
+func newCatalog(t *testing.T) store.Catalog {
+	t.Helper()
+
+	s := store.NewMemoryStore()
+	for _, item := range []*model.CatalogItem{
+		{
+			Name:         "vm-small",
+			DisplayName:  "Small VM",
+			ResourceKind: VMResourceKind,
+			Active:       true,
+			Template:     map[string]string{TemplateCPU: "2", TemplateMemory: "4Gi", TemplateImage: "quay.io/containerdisks/fedora:41"},
+		},
+		{
+			Name:         "vm-broken",
+			DisplayName:  "Broken VM",
+			ResourceKind: VMResourceKind,
+			Active:       true,
+			Template:     map[string]string{TemplateCPU: "two"},
+		},
+		{Name: "volume-small", DisplayName: "Small volume", ResourceKind: "volume", Active: true},
+	} {
+		if err := s.Catalog().CreateCatalogItem(context.Background(), item); err != nil {
+			t.Fatalf("creating the catalog item %s: %v", item.Name, err)
+		}
+	}
+	return s.Catalog()
+}
+
+func TestCreateVMFromCatalogTemplate(t *testing.T) {
+	deploy, vms, vmis := newMocks(t)
+	provider := NewVMProvider(deploy, newCatalog(t))
+
+	var created *virtv1.VirtualMachine
+	vms.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, vm *virtv1.VirtualMachine, _ metav1.CreateOptions) (*virtv1.VirtualMachine, error) {
+			created = vm
+			return vm, nil
+		})
+	vmis.EXPECT().Get(gomock.Any(), "vm-1", gomock.Any()).Return(nil, apierrors.NewNotFound(vmiResource, "vm-1"))
+
+	vm, err := provider.CreateVM(context.Background(), CreateVMRequest{Name: "vm-1", CatalogItem: "vm-small"})
+	if err != nil {
+		t.Fatalf("creating the VM: %v", err)
+	}
+	if vm.Name != "vm-1" || vm.Namespace != namespace || vm.CatalogItem != "vm-small" || !vm.Running || vm.Phase != PhaseStopped {
+		t.Errorf("expected a running vm-1 of vm-small without instance yet, got %+v", vm)
+	}
+
+	if created.Namespace != namespace || created.Labels[CatalogItemLabel] != "vm-small" || created.Labels[ManagedByLabel] != ManagedByValue {
+		t.Errorf("expected the VM in %s with the DCM labels, got %s with %v", namespace, created.Namespace, created.Labels)
+	}
+	if runStrategy, err := created.RunStrategy(); err != nil || runStrategy != virtv1.RunStrategyAlways {
+		t.Errorf("expected the Always run strategy, got %s (%v)", runStrategy, err)
+	}
+	spec := created.Spec.Template.Spec
+	if spec.Domain.CPU == nil || spec.Domain.CPU.Cores != 2 {
+		t.Errorf("expected 2 cores, got %+v", spec.Domain.CPU)
+	}
+	if spec.Domain.Memory == nil || spec.Domain.Memory.Guest == nil || !spec.Domain.Memory.Guest.Equal(resource.MustParse("4Gi")) {
+		t.Errorf("expected 4Gi of memory, got %+v", spec.Domain.Memory)
+	}
+	if len(spec.Volumes) != 1 || spec.Volumes[0].ContainerDisk == nil || spec.Volumes[0].ContainerDisk.Image != "quay.io/containerdisks/fedora:41" {
+		t.Errorf("expected the container disk of the template, got %+v", spec.Volumes)
+	}
+}
+
+func TestCreateVMRejectsInvalidRequests(t *testing.T) {
+	for name, request := range map[string]CreateVMRequest{
+		"invalid name":           {Name: "VM_1", CatalogItem: "vm-small"},
+		"missing catalog item":   {Name: "vm-1", CatalogItem: "vm-missing"},
+		"other resource kind":    {Name: "vm-1", CatalogItem: "volume-small"},
+		"invalid vm template":    {Name: "vm-1", CatalogItem: "vm-broken"},
+		"empty catalog item":     {Name: "vm-1"},
+		"name with a subdomain":  {Name: "vm.1", CatalogItem: "vm-small"},
+		"name longer than label": {Name: "vm-1234567890123456789012345678901234567890123456789012345678901", CatalogItem: "vm-small"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// The mocks fail the test if the VM is created
+			deploy, _, _ := newMocks(t)
+			provider := NewVMProvider(deploy, newCatalog(t))
+
+			_, err := provider.CreateVM(context.Background(), request)
+			if !errors.Is(err, ErrInvalidRequest) && !errors.Is(err, ErrInvalidTemplate) {
+				t.Errorf("expected an invalid request, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCreateVMAlreadyExists(t *testing.T) {
+	deploy, vms, _ := newMocks(t)
+	provider := NewVMProvider(deploy, newCatalog(t))
+	vms.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, apierrors.NewAlreadyExists(vmResource, "vm-1"))
+
+	_, err := provider.CreateVM(context.Background(), CreateVMRequest{Name: "vm-1", CatalogItem: "vm-small"})
+	if !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists, got %v", err)
+	}
+}
+
+func TestGetVMWithInstance(t *testing.T) {
+	deploy, vms, vmis := newMocks(t)
+	created := metav1.Now()
+	runStrategy := virtv1.RunStrategyAlways
+	vms.EXPECT().Get(gomock.Any(), "vm-1", gomock.Any()).Return(&virtv1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "vm-1",
+			Namespace:         namespace,
+			Labels:            map[string]string{CatalogItemLabel: "vm-small"},
+			CreationTimestamp: created,
+		},
+		Spec: virtv1.VirtualMachineSpec{RunStrategy: &runStrategy},
+	}, nil)
+	vmis.EXPECT().Get(gomock.Any(), "vm-1", gomock.Any()).Return(&virtv1.VirtualMachineInstance{
+		Status: virtv1.VirtualMachineInstanceStatus{
+			Phase:      virtv1.Running,
+			Interfaces: []virtv1.VirtualMachineInstanceNetworkInterface{{IP: "10.0.2.15"}},
+		},
+	}, nil)
+
+	vm, err := deploy.GetVM(context.Background(), "vm-1")
+	if err != nil {
+		t.Fatalf("getting the VM: %v", err)
+	}
+	if vm.Phase != string(virtv1.Running) || vm.IP != "10.0.2.15" || !vm.Running {
+		t.Errorf("expected a running VM at 10.0.2.15, got %+v", vm)
+	}
+	if vm.CatalogItem != "vm-small" || !vm.CreatedAt.Equal(created.Time) {
+		t.Errorf("expected the catalog item and the creation time of the VM, got %+v", vm)
+	}
+}
+
+func TestGetVMStopped(t *testing.T) {
+	deploy, vms, vmis := newMocks(t)
+	runStrategy := virtv1.RunStrategyHalted
+	vms.EXPECT().Get(gomock.Any(), "vm-1", gomock.Any()).Return(&virtv1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "vm-1", Namespace: namespace},
+		Spec:       virtv1.VirtualMachineSpec{RunStrategy: &runStrategy},
+	}, nil)
+	vmis.EXPECT().Get(gomock.Any(), "vm-1", gomock.Any()).Return(nil, apierrors.NewNotFound(vmiResource, "vm-1"))
+
+	vm, err := deploy.GetVM(context.Background(), "vm-1")
+	if err != nil {
+		t.Fatalf("getting the VM: %v", err)
+	}
+	if vm.Running || vm.Phase != PhaseStopped || vm.IP != "" {
+		t.Errorf("expected a stopped VM, got %+v", vm)
+	}
+}
+
+func TestGetVMNotFound(t *testing.T) {
+	deploy, vms, _ := newMocks(t)
+	vms.EXPECT().Get(gomock.Any(), "vm-1", gomock.Any()).Return(nil, apierrors.NewNotFound(vmResource, "vm-1"))
+
+	if _, err := deploy.GetVM(context.Background(), "vm-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestPowerActions(t *testing.T) {
+	deploy, vms, _ := newMocks(t)
+	ctx := context.Background()
+	gomock.InOrder(
+		vms.EXPECT().Stop(gomock.Any(), "vm-1", gomock.Any()).Return(nil),
+		vms.EXPECT().Start(gomock.Any(), "vm-1", gomock.Any()).Return(nil),
+		vms.EXPECT().Start(gomock.Any(), "vm-2", gomock.Any()).Return(apierrors.NewNotFound(vmResource, "vm-2")),
+	)
+
+	if err := deploy.StopVM(ctx, "vm-1"); err != nil {
+		t.Errorf("stopping the VM: %v", err)
+	}
+	if err := deploy.StartVM(ctx, "vm-1"); err != nil {
+		t.Errorf("starting the VM: %v", err)
+	}
+	if err := deploy.StartVM(ctx, "vm-2"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound starting a missing VM, got %v", err)
+	}
+}
+
+func TestDeleteVM(t *testing.T) {
+	deploy, vms, _ := newMocks(t)
+	provider := NewVMProvider(deploy, newCatalog(t))
+	ctx := context.Background()
+	gomock.InOrder(
+		vms.EXPECT().Delete(gomock.Any(), "vm-1", gomock.Any()).Return(nil),
+		vms.EXPECT().Delete(gomock.Any(), "vm-1", gomock.Any()).Return(apierrors.NewNotFound(vmResource, "vm-1")),
+	)
+
+	if err := provider.Delete(ctx, "vm-1"); err != nil {
+		t.Errorf("deleting the VM: %v", err)
+	}
+	if err := provider.Delete(ctx, "vm-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound deleting a missing VM, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package deploy
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Keys of the template of the vm catalog items
+const (
+	TemplateCPU    = "cpu"
+	TemplateMemory = "memory"
+	TemplateImage  = "image"
+)
+
+// ErrInvalidTemplate is wrapped by the errors returned for catalog item
+// templates that do not describe a VM
+var ErrInvalidTemplate = errors.New("invalid vm template")
+
+// VMTemplate is the size and the disk image of a VM
+type VMTemplate struct {
+	// CPU is the number of cores
+	CPU    uint32
+	Memory resource.Quantity
+	// Image is the container disk image the VM boots from
+	Image string
+}
+
+// ParseVMTemplate returns the VM template of the template of a catalog item
+func ParseVMTemplate(template map[string]string) (VMTemplate, error) {
+	var t VMTemplate
+
+	cpu, err := strconv.ParseUint(template[TemplateCPU], 10, 32)
+	if err != nil || cpu == 0 {
+		return t, fmt.Errorf("%w: %s %q is not a positive number of cores", ErrInvalidTemplate, TemplateCPU, template[TemplateCPU])
+	}
+	t.CPU = uint32(cpu)
+
+	if t.Memory, err = resource.ParseQuantity(template[TemplateMemory]); err != nil || t.Memory.Sign() <= 0 {
+		return t, fmt.Errorf("%w: %s %q is not a positive quantity", ErrInvalidTemplate, TemplateMemory, template[TemplateMemory])
+	}
+
+	if t.Image = template[TemplateImage]; t.Image == "" {
+		return t, fmt.Errorf("%w: %s is required", ErrInvalidTemplate, TemplateImage)
+	}
+	return t, nil
+}
@@ -0,0 +1,204 @@
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/dcm-project/service-provider-api/internal/api/server"
+	"github.com/dcm-project/service-provider-api/internal/builtin"
+	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// VMResourceKind is the resource kind served by the vm provider
+const VMResourceKind = "vm"
+
+// CreateVMRequest is the body of the creation of a VM
+type CreateVMRequest struct {
+	Name string `json:"name"`
+	// CatalogItem is the name of the vm catalog item with the template of
+	// the VM
+	CatalogItem string `json:"catalog_item"`
+}
+
+// VMProvider is the built-in provider of the vm resource kind, creating VMs
+// from the template of vm catalog items
+type VMProvider struct {
+	deploy  *DeployService
+	catalog store.Catalog
+}
+
+var _ builtin.Provider = (*VMProvider)(nil)
+
+func NewVMProvider(deploy *DeployService, catalog store.Catalog) *VMProvider {
+	return &VMProvider{
+		deploy:  deploy,
+		catalog: catalog,
+	}
+}
+
+// Create creates a VM from the template of its catalog item
+func (p *VMProvider) Create(ctx context.Context, request builtin.CreateRequest) (*builtin.Resource, error) {
+	vm, err := p.CreateVM(ctx, CreateVMRequest{Name: request.Name, CatalogItem: request.CatalogItem})
+	if err != nil {
+		return nil, err
+	}
+	return toResource(vm), nil
+}
+
+// Get returns the VM named id
+func (p *VMProvider) Get(ctx context.Context, id string) (*builtin.Resource, error) {
+	vm, err := p.deploy.GetVM(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toResource(vm), nil
+}
+
+// Delete deletes the VM named id
+func (p *VMProvider) Delete(ctx context.Context, id string) error {
+	return p.deploy.DeleteVM(ctx, id)
+}
+
+// Health checks that KubeVirt is reachable
+func (p *VMProvider) Health(ctx context.Context) error {
+	return p.deploy.Ping(ctx)
+}
+
+// CreateVM creates a VM from the template of its catalog item
+func (p *VMProvider) CreateVM(ctx context.Context, request CreateVMRequest) (*VM, error) {
+	if errs := validation.IsDNS1123Label(request.Name); len(errs) > 0 {
+		return nil, fmt.Errorf("%w: name %q: %v", ErrInvalidRequest, request.Name, errs)
+	}
+
+	item, err := p.catalog.GetCatalogItem(ctx, request.CatalogItem)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, fmt.Errorf("%w: catalog item %q does not exist", ErrInvalidRequest, request.CatalogItem)
+		}
+		return nil, err
+	}
+	if item.ResourceKind != VMResourceKind {
+		return nil, fmt.Errorf("%w: catalog item %q is of kind %s", ErrInvalidRequest, item.Name, item.ResourceKind)
+	}
+	template, err := ParseVMTemplate(item.Template)
+	if err != nil {
+		return nil, fmt.Errorf("catalog item %q: %w", item.Name, err)
+	}
+
+	return p.deploy.CreateVM(ctx, request.Name, item.Name, template)
+}
+
+// Handler returns the HTTP API of the provider: POST / creates a VM, GET and
+// DELETE /{id} get and delete it and POST /{id}/start and /{id}/stop change
+// its power state
+func (p *VMProvider) Handler() http.Handler {
+	r := chi.NewRouter()
+	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
+		if err := p.Health(r.Context()); err != nil {
+			writeError(w, r, http.StatusServiceUnavailable, "UNAVAILABLE", err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"status": "healthy"})
+	})
+	r.Post("/", p.create)
+	r.Get("/{id}", p.get)
+	r.Delete("/{id}", p.delete)
+	r.Post("/{id}/start", p.powerAction(p.deploy.StartVM))
+	r.Post("/{id}/stop", p.powerAction(p.deploy.StopVM))
+	return r
+}
+
+func (p *VMProvider) create(w http.ResponseWriter, r *http.Request) {
+	var request CreateVMRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, r, http.StatusBadRequest, "INVALID_ARGUMENT", err.Error())
+		return
+	}
+	vm, err := p.CreateVM(r.Context(), request)
+	if err != nil {
+		writeDeployError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, vm)
+}
+
+func (p *VMProvider) get(w http.ResponseWriter, r *http.Request) {
+	vm, err := p.deploy.GetVM(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeDeployError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, vm)
+}
+
+func (p *VMProvider) delete(w http.ResponseWriter, r *http.Request) {
+	if err := p.deploy.DeleteVM(r.Context(), chi.URLParam(r, "id")); err != nil {
+		writeDeployError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (p *VMProvider) powerAction(action func(ctx context.Context, name string) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := action(r.Context(), chi.URLParam(r, "id")); err != nil {
+			writeDeployError(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func toResource(vm *VM) *builtin.Resource {
+	properties := map[string]string{
+		"namespace": vm.Namespace,
+		"running":   strconv.FormatBool(vm.Running),
+	}
+	if vm.IP != "" {
+		properties["ip"] = vm.IP
+	}
+	return &builtin.Resource{
+		ID:          vm.Name,
+		CatalogItem: vm.CatalogItem,
+		Status:      vm.Phase,
+		Properties:  properties,
+		CreatedAt:   vm.CreatedAt,
+	}
+}
+
+func writeDeployError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidRequest), errors.Is(err, ErrInvalidTemplate):
+		writeError(w, r, http.StatusUnprocessableEntity, "INVALID_ARGUMENT", err.Error())
+	case errors.Is(err, ErrNotFound):
+		writeError(w, r, http.StatusNotFound, "NOT_FOUND", err.Error())
+	case errors.Is(err, ErrAlreadyExists):
+		writeError(w, r, http.StatusConflict, "ALREADY_EXISTS", err.Error())
+	default:
+		zap.S().Named("deploy").Errorw("VM operation failed", "path", r.URL.Path, "error", err)
+		writeError(w, r, http.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+}
+
+// writeError writes the error envelope of the API
+func writeError(w http.ResponseWriter, r *http.Request, statusCode int, code, message string) {
+	apiErr := server.Error{Code: code, Message: message}
+	if requestID := middleware.GetReqID(r.Context()); requestID != "" {
+		apiErr.RequestId = &requestID
+	}
+	writeJSON(w, statusCode, apiErr)
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
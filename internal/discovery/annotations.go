@@ -0,0 +1,148 @@
+package discovery
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/dcm-project/service-provider-api/internal/api/server"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Annotations of the Services discovered as providers. A Service is
+// discovered when it has the resource kind annotation.
+const (
+	AnnotationResourceKind = "dcm.io/resource-kind"
+	// AnnotationServiceID overrides the service ID, the Service UID by default
+	AnnotationServiceID = "dcm.io/service-id"
+	// AnnotationOperations is a comma separated list of operations
+	AnnotationOperations = "dcm.io/operations"
+	AnnotationZone       = "dcm.io/zone"
+	AnnotationRegion     = "dcm.io/region"
+	// AnnotationLabels is a comma separated list of key=value labels
+	AnnotationLabels = "dcm.io/labels"
+	// AnnotationPort is the name or number of the Service port of the
+	// provider API, the first port by default
+	AnnotationPort = "dcm.io/port"
+	// AnnotationScheme is http or https, http by default
+	AnnotationScheme = "dcm.io/scheme"
+	// AnnotationPath is the base path of the provider API
+	AnnotationPath = "dcm.io/path"
+)
+
+// Labels set on the discovered registrations
+const (
+	ManagedByLabel = "dcm.io/managed-by"
+	ManagedByValue = "dcm-discovery"
+	// SourceLabel is the namespace and name of the Service, joined with a dot
+	SourceLabel = "dcm.io/discovered-from"
+)
+
+// desiredRegistration is the registration of a discovered Service
+type desiredRegistration struct {
+	serviceID    string
+	resourceKind string
+	endpoint     string
+	metadata     server.ProviderMetadata
+	operations   []string
+}
+
+// isDiscovered reports whether svc is annotated as a provider
+func isDiscovered(svc *corev1.Service) bool {
+	return svc.Annotations[AnnotationResourceKind] != ""
+}
+
+// registrationFor returns the registration described by the annotations of
+// svc
+func registrationFor(svc *corev1.Service) (*desiredRegistration, error) {
+	annotations := svc.Annotations
+
+	serviceID := annotations[AnnotationServiceID]
+	if serviceID == "" {
+		serviceID = string(svc.UID)
+	}
+
+	endpoint, err := endpointFor(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := parseLabels(annotations[AnnotationLabels])
+	if err != nil {
+		return nil, err
+	}
+	labels[ManagedByLabel] = ManagedByValue
+	labels[SourceLabel] = svc.Namespace + "." + svc.Name
+
+	return &desiredRegistration{
+		serviceID:    serviceID,
+		resourceKind: annotations[AnnotationResourceKind],
+		endpoint:     endpoint,
+		metadata: server.ProviderMetadata{
+			Zone:   annotations[AnnotationZone],
+			Region: annotations[AnnotationRegion],
+			Labels: &labels,
+		},
+		operations: splitList(annotations[AnnotationOperations]),
+	}, nil
+}
+
+// endpointFor returns the in-cluster URL of the provider API of svc
+func endpointFor(svc *corev1.Service) (string, error) {
+	if len(svc.Spec.Ports) == 0 {
+		return "", fmt.Errorf("service has no ports")
+	}
+
+	port := svc.Spec.Ports[0]
+	if name := svc.Annotations[AnnotationPort]; name != "" {
+		found := false
+		for _, p := range svc.Spec.Ports {
+			if p.Name == name || strconv.Itoa(int(p.Port)) == name {
+				port, found = p, true
+				break
+			}
+		}
+		if !found {
+			return "", fmt.Errorf("service has no port %q", name)
+		}
+	}
+
+	scheme := svc.Annotations[AnnotationScheme]
+	if scheme == "" {
+		scheme = "http"
+	}
+	if scheme != "http" && scheme != "https" {
+		return "", fmt.Errorf("unsupported scheme %q, use http or https", scheme)
+	}
+
+	u := url.URL{
+		Scheme: scheme,
+		Host:   net.JoinHostPort(fmt.Sprintf("%s.%s.svc", svc.Name, svc.Namespace), strconv.Itoa(int(port.Port))),
+		Path:   svc.Annotations[AnnotationPath],
+	}
+	return u.String(), nil
+}
+
+func parseLabels(value string) (map[string]string, error) {
+	labels := make(map[string]string)
+	for _, pair := range splitList(value) {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid label %q in %s, use key=value", pair, AnnotationLabels)
+		}
+		labels[k] = v
+	}
+	return labels, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
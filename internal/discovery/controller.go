@@ -0,0 +1,272 @@
+// Package discovery registers the providers running in a Kubernetes cluster
+// from the annotations of their Services.
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/pkg/registration"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	discoverylisters "k8s.io/client-go/listers/discovery/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// Options of the discovery controller
+type Options struct {
+	// Namespace restricts the discovery to one namespace, all namespaces are
+	// watched when empty
+	Namespace string
+	// ResyncPeriod is the period of the reconciliation of all the Services
+	ResyncPeriod time.Duration
+	// Workers is the number of Services reconciled concurrently
+	Workers int
+}
+
+// Controller registers the annotated Services that have ready endpoints, and
+// unregisters them when they are deleted, lose their annotations or their
+// ready endpoints.
+//
+// The registrations are tracked in memory: the Services deleted while the
+// controller is not running stay registered.
+type Controller struct {
+	registration *registration.Handler
+	opts         Options
+
+	factory  informers.SharedInformerFactory
+	services corelisters.ServiceLister
+	slices   discoverylisters.EndpointSliceLister
+	queue    workqueue.TypedRateLimitingInterface[string]
+
+	mu sync.Mutex
+	// registered is the last registration of each Service key
+	registered map[string]desiredRegistration
+}
+
+func NewController(client kubernetes.Interface, registrationHandler *registration.Handler, opts Options) *Controller {
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	factory := informers.NewSharedInformerFactoryWithOptions(client, opts.ResyncPeriod, informers.WithNamespace(opts.Namespace))
+
+	c := &Controller{
+		registration: registrationHandler,
+		opts:         opts,
+		factory:      factory,
+		services:     factory.Core().V1().Services().Lister(),
+		slices:       factory.Discovery().V1().EndpointSlices().Lister(),
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: "discovery"},
+		),
+		registered: make(map[string]desiredRegistration),
+	}
+
+	enqueue := func(obj interface{}) {
+		key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+		if err == nil {
+			c.queue.Add(key)
+		}
+	}
+	_, _ = factory.Core().V1().Services().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    enqueue,
+		UpdateFunc: func(_, obj interface{}) { enqueue(obj) },
+		DeleteFunc: enqueue,
+	})
+
+	// Endpoint slices are reconciled as their Service
+	enqueueService := func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		slice, ok := obj.(*discoveryv1.EndpointSlice)
+		if !ok {
+			return
+		}
+		if name := slice.Labels[discoveryv1.LabelServiceName]; name != "" {
+			c.queue.Add(slice.Namespace + "/" + name)
+		}
+	}
+	_, _ = factory.Discovery().V1().EndpointSlices().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    enqueueService,
+		UpdateFunc: func(_, obj interface{}) { enqueueService(obj) },
+		DeleteFunc: enqueueService,
+	})
+
+	return c
+}
+
+// Run watches the Services until ctx is done
+func (c *Controller) Run(ctx context.Context) error {
+	logger := zap.S().Named("discovery")
+	defer c.queue.ShutDown()
+
+	c.factory.Start(ctx.Done())
+	defer c.factory.Shutdown()
+
+	logger.Infow("Waiting for the informer caches to sync", "namespace", c.opts.Namespace)
+	for informer, synced := range c.factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return fmt.Errorf("failed to sync the informer cache of %v", informer)
+		}
+	}
+
+	logger.Infow("Discovering providers", "workers", c.opts.Workers)
+	var wg sync.WaitGroup
+	for i := 0; i < c.opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c.processNextItem(ctx) {
+			}
+		}()
+	}
+
+	<-ctx.Done()
+	c.queue.ShutDown()
+	wg.Wait()
+	return nil
+}
+
+func (c *Controller) processNextItem(ctx context.Context) bool {
+	key, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.reconcile(ctx, key); err != nil {
+		zap.S().Named("discovery").Warnw("Failed to reconcile service, retrying", "service", key, "error", err)
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+// reconcile registers or unregisters the Service with key
+func (c *Controller) reconcile(ctx context.Context, key string) error {
+	logger := zap.S().Named("discovery")
+
+	desired, err := c.desiredRegistration(key)
+	if err != nil {
+		var invalid *invalidServiceError
+		if !errors.As(err, &invalid) {
+			return err
+		}
+		// Retrying does not help until the Service is updated
+		logger.Warnw("Ignoring service with invalid annotations", "service", key, "error", err)
+		desired = nil
+	}
+
+	c.mu.Lock()
+	current, registered := c.registered[key]
+	c.mu.Unlock()
+
+	if registered && (desired == nil || current.serviceID != desired.serviceID || current.resourceKind != desired.resourceKind) {
+		if err := c.registration.Unregister(ctx, current.serviceID, current.resourceKind); err != nil && !isNotFound(err) {
+			return err
+		}
+		c.mu.Lock()
+		delete(c.registered, key)
+		c.mu.Unlock()
+		registered = false
+		logger.Infow("Unregistered service", "service", key, "service_id", current.serviceID, "resource_kind", current.resourceKind)
+	}
+
+	if desired == nil || (registered && reflect.DeepEqual(current, *desired)) {
+		return nil
+	}
+
+	_, err = c.registration.Register(ctx, desired.serviceID, desired.resourceKind, desired.endpoint, desired.metadata, desired.operations)
+	if err != nil {
+		var regErr *registration.RegistrationError
+		if errors.As(err, &regErr) && regErr.Code == registration.ErrCodeValidation {
+			logger.Warnw("Ignoring service with invalid registration", "service", key, "error", err)
+			return nil
+		}
+		return err
+	}
+	c.mu.Lock()
+	c.registered[key] = *desired
+	c.mu.Unlock()
+	logger.Infow("Registered service", "service", key, "service_id", desired.serviceID, "resource_kind", desired.resourceKind, "endpoint", desired.endpoint)
+	return nil
+}
+
+// invalidServiceError is returned for the Services whose annotations do not
+// describe a registration
+type invalidServiceError struct {
+	err error
+}
+
+func (e *invalidServiceError) Error() string {
+	return e.err.Error()
+}
+
+// desiredRegistration returns the registration of the Service with key, nil
+// if it must not be registered
+func (c *Controller) desiredRegistration(key string) (*desiredRegistration, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, &invalidServiceError{err: err}
+	}
+
+	svc, err := c.services.Services(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !isDiscovered(svc) {
+		return nil, nil
+	}
+
+	ready, err := c.hasReadyEndpoints(svc)
+	if err != nil || !ready {
+		return nil, err
+	}
+
+	desired, err := registrationFor(svc)
+	if err != nil {
+		return nil, &invalidServiceError{err: err}
+	}
+	return desired, nil
+}
+
+// hasReadyEndpoints reports whether an endpoint slice of svc has a ready
+// endpoint
+func (c *Controller) hasReadyEndpoints(svc *corev1.Service) (bool, error) {
+	selector := labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: svc.Name})
+	slices, err := c.slices.EndpointSlices(svc.Namespace).List(selector)
+	if err != nil {
+		return false, err
+	}
+	for _, slice := range slices {
+		for _, endpoint := range slice.Endpoints {
+			// A nil condition is to be interpreted as ready
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func isNotFound(err error) bool {
+	var regErr *registration.RegistrationError
+	return errors.As(err, &regErr) && regErr.Code == registration.ErrCodeNotFound
+}
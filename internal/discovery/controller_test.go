@@ -0,0 +1,233 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/dcm-project/service-provider-api/internal/store/model"
+	storeregistration "github.com/dcm-project/service-provider-api/internal/store/registration"
+	"github.com/dcm-project/service-provider-api/pkg/registration"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const namespace = "providers"
+
+// startController runs a controller on client, registering into a memory
+// store, until the end of the test
+func startController(t *testing.T, client kubernetes.Interface) store.Store {
+	t.Helper()
+
+	s := store.NewMemoryStore()
+	handler, err := registration.NewHandler(registration.Config{
+		RegistryStore: storeregistration.NewRegistrationRegistryAdapter(s),
+		CatalogStore:  storeregistration.NewRegistrationCatalogAdapter(s),
+	})
+	if err != nil {
+		t.Fatalf("creating the registration handler: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- NewController(client, handler, Options{Namespace: namespace}).Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("running the controller: %v", err)
+		}
+	})
+	return s
+}
+
+func newService(name string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			UID:       types.UID(uuid.NewString()),
+			Annotations: map[string]string{
+				AnnotationResourceKind: "vm",
+				AnnotationOperations:   "CREATE,DELETE",
+				AnnotationZone:         "zone-a",
+				AnnotationRegion:       "region-1",
+				AnnotationPort:         "api",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Name: "metrics", Port: 9090}, {Name: "api", Port: 8080}},
+		},
+	}
+}
+
+func newEndpointSlice(svc *corev1.Service, ready bool) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      svc.Name + "-abcde",
+			Namespace: svc.Namespace,
+			Labels:    map[string]string{discoveryv1.LabelServiceName: svc.Name},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{{
+			Addresses:  []string{"10.0.0.1"},
+			Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+		}},
+	}
+}
+
+// waitForProvider waits until the provider of svc matches, nil waiting for
+// the provider to be unregistered
+func waitForProvider(t *testing.T, s store.Store, svc *corev1.Service, match func(*model.Provider) bool) *model.Provider {
+	t.Helper()
+
+	id := uuid.MustParse(string(svc.UID))
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		provider, err := s.Provider().Get(context.Background(), id)
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			t.Fatalf("getting the provider of %s: %v", svc.Name, err)
+		}
+		if match == nil && provider == nil || match != nil && provider != nil && match(provider) {
+			return provider
+		}
+		if time.Now().After(deadline) {
+			if match == nil {
+				t.Fatalf("expected the service %s to be unregistered, got %+v", svc.Name, provider)
+			}
+			t.Fatalf("expected the service %s to be registered, got %+v", svc.Name, provider)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func registered(*model.Provider) bool { return true }
+
+func TestControllerRegistersAnnotatedService(t *testing.T) {
+	svc := newService("vm-provider")
+	client := fake.NewClientset(svc, newEndpointSlice(svc, true))
+	s := startController(t, client)
+
+	provider := waitForProvider(t, s, svc, registered)
+	if provider.ProviderType != "vm" || provider.Endpoint != "http://vm-provider.providers.svc:8080" {
+		t.Errorf("expected a vm provider at the api port, got %s at %s", provider.ProviderType, provider.Endpoint)
+	}
+	if !slices.Equal(provider.Operations, []string{"CREATE", "DELETE"}) {
+		t.Errorf("expected the annotated operations, got %v", provider.Operations)
+	}
+	if provider.Zone != "zone-a" || provider.Region != "region-1" {
+		t.Errorf("expected zone-a in region-1, got %s in %s", provider.Zone, provider.Region)
+	}
+	if provider.Labels[ManagedByLabel] != ManagedByValue || provider.Labels[SourceLabel] != "providers.vm-provider" {
+		t.Errorf("expected the discovery labels, got %v", provider.Labels)
+	}
+}
+
+func TestControllerWaitsForReadyEndpoints(t *testing.T) {
+	ctx := context.Background()
+	svc := newService("vm-provider")
+	slice := newEndpointSlice(svc, false)
+	client := fake.NewClientset(svc, slice)
+	s := startController(t, client)
+
+	// Give the controller the time to reconcile the Service
+	time.Sleep(200 * time.Millisecond)
+	waitForProvider(t, s, svc, nil)
+
+	slice = newEndpointSlice(svc, true)
+	if _, err := client.DiscoveryV1().EndpointSlices(namespace).Update(ctx, slice, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("updating the endpoint slice: %v", err)
+	}
+	waitForProvider(t, s, svc, registered)
+
+	if err := client.DiscoveryV1().EndpointSlices(namespace).Delete(ctx, slice.Name, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("deleting the endpoint slice: %v", err)
+	}
+	waitForProvider(t, s, svc, nil)
+}
+
+func TestControllerRegistersAgainWhenEndpointsAreReadyAgain(t *testing.T) {
+	ctx := context.Background()
+	svc := newService("vm-provider")
+	client := fake.NewClientset(svc, newEndpointSlice(svc, true))
+	s := startController(t, client)
+	waitForProvider(t, s, svc, registered)
+
+	for _, ready := range []bool{false, true, false, true} {
+		if _, err := client.DiscoveryV1().EndpointSlices(namespace).Update(ctx, newEndpointSlice(svc, ready), metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("updating the endpoint slice: %v", err)
+		}
+		if ready {
+			waitForProvider(t, s, svc, registered)
+		} else {
+			waitForProvider(t, s, svc, nil)
+		}
+	}
+}
+
+func TestControllerUpdatesRegistration(t *testing.T) {
+	ctx := context.Background()
+	svc := newService("vm-provider")
+	client := fake.NewClientset(svc, newEndpointSlice(svc, true))
+	s := startController(t, client)
+	waitForProvider(t, s, svc, registered)
+
+	svc = svc.DeepCopy()
+	svc.Annotations[AnnotationZone] = "zone-b"
+	svc.Annotations[AnnotationOperations] = "CREATE,READ,DELETE"
+	if _, err := client.CoreV1().Services(namespace).Update(ctx, svc, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("updating the service: %v", err)
+	}
+	provider := waitForProvider(t, s, svc, func(p *model.Provider) bool { return p.Zone == "zone-b" })
+	if !slices.Equal(provider.Operations, []string{"CREATE", "READ", "DELETE"}) {
+		t.Errorf("expected the updated operations, got %v", provider.Operations)
+	}
+}
+
+func TestControllerUnregistersOnAnnotationRemoval(t *testing.T) {
+	ctx := context.Background()
+	svc := newService("vm-provider")
+	client := fake.NewClientset(svc, newEndpointSlice(svc, true))
+	s := startController(t, client)
+	waitForProvider(t, s, svc, registered)
+
+	svc = svc.DeepCopy()
+	delete(svc.Annotations, AnnotationResourceKind)
+	if _, err := client.CoreV1().Services(namespace).Update(ctx, svc, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("updating the service: %v", err)
+	}
+	waitForProvider(t, s, svc, nil)
+}
+
+func TestControllerUnregistersDeletedService(t *testing.T) {
+	ctx := context.Background()
+	svc := newService("vm-provider")
+	client := fake.NewClientset(svc, newEndpointSlice(svc, true))
+	s := startController(t, client)
+	waitForProvider(t, s, svc, registered)
+
+	if err := client.CoreV1().Services(namespace).Delete(ctx, svc.Name, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("deleting the service: %v", err)
+	}
+	waitForProvider(t, s, svc, nil)
+}
+
+func TestControllerIgnoresOtherServices(t *testing.T) {
+	svc := newService("vm-provider")
+	delete(svc.Annotations, AnnotationResourceKind)
+	invalid := newService("invalid-provider")
+	invalid.Annotations[AnnotationPort] = "missing"
+	client := fake.NewClientset(svc, newEndpointSlice(svc, true), invalid, newEndpointSlice(invalid, true))
+	s := startController(t, client)
+
+	time.Sleep(200 * time.Millisecond)
+	waitForProvider(t, s, svc, nil)
+	waitForProvider(t, s, invalid, nil)
+}
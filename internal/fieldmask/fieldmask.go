@@ -0,0 +1,52 @@
+// Package fieldmask implements AEP-134 style update masks for partial updates
+package fieldmask
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Wildcard selects all mutable fields of a resource
+const Wildcard = "*"
+
+// Mask is a list of field paths, using the JSON names of the fields and dots
+// to separate nested fields (e.g. "metadata.zone")
+type Mask []string
+
+// Parse parses a comma separated update_mask query parameter
+func Parse(mask string) Mask {
+	var paths Mask
+	for _, path := range strings.Split(mask, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// Validate checks that every path is either the wildcard or one of the mutable
+// fields, and returns an error naming the first immutable or unknown field
+func (m Mask) Validate(mutable, immutable []string) error {
+	for _, path := range m {
+		switch {
+		case path == Wildcard:
+		case slices.Contains(immutable, path):
+			return fmt.Errorf("field %q is immutable", path)
+		case !slices.Contains(mutable, path):
+			return fmt.Errorf("unknown field %q in update mask", path)
+		}
+	}
+	return nil
+}
+
+// Contains reports whether the field at path is selected by the mask, either
+// directly, through one of its parent fields or through the wildcard
+func (m Mask) Contains(path string) bool {
+	for _, p := range m {
+		if p == Wildcard || p == path || strings.HasPrefix(path, p+".") {
+			return true
+		}
+	}
+	return false
+}
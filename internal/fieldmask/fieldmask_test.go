@@ -0,0 +1,88 @@
+package fieldmask
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		mask string
+		want Mask
+	}{
+		{mask: "", want: nil},
+		{mask: " , ", want: nil},
+		{mask: "name", want: Mask{"name"}},
+		{mask: "name, description ,,operations", want: Mask{"name", "description", "operations"}},
+		{mask: "metadata.zone,*", want: Mask{"metadata.zone", "*"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mask, func(t *testing.T) {
+			if got := Parse(tt.mask); !slices.Equal(got, tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	mutable := []string{"name", "description", "metadata.zone"}
+	immutable := []string{"id", "type"}
+
+	tests := []struct {
+		name string
+		mask Mask
+		// wantErr is a substring of the expected error, empty for a valid mask
+		wantErr string
+	}{
+		{name: "empty", mask: nil},
+		{name: "mutable fields", mask: Mask{"name", "description"}},
+		{name: "wildcard", mask: Mask{Wildcard}},
+		{name: "nested field", mask: Mask{"metadata.zone"}},
+		{name: "immutable field", mask: Mask{"name", "id"}, wantErr: `field "id" is immutable`},
+		{name: "immutable field with wildcard", mask: Mask{Wildcard, "type"}, wantErr: `field "type" is immutable`},
+		{name: "unknown field", mask: Mask{"labels"}, wantErr: `unknown field "labels"`},
+		{name: "unknown nested field", mask: Mask{"metadata.region"}, wantErr: `unknown field "metadata.region"`},
+		{name: "field below a mutable field", mask: Mask{"name.first"}, wantErr: `unknown field "name.first"`},
+		{name: "parent of a nested field", mask: Mask{"metadata"}, wantErr: `unknown field "metadata"`},
+		{name: "case sensitive", mask: Mask{"Name"}, wantErr: `unknown field "Name"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.mask.Validate(mutable, immutable)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("expected the mask to be valid, got %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		name string
+		mask Mask
+		path string
+		want bool
+	}{
+		{name: "empty mask", mask: nil, path: "name", want: false},
+		{name: "field", mask: Mask{"name"}, path: "name", want: true},
+		{name: "other field", mask: Mask{"name"}, path: "description", want: false},
+		{name: "wildcard", mask: Mask{Wildcard}, path: "metadata.zone", want: true},
+		{name: "parent field", mask: Mask{"metadata"}, path: "metadata.zone", want: true},
+		{name: "nested field", mask: Mask{"metadata.zone"}, path: "metadata.zone", want: true},
+		{name: "sibling nested field", mask: Mask{"metadata.zone"}, path: "metadata.region", want: false},
+		{name: "parent of a nested field", mask: Mask{"metadata.zone"}, path: "metadata", want: false},
+		{name: "field with the same prefix", mask: Mask{"meta"}, path: "metadata.zone", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mask.Contains(tt.path); got != tt.want {
+				t.Errorf("expected %q to contain %q: %v, got %v", tt.mask, tt.path, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/dcm-project/service-provider-api/internal/api/server"
+	"github.com/dcm-project/service-provider-api/pkg/registration"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// Stable error codes returned in the code field of error responses.
+// Registration failures use the registration.ErrCode* values instead.
+const (
+	ErrCodeInvalidArgument      = registration.ErrCodeInvalidArgument
+	ErrCodeNotFound             = "NOT_FOUND"
+	ErrCodeAlreadyExists        = "ALREADY_EXISTS"
+	ErrCodePreconditionFailed   = registration.ErrCodePreconditionFailed
+	ErrCodeEndpointUnreachable  = registration.ErrCodeEndpointUnreachable
+	ErrCodeIdempotencyKeyReused = "IDEMPOTENCY_KEY_REUSED"
+	ErrCodeIdempotencyKeyInUse  = "IDEMPOTENCY_KEY_IN_USE"
+	ErrCodeRequestTooLarge      = "REQUEST_TOO_LARGE"
+	ErrCodeInternal             = "INTERNAL"
+)
+
+// NewError builds the error envelope for the request handled with ctx
+func NewError(ctx context.Context, code, message string) server.Error {
+	apiErr := server.Error{
+		Code:    code,
+		Message: message,
+	}
+	if requestID := middleware.GetReqID(ctx); requestID != "" {
+		apiErr.RequestId = &requestID
+	}
+	return apiErr
+}
+
+// WriteError writes the error envelope from plain HTTP handlers and middlewares
+func WriteError(w http.ResponseWriter, r *http.Request, statusCode int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(NewError(r.Context(), code, message))
+}
+
+// RequestErrorHandler reports requests the strict handlers failed to decode
+func RequestErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	WriteError(w, r, http.StatusBadRequest, ErrCodeInvalidArgument, err.Error())
+}
+
+// ResponseErrorHandler reports errors returned by the strict handlers
+func ResponseErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	WriteError(w, r, http.StatusInternalServerError, ErrCodeInternal, err.Error())
+}
+
+// registrationError converts an error returned by the registration handler
+// into the error envelope and the HTTP status for its code
+func registrationError(ctx context.Context, err error) (int, server.Error) {
+	var regErr *registration.RegistrationError
+	if !errors.As(err, &regErr) {
+		return http.StatusInternalServerError, NewError(ctx, ErrCodeInternal, err.Error())
+	}
+
+	apiErr := NewError(ctx, regErr.Code, regErr.Message)
+	if regErr.Err != nil {
+		apiErr.Details = &[]map[string]interface{}{
+			{"cause": regErr.Err.Error()},
+		}
+	}
+	return RegistrationErrorStatus(regErr.Code), apiErr
+}
+
+// RegistrationErrorStatus returns the HTTP status for a registration error code
+func RegistrationErrorStatus(code string) int {
+	switch code {
+	case registration.ErrCodeInvalidArgument:
+		return http.StatusBadRequest
+	case registration.ErrCodeValidation:
+		return http.StatusUnprocessableEntity
+	case registration.ErrCodeNotFound:
+		return http.StatusNotFound
+	case registration.ErrCodeConflict:
+		return http.StatusConflict
+	case registration.ErrCodePreconditionFailed:
+		return http.StatusPreconditionFailed
+	case registration.ErrCodeEndpointUnreachable:
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
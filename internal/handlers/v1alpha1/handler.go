@@ -2,20 +2,28 @@ package v1alpha1
 
 import (
 	"context"
-	"time"
+	"errors"
+	"net/http"
 
 	"github.com/dcm-project/service-provider-api/internal/api/server"
+	"github.com/dcm-project/service-provider-api/internal/apply"
+	"github.com/dcm-project/service-provider-api/internal/health"
 	"github.com/dcm-project/service-provider-api/internal/service"
 	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/dcm-project/service-provider-api/internal/store/model"
 	storeregistration "github.com/dcm-project/service-provider-api/internal/store/registration"
 	"github.com/dcm-project/service-provider-api/pkg/registration"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 type ServiceHandler struct {
 	providerService     *service.ProviderService
 	registrationHandler *registration.Handler
 	store               store.Store
+	logLevel            zap.AtomicLevel
+	healthChecker       *health.Checker
+	applier             *apply.Applier
 }
 
 func NewServiceHandler(providerService *service.ProviderService) *ServiceHandler {
@@ -32,11 +40,60 @@ func (s *ServiceHandler) SetStore(store store.Store) {
 	s.store = store
 }
 
+// SetHealthChecker sets the checker run by the readiness endpoint
+func (s *ServiceHandler) SetHealthChecker(checker *health.Checker) {
+	s.healthChecker = checker
+}
+
+// SetApplier sets the applier of the manifests posted to the apply endpoint
+func (s *ServiceHandler) SetApplier(applier *apply.Applier) {
+	s.applier = applier
+}
+
+// SetAtomicLevel sets the level changed by the log level admin endpoints
+func (s *ServiceHandler) SetAtomicLevel(level zap.AtomicLevel) {
+	s.logLevel = level
+}
+
 // ListHealth (GET /health)
 func (s *ServiceHandler) ListHealth(ctx context.Context, request server.ListHealthRequestObject) (server.ListHealthResponseObject, error) {
 	return server.ListHealth200Response{}, nil
 }
 
+// GetLiveness (GET /livez)
+func (s *ServiceHandler) GetLiveness(ctx context.Context, request server.GetLivenessRequestObject) (server.GetLivenessResponseObject, error) {
+	return server.GetLiveness200JSONResponse{Status: server.Ok}, nil
+}
+
+// GetReadiness (GET /readyz)
+func (s *ServiceHandler) GetReadiness(ctx context.Context, request server.GetReadinessRequestObject) (server.GetReadinessResponseObject, error) {
+	if s.healthChecker == nil {
+		return server.GetReadiness200JSONResponse{Status: server.Ok}, nil
+	}
+
+	ready, results := s.healthChecker.Check(ctx)
+	checks := make(map[string]server.HealthCheck, len(results))
+	for _, result := range results {
+		durationMs := result.Duration.Milliseconds()
+		check := server.HealthCheck{
+			Status:     server.Ok,
+			DurationMs: &durationMs,
+		}
+		if result.Err != nil {
+			message := result.Err.Error()
+			check.Status = server.Unavailable
+			check.Error = &message
+		}
+		checks[result.Name] = check
+	}
+
+	if !ready {
+		zap.S().Named("handler:getReadiness").Warnw("Service is not ready", "checks", checks)
+		return server.GetReadiness503JSONResponse{Status: server.Unavailable, Checks: &checks}, nil
+	}
+	return server.GetReadiness200JSONResponse{Status: server.Ok, Checks: &checks}, nil
+}
+
 // ListProviders (GET /providers)
 func (s *ServiceHandler) ListProviders(ctx context.Context, request server.ListProvidersRequestObject) (server.ListProvidersResponseObject, error) {
 	logger := zap.S().Named("handler:listProviders")
@@ -49,7 +106,7 @@ func (s *ServiceHandler) ListProviders(ctx context.Context, request server.ListP
 
 	providers, err := s.providerService.ListProvider(ctx, providerType)
 	if err != nil {
-		return nil, err
+		return server.ListProviders500JSONResponse(NewError(ctx, ErrCodeInternal, err.Error())), nil
 	}
 	return server.ListProviders200JSONResponse{
 		Providers: providers,
@@ -61,21 +118,21 @@ func (s *ServiceHandler) CreateProvider(ctx context.Context, request server.Crea
 	logger := zap.S().Named("handler:createProvider")
 	logger.Info("Creating new service provider")
 
-	newProvider := request.Body
-	err := s.providerService.CreateProvider(ctx, newProvider)
+	newProvider, err := s.providerService.CreateProvider(ctx, request.Body)
 	if err != nil {
-		return server.CreateProvider400JSONResponse{Error: err.Error()}, nil
+		switch {
+		case errors.Is(err, service.ErrAlreadyExists):
+			return server.CreateProvider409JSONResponse(NewError(ctx, ErrCodeAlreadyExists, err.Error())), nil
+		case errors.Is(err, service.ErrEndpointUnreachable):
+			return server.CreateProvider502JSONResponse(NewError(ctx, ErrCodeEndpointUnreachable, err.Error())), nil
+		case errors.Is(err, service.ErrInvalidArgument):
+			return server.CreateProvider400JSONResponse(NewError(ctx, ErrCodeInvalidArgument, err.Error())), nil
+		}
+		logger.Errorw("Failed to create provider", "error", err)
+		return server.CreateProvider500JSONResponse(NewError(ctx, ErrCodeInternal, err.Error())), nil
 	}
 
-	return server.CreateProvider201JSONResponse{
-		Description: newProvider.Description,
-		Endpoint:    newProvider.Endpoint,
-		Id:          newProvider.Id,
-		Name:        newProvider.Name,
-		Type:        newProvider.Type,
-		Operations:  newProvider.Operations,
-		ApiHost:     newProvider.ApiHost,
-	}, nil
+	return server.CreateProvider201JSONResponse(newProvider), nil
 }
 
 // GetProvider (GET /providers/{providerId})
@@ -84,16 +141,19 @@ func (s *ServiceHandler) GetProvider(ctx context.Context, request server.GetProv
 	logger.Info("Retrieving provider details: ", "ID: ", request.ProviderId)
 	providerInfo, err := s.providerService.GetProvider(ctx, request.ProviderId.String())
 	if err != nil {
-		return server.GetProvider404JSONResponse{Error: err.Error()}, nil
+		if errors.Is(err, service.ErrNotFound) {
+			return server.GetProvider404JSONResponse(NewError(ctx, ErrCodeNotFound, err.Error())), nil
+		}
+		return server.GetProvider500JSONResponse(NewError(ctx, ErrCodeInternal, err.Error())), nil
+	}
+
+	etag := *providerInfo.Etag
+	if request.Params.IfNoneMatch != nil && model.MatchETag(*request.Params.IfNoneMatch, etag) {
+		return server.GetProvider304Response{Headers: server.GetProvider304ResponseHeaders{ETag: etag}}, nil
 	}
 	return server.GetProvider200JSONResponse{
-		Description: providerInfo.Description,
-		Endpoint:    providerInfo.Endpoint,
-		Id:          providerInfo.Id,
-		Name:        providerInfo.Name,
-		Type:        providerInfo.Type,
-		ApiHost:     providerInfo.ApiHost,
-		Operations:  providerInfo.Operations,
+		Body:    providerInfo,
+		Headers: server.GetProvider200ResponseHeaders{ETag: etag},
 	}, nil
 }
 
@@ -102,12 +162,72 @@ func (s *ServiceHandler) ApplyProvider(ctx context.Context, request server.Apply
 	logger := zap.S().Named("handler:applyProvider")
 	logger.Info("Updating provider details: ", "ID: ", request.ProviderId)
 
-	_, err := s.providerService.UpdateProvider(ctx, *request.Body)
+	// The precondition may be given either as If-Match header or as etag field
+	ifMatch := ""
+	if request.Body.Etag != nil {
+		ifMatch = *request.Body.Etag
+	}
+	if request.Params.IfMatch != nil {
+		ifMatch = *request.Params.IfMatch
+	}
 
+	provider, err := s.providerService.UpdateProvider(ctx, request.ProviderId.String(), *request.Body, ifMatch)
 	if err != nil {
-		return server.ApplyProvider404JSONResponse{Error: err.Error()}, nil
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument):
+			return server.ApplyProvider400JSONResponse(NewError(ctx, ErrCodeInvalidArgument, err.Error())), nil
+		case errors.Is(err, service.ErrNotFound):
+			return server.ApplyProvider404JSONResponse(NewError(ctx, ErrCodeNotFound, err.Error())), nil
+		case errors.Is(err, service.ErrPreconditionFailed):
+			return server.ApplyProvider412JSONResponse(NewError(ctx, ErrCodePreconditionFailed, err.Error())), nil
+		case errors.Is(err, service.ErrEndpointUnreachable):
+			return server.ApplyProvider502JSONResponse(NewError(ctx, ErrCodeEndpointUnreachable, err.Error())), nil
+		}
+		logger.Errorw("Failed to update provider", "error", err)
+		return server.ApplyProvider500JSONResponse(NewError(ctx, ErrCodeInternal, err.Error())), nil
 	}
-	return nil, nil
+	return server.ApplyProvider200JSONResponse{
+		Body:    provider,
+		Headers: server.ApplyProvider200ResponseHeaders{ETag: *provider.Etag},
+	}, nil
+}
+
+// PatchProvider (PATCH /providers/{providerId})
+func (s *ServiceHandler) PatchProvider(ctx context.Context, request server.PatchProviderRequestObject) (server.PatchProviderResponseObject, error) {
+	logger := zap.S().Named("handler:patchProvider")
+	logger.Info("Patching provider details: ", "ID: ", request.ProviderId)
+
+	ifMatch := ""
+	if request.Body.Etag != nil {
+		ifMatch = *request.Body.Etag
+	}
+	if request.Params.IfMatch != nil {
+		ifMatch = *request.Params.IfMatch
+	}
+	updateMask := ""
+	if request.Params.UpdateMask != nil {
+		updateMask = *request.Params.UpdateMask
+	}
+
+	provider, err := s.providerService.PatchProvider(ctx, request.ProviderId.String(), *request.Body, updateMask, ifMatch)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument):
+			return server.PatchProvider400JSONResponse(NewError(ctx, ErrCodeInvalidArgument, err.Error())), nil
+		case errors.Is(err, service.ErrNotFound):
+			return server.PatchProvider404JSONResponse(NewError(ctx, ErrCodeNotFound, err.Error())), nil
+		case errors.Is(err, service.ErrPreconditionFailed):
+			return server.PatchProvider412JSONResponse(NewError(ctx, ErrCodePreconditionFailed, err.Error())), nil
+		case errors.Is(err, service.ErrEndpointUnreachable):
+			return server.PatchProvider502JSONResponse(NewError(ctx, ErrCodeEndpointUnreachable, err.Error())), nil
+		}
+		logger.Errorw("Failed to patch provider", "error", err)
+		return server.PatchProvider500JSONResponse(NewError(ctx, ErrCodeInternal, err.Error())), nil
+	}
+	return server.PatchProvider200JSONResponse{
+		Body:    provider,
+		Headers: server.PatchProvider200ResponseHeaders{ETag: *provider.Etag},
+	}, nil
 }
 
 // DeleteProvider (DELETE /providers/{providerId})
@@ -118,7 +238,11 @@ func (s *ServiceHandler) DeleteProvider(ctx context.Context, request server.Dele
 
 	err := s.providerService.DeleteProvider(ctx, providerID)
 	if err != nil {
-		return server.DeleteProvider404JSONResponse{}, err
+		if errors.Is(err, service.ErrNotFound) {
+			return server.DeleteProvider404JSONResponse(NewError(ctx, ErrCodeNotFound, err.Error())), nil
+		}
+		logger.Errorw("Failed to delete provider", "error", err)
+		return server.DeleteProvider500JSONResponse(NewError(ctx, ErrCodeInternal, err.Error())), nil
 	}
 	logger.Info("Successfully deleted service provider")
 	return server.DeleteProvider204JSONResponse{Id: providerID}, nil
@@ -129,12 +253,18 @@ func (s *ServiceHandler) RegisterProvider(ctx context.Context, request server.Re
 	logger := zap.S().Named("handler:registerProvider")
 
 	if s.registrationHandler == nil {
-		return server.RegisterProvider500JSONResponse{Error: "registration handler not initialized"}, nil
+		return server.RegisterProvider500JSONResponse(NewError(ctx, ErrCodeInternal, "registration handler not initialized")), nil
+	}
+
+	ifMatch := ""
+	if request.Params.IfMatch != nil {
+		ifMatch = *request.Params.IfMatch
 	}
 
 	// Call registration handler directly with OpenAPI types
-	resp, err := s.registrationHandler.Register(
+	resp, err := s.registrationHandler.RegisterIfMatch(
 		ctx,
+		ifMatch,
 		request.Body.ServiceId,
 		request.ResourceKind,
 		request.Body.Endpoint,
@@ -142,15 +272,25 @@ func (s *ServiceHandler) RegisterProvider(ctx context.Context, request server.Re
 		request.Body.Operations,
 	)
 	if err != nil {
-		regErr, ok := err.(*registration.RegistrationError)
-		if ok && regErr.Code == registration.ErrCodeValidation {
-			return server.RegisterProvider400JSONResponse{Error: regErr.Error()}, nil
+		status, apiErr := registrationError(ctx, err)
+		switch status {
+		case http.StatusConflict:
+			return server.RegisterProvider409JSONResponse(apiErr), nil
+		case http.StatusPreconditionFailed:
+			return server.RegisterProvider412JSONResponse(apiErr), nil
+		case http.StatusUnprocessableEntity:
+			return server.RegisterProvider422JSONResponse(apiErr), nil
+		case http.StatusBadGateway:
+			return server.RegisterProvider502JSONResponse(apiErr), nil
 		}
 		logger.Errorw("Registration failed", "error", err)
-		return server.RegisterProvider500JSONResponse{Error: err.Error()}, nil
+		return server.RegisterProvider500JSONResponse(apiErr), nil
 	}
 
-	return server.RegisterProvider200JSONResponse(*resp), nil
+	return server.RegisterProvider200JSONResponse{
+		Body:    *resp,
+		Headers: server.RegisterProvider200ResponseHeaders{ETag: *resp.Etag},
+	}, nil
 }
 
 // UnregisterProvider (DELETE /resource/{resourceKind}/provider/{providerId})
@@ -158,17 +298,20 @@ func (s *ServiceHandler) UnregisterProvider(ctx context.Context, request server.
 	logger := zap.S().Named("handler:unregisterProvider")
 
 	if s.registrationHandler == nil {
-		return server.UnregisterProvider500JSONResponse{Error: "registration handler not initialized"}, nil
+		return server.UnregisterProvider500JSONResponse(NewError(ctx, ErrCodeInternal, "registration handler not initialized")), nil
 	}
 
 	err := s.registrationHandler.Unregister(ctx, request.ProviderId, request.ResourceKind)
 	if err != nil {
-		regErr, ok := err.(*registration.RegistrationError)
-		if ok && regErr.Code == registration.ErrCodeNotFound {
-			return server.UnregisterProvider404JSONResponse{Error: regErr.Error()}, nil
+		status, apiErr := registrationError(ctx, err)
+		switch status {
+		case http.StatusUnprocessableEntity:
+			return server.UnregisterProvider422JSONResponse(apiErr), nil
+		case http.StatusNotFound:
+			return server.UnregisterProvider404JSONResponse(apiErr), nil
 		}
 		logger.Errorw("Unregistration failed", "error", err)
-		return server.UnregisterProvider500JSONResponse{Error: err.Error()}, nil
+		return server.UnregisterProvider500JSONResponse(apiErr), nil
 	}
 
 	return server.UnregisterProvider204Response{}, nil
@@ -179,30 +322,99 @@ func (s *ServiceHandler) GetRegisteredProvider(ctx context.Context, request serv
 	logger := zap.S().Named("handler:getRegisteredProvider")
 
 	if s.registrationHandler == nil {
-		return server.GetRegisteredProvider500JSONResponse{Error: "registration handler not initialized"}, nil
+		return server.GetRegisteredProvider500JSONResponse(NewError(ctx, ErrCodeInternal, "registration handler not initialized")), nil
 	}
 
 	provider, err := s.registrationHandler.GetRegistration(ctx, request.ProviderId, request.ResourceKind)
 	if err != nil {
-		regErr, ok := err.(*registration.RegistrationError)
-		if ok && regErr.Code == registration.ErrCodeNotFound {
-			return server.GetRegisteredProvider404JSONResponse{Error: regErr.Error()}, nil
+		status, apiErr := registrationError(ctx, err)
+		switch status {
+		case http.StatusUnprocessableEntity:
+			return server.GetRegisteredProvider422JSONResponse(apiErr), nil
+		case http.StatusNotFound:
+			return server.GetRegisteredProvider404JSONResponse(apiErr), nil
 		}
 		logger.Errorw("Failed to get provider", "error", err)
-		return server.GetRegisteredProvider500JSONResponse{Error: err.Error()}, nil
+		return server.GetRegisteredProvider500JSONResponse(apiErr), nil
+	}
+
+	if request.Params.IfNoneMatch != nil && model.MatchETag(*request.Params.IfNoneMatch, provider.ETag) {
+		return server.GetRegisteredProvider304Response{
+			Headers: server.GetRegisteredProvider304ResponseHeaders{ETag: provider.ETag},
+		}, nil
 	}
 
 	// Convert to OpenAPI response
 	return server.GetRegisteredProvider200JSONResponse{
-		ServiceId:    &provider.ServiceID,
-		ResourceKind: &provider.ResourceKind,
-		Endpoint:     &provider.Endpoint,
-		Metadata:     &provider.Metadata,
-		Operations:   &provider.Operations,
-		CatalogItem:  &provider.CatalogItem,
-		Status:       &provider.Status,
-		RegisteredAt: &provider.RegisteredAt,
-		UpdatedAt:    &provider.UpdatedAt,
+		Body: server.RegisteredProvider{
+			ServiceId:    &provider.ServiceID,
+			ResourceKind: &provider.ResourceKind,
+			Endpoint:     &provider.Endpoint,
+			Metadata:     &provider.Metadata,
+			Operations:   &provider.Operations,
+			CatalogItem:  &provider.CatalogItem,
+			Status:       &provider.Status,
+			RegisteredAt: &provider.RegisteredAt,
+			UpdatedAt:    &provider.UpdatedAt,
+			Etag:         &provider.ETag,
+		},
+		Headers: server.GetRegisteredProvider200ResponseHeaders{ETag: provider.ETag},
+	}, nil
+}
+
+// PatchRegisteredProvider (PATCH /resource/{resourceKind}/provider/{providerId})
+func (s *ServiceHandler) PatchRegisteredProvider(ctx context.Context, request server.PatchRegisteredProviderRequestObject) (server.PatchRegisteredProviderResponseObject, error) {
+	logger := zap.S().Named("handler:patchRegisteredProvider")
+
+	if s.registrationHandler == nil {
+		return server.PatchRegisteredProvider500JSONResponse(NewError(ctx, ErrCodeInternal, "registration handler not initialized")), nil
+	}
+
+	ifMatch := ""
+	if request.Body.Etag != nil {
+		ifMatch = *request.Body.Etag
+	}
+	if request.Params.IfMatch != nil {
+		ifMatch = *request.Params.IfMatch
+	}
+	updateMask := ""
+	if request.Params.UpdateMask != nil {
+		updateMask = *request.Params.UpdateMask
+	}
+
+	provider, err := s.registrationHandler.PatchRegistration(ctx, ifMatch, request.ProviderId, request.ResourceKind, *request.Body, updateMask)
+	if err != nil {
+		status, apiErr := registrationError(ctx, err)
+		switch status {
+		case http.StatusBadRequest:
+			return server.PatchRegisteredProvider400JSONResponse(apiErr), nil
+		case http.StatusNotFound:
+			return server.PatchRegisteredProvider404JSONResponse(apiErr), nil
+		case http.StatusPreconditionFailed:
+			return server.PatchRegisteredProvider412JSONResponse(apiErr), nil
+		case http.StatusUnprocessableEntity:
+			return server.PatchRegisteredProvider422JSONResponse(apiErr), nil
+		case http.StatusBadGateway:
+			return server.PatchRegisteredProvider502JSONResponse(apiErr), nil
+		}
+		logger.Errorw("Failed to patch provider", "error", err)
+		return server.PatchRegisteredProvider500JSONResponse(apiErr), nil
+	}
+
+	return server.PatchRegisteredProvider200JSONResponse{
+		Body: server.RegisteredProvider{
+			ServiceId:    &provider.ServiceID,
+			ResourceKind: &provider.ResourceKind,
+			Endpoint:     &provider.Endpoint,
+			Metadata:     &provider.Metadata,
+			Operations:   &provider.Operations,
+			CatalogItem:  &provider.CatalogItem,
+			Status:       &provider.Status,
+			RegisteredAt: &provider.RegisteredAt,
+			UpdatedAt:    &provider.UpdatedAt,
+			Etag:         &provider.ETag,
+		},
+		Headers: server.PatchRegisteredProvider200ResponseHeaders{ETag: provider.ETag},
 	}, nil
 }
 
@@ -211,13 +423,17 @@ func (s *ServiceHandler) ListRegisteredProviders(ctx context.Context, request se
 	logger := zap.S().Named("handler:listRegisteredProviders")
 
 	if s.registrationHandler == nil {
-		return server.ListRegisteredProviders500JSONResponse{Error: "registration handler not initialized"}, nil
+		return server.ListRegisteredProviders500JSONResponse(NewError(ctx, ErrCodeInternal, "registration handler not initialized")), nil
 	}
 
 	providers, err := s.registrationHandler.ListRegistrations(ctx, request.ResourceKind)
 	if err != nil {
+		status, apiErr := registrationError(ctx, err)
+		if status == http.StatusUnprocessableEntity {
+			return server.ListRegisteredProviders422JSONResponse(apiErr), nil
+		}
 		logger.Errorw("Failed to list providers", "error", err)
-		return server.ListRegisteredProviders500JSONResponse{Error: err.Error()}, nil
+		return server.ListRegisteredProviders500JSONResponse(apiErr), nil
 	}
 
 	// Convert to OpenAPI response types
@@ -233,6 +449,7 @@ func (s *ServiceHandler) ListRegisteredProviders(ctx context.Context, request se
 			Status:       &p.Status,
 			RegisteredAt: &p.RegisteredAt,
 			UpdatedAt:    &p.UpdatedAt,
+			Etag:         &p.ETag,
 		}
 	}
 
@@ -244,109 +461,17 @@ func (s *ServiceHandler) GetRegistry(ctx context.Context, request server.GetRegi
 	logger := zap.S().Named("handler:getRegistry")
 
 	if s.store == nil {
-		return server.GetRegistry500JSONResponse{Error: "store not initialized"}, nil
+		return server.GetRegistry500JSONResponse(NewError(ctx, ErrCodeInternal, "store not initialized")), nil
 	}
 
 	registryAdapter := storeregistration.NewRegistrationRegistryAdapter(s.store)
-
-	// Get unique resource kinds
-	resourceKinds, err := s.store.Catalog().GetDistinctResourceKinds(ctx)
+	providers, err := registryAdapter.ListRegisteredProviders(ctx)
 	if err != nil {
-		logger.Errorw("Failed to get resource kinds", "error", err)
-		return server.GetRegistry500JSONResponse{Error: "failed to retrieve registry"}, nil
-	}
-
-	// Collect all providers
-	providersMap := make(map[string]*registryProvider)
-
-	for _, resourceKind := range resourceKinds {
-		providers, err := registryAdapter.ListProviders(ctx, resourceKind)
-		if err != nil {
-			logger.Warnw("Failed to list providers", "resource_kind", resourceKind, "error", err)
-			continue
-		}
-
-		for _, provider := range providers {
-			entry, exists := providersMap[provider.ServiceID]
-			if !exists {
-				entry = &registryProvider{
-					ServiceID:     provider.ServiceID,
-					Metadata:      &provider.Metadata,
-					Status:        &provider.Status,
-					Registrations: []registryProviderRegistration{},
-					RegisteredAt:  &provider.RegisteredAt,
-				}
-				providersMap[provider.ServiceID] = entry
-			}
-
-			entry.Registrations = append(entry.Registrations, registryProviderRegistration{
-				ResourceKind: &provider.ResourceKind,
-				Endpoint:     &provider.Endpoint,
-				Operations:   &provider.Operations,
-				CatalogItem:  &provider.CatalogItem,
-				RegisteredAt: &provider.RegisteredAt,
-			})
-		}
+		logger.Errorw("Failed to list providers", "error", err)
+		return server.GetRegistry500JSONResponse(NewError(ctx, ErrCodeInternal, "failed to retrieve registry")), nil
 	}
 
-	// Build response using the inline struct types from RegistryView
-	registryItems := make([]struct {
-		Metadata      *server.ProviderMetadata `json:"metadata,omitempty"`
-		RegisteredAt  *time.Time               `json:"registered_at,omitempty"`
-		Registrations *[]struct {
-			CatalogItem  *string    `json:"catalog_item,omitempty"`
-			Endpoint     *string    `json:"endpoint,omitempty"`
-			Operations   *[]string  `json:"operations,omitempty"`
-			RegisteredAt *time.Time `json:"registered_at,omitempty"`
-			ResourceKind *string    `json:"resource_kind,omitempty"`
-		} `json:"registrations,omitempty"`
-		ServiceId *string `json:"service_id,omitempty"`
-		Status    *string `json:"status,omitempty"`
-	}, 0, len(providersMap))
-
-	for _, entry := range providersMap {
-		regs := make([]struct {
-			CatalogItem  *string    `json:"catalog_item,omitempty"`
-			Endpoint     *string    `json:"endpoint,omitempty"`
-			Operations   *[]string  `json:"operations,omitempty"`
-			RegisteredAt *time.Time `json:"registered_at,omitempty"`
-			ResourceKind *string    `json:"resource_kind,omitempty"`
-		}, len(entry.Registrations))
-
-		for i, reg := range entry.Registrations {
-			regs[i].ResourceKind = reg.ResourceKind
-			regs[i].Endpoint = reg.Endpoint
-			regs[i].Operations = reg.Operations
-			regs[i].CatalogItem = reg.CatalogItem
-			regs[i].RegisteredAt = reg.RegisteredAt
-		}
-
-		registryItems = append(registryItems, struct {
-			Metadata      *server.ProviderMetadata `json:"metadata,omitempty"`
-			RegisteredAt  *time.Time               `json:"registered_at,omitempty"`
-			Registrations *[]struct {
-				CatalogItem  *string    `json:"catalog_item,omitempty"`
-				Endpoint     *string    `json:"endpoint,omitempty"`
-				Operations   *[]string  `json:"operations,omitempty"`
-				RegisteredAt *time.Time `json:"registered_at,omitempty"`
-				ResourceKind *string    `json:"resource_kind,omitempty"`
-			} `json:"registrations,omitempty"`
-			ServiceId *string `json:"service_id,omitempty"`
-			Status    *string `json:"status,omitempty"`
-		}{
-			ServiceId:     &entry.ServiceID,
-			Metadata:      entry.Metadata,
-			Status:        entry.Status,
-			Registrations: &regs,
-			RegisteredAt:  entry.RegisteredAt,
-		})
-	}
-
-	total := len(registryItems)
-	return server.GetRegistry200JSONResponse{
-		Total:     &total,
-		Providers: &registryItems,
-	}, nil
+	return registryViewResponse(providers), nil
 }
 
 // GetCatalog (GET /admin/catalog)
@@ -354,68 +479,97 @@ func (s *ServiceHandler) GetCatalog(ctx context.Context, request server.GetCatal
 	logger := zap.S().Named("handler:getCatalog")
 
 	if s.store == nil {
-		return server.GetCatalog500JSONResponse{Error: "store not initialized"}, nil
+		return server.GetCatalog500JSONResponse(NewError(ctx, ErrCodeInternal, "store not initialized")), nil
 	}
 
-	catalogItems, err := s.store.Catalog().ListAllCatalogItems(ctx)
+	catalogItems, err := s.store.Catalog().ListCatalogProviders(ctx)
 	if err != nil {
 		logger.Errorw("Failed to get catalog items", "error", err)
-		return server.GetCatalog500JSONResponse{Error: "failed to retrieve catalog"}, nil
+		return server.GetCatalog500JSONResponse(NewError(ctx, ErrCodeInternal, "failed to retrieve catalog")), nil
 	}
 
-	registryAdapter := storeregistration.NewRegistrationRegistryAdapter(s.store)
-	catalogResponse := make([]struct {
-		AvailableProviders *[]string `json:"available_providers,omitempty"`
-		DisplayName        *string   `json:"display_name,omitempty"`
-		Name               *string   `json:"name,omitempty"`
-		ResourceKind       *string   `json:"resource_kind,omitempty"`
-	}, 0, len(catalogItems))
-
-	for _, item := range catalogItems {
-		providers, err := registryAdapter.ListProviders(ctx, item.ResourceKind)
-		if err != nil {
-			logger.Warnw("Failed to list providers", "catalog_item", item.Name, "error", err)
-			continue
-		}
-
-		serviceIDs := make([]string, 0, len(providers))
-		for _, provider := range providers {
-			serviceIDs = append(serviceIDs, provider.ServiceID)
-		}
+	return catalogViewResponse(catalogItems), nil
+}
 
-		catalogResponse = append(catalogResponse, struct {
-			AvailableProviders *[]string `json:"available_providers,omitempty"`
-			DisplayName        *string   `json:"display_name,omitempty"`
-			Name               *string   `json:"name,omitempty"`
-			ResourceKind       *string   `json:"resource_kind,omitempty"`
-		}{
-			Name:               &item.Name,
-			DisplayName:        &item.DisplayName,
-			ResourceKind:       &item.ResourceKind,
-			AvailableProviders: &serviceIDs,
-		})
-	}
-
-	total := len(catalogResponse)
-	return server.GetCatalog200JSONResponse{
-		Total:        &total,
-		CatalogItems: &catalogResponse,
+// GetLogLevel (GET /admin/loglevel)
+func (s *ServiceHandler) GetLogLevel(ctx context.Context, request server.GetLogLevelRequestObject) (server.GetLogLevelResponseObject, error) {
+	return server.GetLogLevel200JSONResponse{
+		Level: server.LogLevelLevel(s.logLevel.Level().String()),
 	}, nil
 }
 
-// Helper types for registry view
-type registryProvider struct {
-	ServiceID     string
-	Metadata      *server.ProviderMetadata
-	Status        *string
-	Registrations []registryProviderRegistration
-	RegisteredAt  *time.Time
+// SetLogLevel (PUT /admin/loglevel)
+func (s *ServiceHandler) SetLogLevel(ctx context.Context, request server.SetLogLevelRequestObject) (server.SetLogLevelResponseObject, error) {
+	logger := zap.S().Named("handler:setLogLevel")
+
+	if request.Body == nil {
+		return server.SetLogLevel400JSONResponse(NewError(ctx, ErrCodeInvalidArgument, "request body is required")), nil
+	}
+
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(request.Body.Level)); err != nil {
+		return server.SetLogLevel400JSONResponse(NewError(ctx, ErrCodeInvalidArgument, err.Error())), nil
+	}
+
+	previous := s.logLevel.Level()
+	s.logLevel.SetLevel(level)
+	logger.Infow("Log level changed", "previous", previous.String(), "level", level.String())
+
+	return server.SetLogLevel200JSONResponse{
+		Level: server.LogLevelLevel(level.String()),
+	}, nil
 }
 
-type registryProviderRegistration struct {
-	ResourceKind *string
-	Endpoint     *string
-	Operations   *[]string
-	CatalogItem  *string
-	RegisteredAt *time.Time
+// ApplyManifests (POST /admin/apply)
+func (s *ServiceHandler) ApplyManifests(ctx context.Context, request server.ApplyManifestsRequestObject) (server.ApplyManifestsResponseObject, error) {
+	logger := zap.S().Named("handler:applyManifests")
+
+	if s.applier == nil {
+		return server.ApplyManifests500JSONResponse(NewError(ctx, ErrCodeInternal, "applier not initialized")), nil
+	}
+	if request.Body == nil {
+		return server.ApplyManifests400JSONResponse(NewError(ctx, ErrCodeInvalidArgument, "request body is required")), nil
+	}
+
+	opts := apply.Options{
+		DryRun: request.Params.DryRun != nil && *request.Params.DryRun,
+		Prune:  request.Params.Prune != nil && *request.Params.Prune,
+	}
+	changes, err := s.applier.Apply(ctx, request.Body.Manifests, opts)
+	if err == nil {
+		return server.ApplyManifests200JSONResponse{DryRun: opts.DryRun, Changes: changes}, nil
+	}
+
+	if errors.Is(err, apply.ErrInvalidManifest) {
+		return server.ApplyManifests422JSONResponse(NewError(ctx, ErrCodeInvalidArgument, err.Error())), nil
+	}
+
+	status, apiErr := http.StatusInternalServerError, NewError(ctx, ErrCodeInternal, err.Error())
+	var regErr *registration.RegistrationError
+	switch {
+	case errors.As(err, &regErr):
+		status, apiErr = registrationError(ctx, err)
+		apiErr.Message = err.Error()
+	case errors.Is(err, store.ErrVersionConflict), errors.Is(err, registration.ErrConflict):
+		status, apiErr = http.StatusConflict, NewError(ctx, registration.ErrCodeConflict, err.Error())
+	case errors.Is(err, store.ErrAlreadyExists), errors.Is(err, service.ErrAlreadyExists):
+		status, apiErr = http.StatusConflict, NewError(ctx, ErrCodeAlreadyExists, err.Error())
+	case errors.Is(err, service.ErrPreconditionFailed):
+		status, apiErr = http.StatusConflict, NewError(ctx, registration.ErrCodeConflict, err.Error())
+	case errors.Is(err, service.ErrInvalidArgument):
+		status, apiErr = http.StatusUnprocessableEntity, NewError(ctx, ErrCodeInvalidArgument, err.Error())
+	case errors.Is(err, service.ErrEndpointUnreachable):
+		status, apiErr = http.StatusBadGateway, NewError(ctx, ErrCodeEndpointUnreachable, err.Error())
+	}
+
+	switch status {
+	case http.StatusConflict, http.StatusPreconditionFailed:
+		return server.ApplyManifests409JSONResponse(apiErr), nil
+	case http.StatusUnprocessableEntity:
+		return server.ApplyManifests422JSONResponse(apiErr), nil
+	case http.StatusBadGateway:
+		return server.ApplyManifests502JSONResponse(apiErr), nil
+	}
+	logger.Errorw("Apply failed", "error", err)
+	return server.ApplyManifests500JSONResponse(apiErr), nil
 }
@@ -0,0 +1,98 @@
+package v1alpha1
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/dcm-project/service-provider-api/internal/api/server"
+	"github.com/dcm-project/service-provider-api/internal/store/model"
+	"github.com/dcm-project/service-provider-api/pkg/registration"
+)
+
+// registryViewResponse is a server.GetRegistry200JSONResponse encoded one
+// provider at a time, the registry can hold thousands of registrations
+type registryViewResponse []registration.RegisteredProvider
+
+var _ server.GetRegistryResponseObject = registryViewResponse(nil)
+
+func (response registryViewResponse) VisitGetRegistryResponse(w http.ResponseWriter) error {
+	return writeJSONList(w, "providers", response, toRegistryEntry)
+}
+
+// toRegistryEntry converts a registration into a provider of
+// server.RegistryView, which registers a service for a single resource kind
+func toRegistryEntry(provider registration.RegisteredProvider) any {
+	entry := itemOf(server.RegistryView{}.Providers)
+	entry.ServiceId = &provider.ServiceID
+	entry.Metadata = &provider.Metadata
+	entry.Status = &provider.Status
+	entry.RegisteredAt = &provider.RegisteredAt
+	kind := itemOf(entry.Registrations)
+	kind.ResourceKind = &provider.ResourceKind
+	kind.Endpoint = &provider.Endpoint
+	kind.Operations = &provider.Operations
+	kind.CatalogItem = &provider.CatalogItem
+	kind.RegisteredAt = &provider.RegisteredAt
+	entry.Registrations = listOf(kind)
+	return entry
+}
+
+// catalogViewResponse is a server.GetCatalog200JSONResponse encoded one
+// catalog item at a time
+type catalogViewResponse []model.CatalogItemProviders
+
+var _ server.GetCatalogResponseObject = catalogViewResponse(nil)
+
+func (response catalogViewResponse) VisitGetCatalogResponse(w http.ResponseWriter) error {
+	return writeJSONList(w, "catalog_items", response, toCatalogEntry)
+}
+
+// toCatalogEntry converts a catalog item into an item of server.CatalogView
+func toCatalogEntry(item model.CatalogItemProviders) any {
+	serviceIDs := make([]string, 0, len(item.ProviderIDs))
+	for _, id := range item.ProviderIDs {
+		serviceIDs = append(serviceIDs, id.String())
+	}
+	entry := itemOf(server.CatalogView{}.CatalogItems)
+	entry.AvailableProviders = &serviceIDs
+	entry.DisplayName = &item.DisplayName
+	entry.Name = &item.Name
+	entry.ResourceKind = &item.ResourceKind
+	return entry
+}
+
+// itemOf returns the zero item of a list of the generated views, whose item
+// types are anonymous structs
+func itemOf[T any](*[]T) T {
+	var item T
+	return item
+}
+
+func listOf[T any](items ...T) *[]T {
+	return &items
+}
+
+// writeJSONList writes {"<field>":[items],"total":n}, encoding the items as
+// they are written instead of the whole view at once
+func writeJSONList[T any](w http.ResponseWriter, field string, items []T, entry func(T) any) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	buf := bufio.NewWriter(w)
+	_, _ = buf.WriteString(`{"` + field + `":[`)
+	for i, item := range items {
+		if i > 0 {
+			_ = buf.WriteByte(',')
+		}
+		data, err := json.Marshal(entry(item))
+		if err != nil {
+			return err
+		}
+		_, _ = buf.Write(data)
+	}
+	_, _ = buf.WriteString(`],"total":` + strconv.Itoa(len(items)) + "}\n")
+	// Write errors are sticky, and returned by Flush
+	return buf.Flush()
+}
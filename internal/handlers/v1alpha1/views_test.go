@@ -0,0 +1,136 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dcm-project/service-provider-api/internal/api/server"
+	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/dcm-project/service-provider-api/internal/store/storetest"
+)
+
+const (
+	benchmarkProviders     = 10000
+	benchmarkResourceKinds = 20
+)
+
+// newBenchmarkHandler returns a handler of a memory store seeded with 10k
+// registrations, the store queries are measured by the store benchmarks
+func newBenchmarkHandler(b *testing.B) *ServiceHandler {
+	b.Helper()
+
+	s := store.NewMemoryStore()
+	storetest.Seed(b, s, benchmarkProviders, benchmarkResourceKinds)
+
+	h := NewServiceHandler(nil)
+	h.SetStore(s)
+	return h
+}
+
+// TestViewsEncodeGeneratedTypes checks that the streamed views are the
+// encoding of the generated view types
+func TestViewsEncodeGeneratedTypes(t *testing.T) {
+	s := store.NewMemoryStore()
+	storetest.Seed(t, s, 4, 2)
+	h := NewServiceHandler(nil)
+	h.SetStore(s)
+	ctx := context.Background()
+
+	registry, err := h.GetRegistry(ctx, server.GetRegistryRequestObject{})
+	if err != nil {
+		t.Fatalf("getting the registry: %v", err)
+	}
+	catalog, err := h.GetCatalog(ctx, server.GetCatalogRequestObject{})
+	if err != nil {
+		t.Fatalf("getting the catalog: %v", err)
+	}
+	tests := map[string]struct {
+		visit func(w *httptest.ResponseRecorder) error
+		view  any
+	}{
+		"registry": {visit: func(w *httptest.ResponseRecorder) error { return registry.VisitGetRegistryResponse(w) }, view: &server.RegistryView{}},
+		"catalog":  {visit: func(w *httptest.ResponseRecorder) error { return catalog.VisitGetCatalogResponse(w) }, view: &server.CatalogView{}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if err := tt.visit(w); err != nil {
+				t.Fatalf("writing the view: %v", err)
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), tt.view); err != nil {
+				t.Fatalf("decoding the view: %v", err)
+			}
+			encoded, err := json.Marshal(tt.view)
+			if err != nil {
+				t.Fatalf("encoding the view: %v", err)
+			}
+			if got := strings.TrimSpace(w.Body.String()); got != string(encoded) {
+				t.Errorf("expected the encoding of the generated view\n%s\ngot\n%s", encoded, got)
+			}
+		})
+	}
+}
+
+func BenchmarkGetRegistry(b *testing.B) {
+	h := newBenchmarkHandler(b)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		response, err := h.GetRegistry(ctx, server.GetRegistryRequestObject{})
+		if err != nil {
+			b.Fatal(err)
+		}
+		w := httptest.NewRecorder()
+		if err := response.VisitGetRegistryResponse(w); err != nil {
+			b.Fatal(err)
+		}
+
+		if i == 0 {
+			var view server.RegistryView
+			if err := json.Unmarshal(w.Body.Bytes(), &view); err != nil {
+				b.Fatalf("decoding the registry view: %v", err)
+			}
+			if *view.Total != benchmarkProviders || len(*view.Providers) != benchmarkProviders {
+				b.Fatalf("expected %d providers, got %d", benchmarkProviders, *view.Total)
+			}
+		}
+	}
+}
+
+func BenchmarkGetCatalog(b *testing.B) {
+	h := newBenchmarkHandler(b)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		response, err := h.GetCatalog(ctx, server.GetCatalogRequestObject{})
+		if err != nil {
+			b.Fatal(err)
+		}
+		w := httptest.NewRecorder()
+		if err := response.VisitGetCatalogResponse(w); err != nil {
+			b.Fatal(err)
+		}
+
+		if i == 0 {
+			var view server.CatalogView
+			if err := json.Unmarshal(w.Body.Bytes(), &view); err != nil {
+				b.Fatalf("decoding the catalog view: %v", err)
+			}
+			providers := 0
+			for _, item := range *view.CatalogItems {
+				providers += len(*item.AvailableProviders)
+			}
+			if *view.Total != benchmarkResourceKinds || providers != benchmarkProviders {
+				b.Fatalf("expected %d catalog items with %d providers, got %d with %d",
+					benchmarkResourceKinds, benchmarkProviders, *view.Total, providers)
+			}
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrShuttingDown is reported by the shutdown check once the service started
+// shutting down
+var ErrShuttingDown = errors.New("service is shutting down")
+
+const shutdownCheck = "shutdown"
+
+// CheckFunc returns an error when the component it checks is not ready
+type CheckFunc func(ctx context.Context) error
+
+// Result is the outcome of a readiness check
+type Result struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+}
+
+// Checker runs the registered readiness checks
+type Checker struct {
+	mu           sync.RWMutex
+	checks       map[string]CheckFunc
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+// NewChecker returns a checker running each check with timeout
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		checks:  make(map[string]CheckFunc),
+		timeout: timeout,
+	}
+}
+
+// Register adds a readiness check for the named component
+func (c *Checker) Register(name string, check CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[name] = check
+}
+
+// ShutDown makes the service report itself as not ready, so that load
+// balancers stop routing requests before connections are drained
+func (c *Checker) ShutDown() {
+	c.shuttingDown.Store(true)
+}
+
+// Check runs all the checks concurrently and reports whether all of them
+// passed, with the result of each check sorted by name
+func (c *Checker) Check(ctx context.Context) (bool, []Result) {
+	c.mu.RLock()
+	checks := make(map[string]CheckFunc, len(c.checks))
+	for name, check := range c.checks {
+		checks[name] = check
+	}
+	c.mu.RUnlock()
+
+	results := make([]Result, 0, len(checks)+1)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check CheckFunc) {
+			defer wg.Done()
+			result := c.run(ctx, name, check)
+			mu.Lock()
+			results = append(results, result)
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	var shutdownErr error
+	if c.shuttingDown.Load() {
+		shutdownErr = ErrShuttingDown
+	}
+	results = append(results, Result{Name: shutdownCheck, Err: shutdownErr})
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+	ready := true
+	for _, result := range results {
+		if result.Err != nil {
+			ready = false
+		}
+	}
+	return ready, results
+}
+
+func (c *Checker) run(ctx context.Context, name string, check CheckFunc) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	return Result{Name: name, Err: err, Duration: time.Since(start)}
+}
+
+// Worker tracks whether a background worker is running
+type Worker struct {
+	running atomic.Bool
+}
+
+// Run runs fn and reports the worker as running until fn returns
+func (w *Worker) Run(fn func()) {
+	w.running.Store(true)
+	defer w.running.Store(false)
+	fn()
+}
+
+// Check is a CheckFunc failing when the worker is not running
+func (w *Worker) Check(ctx context.Context) error {
+	if !w.running.Load() {
+		return errors.New("worker is not running")
+	}
+	return nil
+}
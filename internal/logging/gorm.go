@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// GormLogger writes gorm logs through zap. Queries are logged at debug level,
+// slow queries as warnings and failed queries as errors.
+type GormLogger struct {
+	logger        *zap.SugaredLogger
+	level         logger.LogLevel
+	slowThreshold time.Duration
+}
+
+var _ logger.Interface = (*GormLogger)(nil)
+
+func NewGormLogger(l *zap.SugaredLogger, slowThreshold time.Duration) *GormLogger {
+	return &GormLogger{
+		logger:        l,
+		level:         logger.Info,
+		slowThreshold: slowThreshold,
+	}
+}
+
+func (g *GormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	l := *g
+	l.level = level
+	return &l
+}
+
+func (g *GormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if g.level >= logger.Info {
+		g.logger.Infof(msg, data...)
+	}
+}
+
+func (g *GormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if g.level >= logger.Warn {
+		g.logger.Warnf(msg, data...)
+	}
+}
+
+func (g *GormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if g.level >= logger.Error {
+		g.logger.Errorf(msg, data...)
+	}
+}
+
+func (g *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if g.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && g.level >= logger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		sql, rows := fc()
+		g.logger.Errorw("Query failed", "sql", sql, "rows", rows, "elapsed", elapsed, "error", err)
+	case g.slowThreshold != 0 && elapsed > g.slowThreshold && g.level >= logger.Warn:
+		sql, rows := fc()
+		g.logger.Warnw("Slow query", "sql", sql, "rows", rows, "elapsed", elapsed, "threshold", g.slowThreshold)
+	case g.level >= logger.Info && g.logger.Level().Enabled(zapcore.DebugLevel):
+		sql, rows := fc()
+		g.logger.Debugw("Query", "sql", sql, "rows", rows, "elapsed", elapsed)
+	}
+}
+
+// ParamsFilter keeps query parameters, which may hold secrets, out of the logs
+func (g *GormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
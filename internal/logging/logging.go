@@ -0,0 +1,45 @@
+package logging
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// level is shared by every logger built with New so that it can be changed
+// at runtime
+var level = zap.NewAtomicLevel()
+
+// New returns a logger writing entries in format, either json or console, at
+// or above the named level
+func New(format, levelName string) (*zap.Logger, error) {
+	parsed, err := zapcore.ParseLevel(levelName)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg zap.Config
+	switch format {
+	case FormatJSON:
+		cfg = zap.NewProductionConfig()
+	case FormatConsole:
+		cfg = zap.NewDevelopmentConfig()
+	default:
+		return nil, fmt.Errorf("unsupported log format %q", format)
+	}
+	level.SetLevel(parsed)
+	cfg.Level = level
+
+	return cfg.Build()
+}
+
+// Level returns the level of the loggers built with New
+func Level() zap.AtomicLevel {
+	return level
+}
@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/dcm-project/service-provider-api/pkg/registration"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "dcm"
+
+var (
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of HTTP requests by API operation and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation", "method", "status"})
+
+	registrationOutcomes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "registration",
+		Name:      "operations_total",
+		Help:      "Register and Unregister operations by outcome code.",
+	}, []string{"operation", "code"})
+
+	endpointCheckDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "endpoint_check",
+		Name:      "duration_seconds",
+		Help:      "Duration of provider endpoint reachability checks.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	endpointCheckFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "endpoint_check",
+		Name:      "failures_total",
+		Help:      "Provider endpoint reachability checks that failed.",
+	})
+
+	cacheHits = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "hits_total",
+		Help:      "Store reads served by the cache, by cache.",
+	}, []string{"cache"})
+
+	cacheMisses = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "misses_total",
+		Help:      "Store reads missing the cache, by cache.",
+	}, []string{"cache"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		httpRequestDuration,
+		registrationOutcomes,
+		endpointCheckDuration,
+		endpointCheckFailures,
+		cacheHits,
+		cacheMisses,
+	)
+}
+
+// Handler serves the registered metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveHTTPRequest records a served HTTP request. operation is the OpenAPI
+// operationId, or empty for requests outside of the API.
+func ObserveHTTPRequest(operation, method string, status int, duration time.Duration) {
+	if operation == "" {
+		operation = "none"
+	}
+	httpRequestDuration.WithLabelValues(operation, method, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+// ObserveEndpointCheck records a provider endpoint check
+func ObserveEndpointCheck(duration time.Duration, err error) {
+	endpointCheckDuration.Observe(duration.Seconds())
+	if err != nil {
+		endpointCheckFailures.Inc()
+	}
+}
+
+// RegistrationRecorder records registration outcomes, it is meant to be set
+// as the Recorder of a registration.Handler
+type RegistrationRecorder struct{}
+
+var _ registration.Recorder = RegistrationRecorder{}
+
+func (RegistrationRecorder) RecordOutcome(operation, code string) {
+	registrationOutcomes.WithLabelValues(operation, code).Inc()
+}
+
+// CacheRecorder records cache lookups, it is meant to be set as the Recorder
+// of a store.CachedStore
+type CacheRecorder struct{}
+
+var _ store.CacheRecorder = CacheRecorder{}
+
+func (CacheRecorder) RecordLookup(cache string, hit bool) {
+	if hit {
+		cacheHits.WithLabelValues(cache).Inc()
+	} else {
+		cacheMisses.WithLabelValues(cache).Inc()
+	}
+}
+
+// RegisterDBStats exposes the connection pool statistics of db
+func RegisterDBStats(db *sql.DB, dbName string) error {
+	return prometheus.Register(collectors.NewDBStatsCollector(db, dbName))
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/internal/store/model"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const collectTimeout = 5 * time.Second
+
+// ProviderLister lists the registered providers, it is implemented by
+// store.Provider
+type ProviderLister interface {
+	List(ctx context.Context) (model.ProviderList, error)
+}
+
+// registrationCollector reports the number of active registrations by
+// resource kind and region, read from the store on every scrape
+type registrationCollector struct {
+	providers ProviderLister
+	desc      *prometheus.Desc
+}
+
+// RegisterRegistrations exposes the number of active registrations listed
+// by providers
+func RegisterRegistrations(providers ProviderLister) error {
+	return prometheus.Register(&registrationCollector{
+		providers: providers,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "registration", "active"),
+			"Active provider registrations by resource kind and region.",
+			[]string{"resource_kind", "region"},
+			nil,
+		),
+	})
+}
+
+func (c *registrationCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *registrationCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	providers, err := c.providers.List(ctx)
+	if err != nil {
+		zap.S().Named("metrics").Warnw("Failed to list providers", "error", err)
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+
+	type key struct{ kind, region string }
+	counts := make(map[key]int)
+	for _, provider := range providers {
+		counts[key{provider.ProviderType, provider.Region}]++
+	}
+	for k, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(count), k.kind, k.region)
+	}
+}
@@ -6,7 +6,13 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/dcm-project/service-provider-api/internal/metrics"
+	"github.com/dcm-project/service-provider-api/internal/tracing"
 	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -19,6 +25,7 @@ type HTTPEndpointChecker struct {
 // NewHTTPEndpointChecker creates a new endpoint checker
 func NewHTTPEndpointChecker(timeout time.Duration) *HTTPEndpointChecker {
 	client := resty.New().
+		SetTransport(otelhttp.NewTransport(http.DefaultTransport)).
 		SetTimeout(timeout).
 		SetRetryCount(2).
 		SetRetryWaitTime(1 * time.Second)
@@ -31,21 +38,36 @@ func NewHTTPEndpointChecker(timeout time.Duration) *HTTPEndpointChecker {
 
 // CheckEndpoint verifies the provider endpoint is reachable and healthy
 func (c *HTTPEndpointChecker) CheckEndpoint(ctx context.Context, endpoint string) error {
+	ctx, span := tracing.Tracer().Start(ctx, "EndpointChecker.CheckEndpoint",
+		trace.WithAttributes(attribute.String("endpoint", endpoint)),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := c.checkEndpoint(ctx, endpoint)
+	metrics.ObserveEndpointCheck(time.Since(start), err)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (c *HTTPEndpointChecker) checkEndpoint(ctx context.Context, endpoint string) error {
 	logger := zap.S().Named("endpoint_checker")
-	
+
 	logger.Infow("Checking endpoint reachability", "endpoint", endpoint)
 
 	// Try to reach the health endpoint
 	// Following common health check patterns: /health, /healthz, /ready
 	healthPaths := []string{"/health", "/healthz", "/ready", "/"}
-	
+
 	for _, path := range healthPaths {
 		url := endpoint + path
-		
+
 		resp, err := c.client.R().
 			SetContext(ctx).
 			Get(url)
-		
+
 		if err == nil && (resp.StatusCode() == http.StatusOK || resp.StatusCode() == http.StatusNoContent) {
 			logger.Infow("Endpoint is reachable", "endpoint", endpoint, "path", path, "status", resp.StatusCode())
 			return nil
@@ -56,4 +78,3 @@ func (c *HTTPEndpointChecker) CheckEndpoint(ctx context.Context, endpoint string
 	logger.Warnw("Endpoint is not reachable", "endpoint", endpoint)
 	return fmt.Errorf("endpoint %s is not reachable on any standard health path", endpoint)
 }
-
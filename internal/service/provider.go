@@ -2,10 +2,14 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/dcm-project/service-provider-api/internal/api/server"
+	"github.com/dcm-project/service-provider-api/internal/builtin"
+	"github.com/dcm-project/service-provider-api/internal/fieldmask"
 	"github.com/dcm-project/service-provider-api/internal/store"
 	"github.com/dcm-project/service-provider-api/internal/store/model"
 	"github.com/go-resty/resty/v2"
@@ -13,42 +17,85 @@ import (
 	"go.uber.org/zap"
 )
 
+// ErrPreconditionFailed is returned when a conditional update does not match
+// the current version of a provider
+var ErrPreconditionFailed = errors.New("provider etag does not match")
+
+var (
+	// ErrInvalidArgument is returned when a request cannot be applied as given
+	ErrInvalidArgument = errors.New("invalid argument")
+
+	// ErrNotFound is returned when the provider does not exist
+	ErrNotFound = errors.New("provider not found")
+
+	// ErrAlreadyExists is returned when creating a provider that already exists
+	ErrAlreadyExists = errors.New("provider already exists")
+
+	// ErrEndpointUnreachable is returned when the provider API is not healthy
+	ErrEndpointUnreachable = errors.New("provider endpoint is unreachable")
+)
+
+var (
+	providerMutableFields   = []string{"name", "apiHost", "endpoint", "description", "operations"}
+	providerImmutableFields = []string{"id", "type"}
+)
+
 type ProviderService struct {
 	store       store.Store
 	restyClient resty.Client
+	// builtins are called in process for builtin:// API hosts, may be nil
+	builtins *builtin.Registry
+}
+
+func NewProviderService(store store.Store, client *resty.Client, builtins *builtin.Registry) *ProviderService {
+	return &ProviderService{store: store, restyClient: *client, builtins: builtins}
 }
 
-func NewProviderService(store store.Store, client *resty.Client) *ProviderService {
-	return &ProviderService{store: store, restyClient: *client}
+// WithStore returns a copy of the service using s, e.g. the store of a
+// transaction
+func (v *ProviderService) WithStore(s store.Store) *ProviderService {
+	service := *v
+	service.store = s
+	return &service
 }
 
-func (v *ProviderService) CreateProvider(ctx context.Context, request *server.CreateProviderJSONRequestBody) error {
+func (v *ProviderService) CreateProvider(ctx context.Context, request *server.CreateProviderJSONRequestBody) (server.Provider, error) {
 	logger := zap.S().Named("provider_service:createProvider")
 	logger.Info("Creating service provider")
 
+	providerUUID, err := uuid.Parse(request.Id)
+	if err != nil {
+		return server.Provider{}, fmt.Errorf("%w: id must be a valid UUID: %v", ErrInvalidArgument, err)
+	}
+
 	newProvider := model.Provider{
 		Name:         request.Name,
 		Endpoint:     request.Endpoint,
-		ID:           uuid.MustParse(request.Id),
+		ID:           providerUUID,
 		Description:  request.Description,
 		ProviderType: string(request.Type),
 		ApiHost:      request.ApiHost,
 		Operations:   request.Operations,
 	}
-	result, err := v.restyClient.R().Get(newProvider.ApiHost + "/health")
-	if err != nil || result.StatusCode() != http.StatusOK {
-		logger.Error("Failed to get health status or health endpoint return OK status")
-		return fmt.Errorf("health endpoint does not return OK status")
+	if err := validateProvider(newProvider); err != nil {
+		return server.Provider{}, err
+	}
+	if err := v.checkHealth(ctx, newProvider.ApiHost); err != nil {
+		logger.Errorw("Failed to get health status or health endpoint return OK status", "error", err)
+		return server.Provider{}, err
 	}
 
 	// TODO Get resource information about the provider
 
 	provider, err := v.store.Provider().Create(ctx, newProvider)
 	if err != nil {
-		return err
+		if errors.Is(err, store.ErrAlreadyExists) {
+			return server.Provider{}, fmt.Errorf("%w: %s", ErrAlreadyExists, request.Id)
+		}
+		return server.Provider{}, err
 	}
 	logger.Info("Successfully created provider: ", provider.ID)
-	return nil
+	return toAPIProvider(*provider), nil
 
 }
 
@@ -58,15 +105,9 @@ func (v *ProviderService) GetProvider(ctx context.Context, providerID string) (s
 
 	existingProvider, err := v.store.Provider().Get(ctx, uuid.MustParse(providerID))
 	if err != nil {
-		return server.Provider{}, fmt.Errorf("provider %s not found", providerID)
-	}
-	provider := server.Provider{
-		Name:        existingProvider.Name,
-		Endpoint:    existingProvider.Endpoint,
-		Id:          existingProvider.ID.String(),
-		Description: existingProvider.Description,
-		Type:        server.ProviderType(existingProvider.ProviderType),
+		return server.Provider{}, lookupError(providerID, err)
 	}
+	provider := toAPIProvider(*existingProvider)
 	logger.Info("Successfully retrieved provider details")
 	return provider, nil
 }
@@ -91,29 +132,35 @@ func (v *ProviderService) ListProvider(ctx context.Context, providerType string)
 
 	var providerList []server.Provider
 	for _, v := range providers {
-		providerList = append(providerList, server.Provider{
-			Description: v.Description,
-			Id:          v.ID.String(),
-			Name:        v.Name,
-			Type:        server.ProviderType(v.ProviderType),
-			Endpoint:    v.Endpoint,
-			ApiHost:     v.ApiHost,
-			Operations:  v.Operations,
-		})
+		providerList = append(providerList, toAPIProvider(v))
 	}
 	logger.Info("Successfully retrieved Service Providers")
 	return &providerList, nil
 }
 
-func (v *ProviderService) UpdateProvider(ctx context.Context, updateProvider server.ApplyProviderJSONRequestBody) (server.Provider, error) {
+// UpdateProvider replaces the provider identified by providerID. The id and
+// the type of the replacement must be the stored ones. When ifMatch is not
+// empty the update is only applied if it matches the provider's etag.
+func (v *ProviderService) UpdateProvider(ctx context.Context, providerID string, updateProvider server.ApplyProviderJSONRequestBody, ifMatch string) (server.Provider, error) {
 	logger := zap.S().Named("service_provider:UpdateProvider")
 	logger.Info("Retrieving Service Providers by ID")
 
-	providerUUID := uuid.MustParse(updateProvider.Id)
-	_, err := v.store.Provider().Get(ctx, providerUUID)
+	providerUUID := uuid.MustParse(providerID)
+	existing, err := v.store.Provider().Get(ctx, providerUUID)
 	if err != nil {
 		logger.Error("ProviderID does not exist in database", err)
-		return server.Provider{}, err
+		return server.Provider{}, lookupError(providerID, err)
+	}
+
+	if ifMatch != "" && !model.MatchETag(ifMatch, existing.ETag()) {
+		return server.Provider{}, ErrPreconditionFailed
+	}
+
+	// As in a patch, the immutable fields may be echoed back but not changed
+	providerType := string(updateProvider.Type)
+	immutable := providerPatchMask(server.ProviderPatch{Id: &updateProvider.Id, Type: &providerType}, *existing)
+	if err := immutable.Validate(providerMutableFields, providerImmutableFields); err != nil {
+		return server.Provider{}, fmt.Errorf("%w: %v", ErrInvalidArgument, err)
 	}
 
 	updatedModel := model.Provider{
@@ -121,16 +168,84 @@ func (v *ProviderService) UpdateProvider(ctx context.Context, updateProvider ser
 		Name:         updateProvider.Name,
 		Endpoint:     updateProvider.Endpoint,
 		Description:  updateProvider.Description,
-		ProviderType: string(updateProvider.Type),
+		ProviderType: existing.ProviderType,
 		ApiHost:      updateProvider.ApiHost,
 		Operations:   updateProvider.Operations,
+		Version:      existing.Version,
+		// Registration metadata is not part of the provider resource
+		Zone:                existing.Zone,
+		Region:              existing.Region,
+		Labels:              existing.Labels,
+		ResourceConstraints: existing.ResourceConstraints,
+		Annotations:         existing.Annotations,
+	}
+	if err := v.validateUpdate(ctx, *existing, updatedModel); err != nil {
+		return server.Provider{}, err
 	}
-	_, err = v.store.Provider().Update(ctx, updatedModel)
+	updated, err := v.store.Provider().Update(ctx, updatedModel)
 	if err != nil {
+		if errors.Is(err, store.ErrVersionConflict) {
+			return server.Provider{}, ErrPreconditionFailed
+		}
 		return server.Provider{}, err
 	}
 	logger.Info("Successfully updated service provider")
-	return updateProvider, nil
+	return toAPIProvider(*updated), nil
+}
+
+// PatchProvider updates the fields of the provider selected by updateMask.
+// Without an update mask all fields present in the patch are updated.
+func (v *ProviderService) PatchProvider(ctx context.Context, providerID string, patch server.ProviderPatch, updateMask string, ifMatch string) (server.Provider, error) {
+	logger := zap.S().Named("service_provider:PatchProvider")
+	logger.Info("Patching service provider")
+
+	existing, err := v.store.Provider().Get(ctx, uuid.MustParse(providerID))
+	if err != nil {
+		logger.Error("ProviderID does not exist in database", err)
+		return server.Provider{}, lookupError(providerID, err)
+	}
+
+	if ifMatch != "" && !model.MatchETag(ifMatch, existing.ETag()) {
+		return server.Provider{}, ErrPreconditionFailed
+	}
+
+	mask := fieldmask.Parse(updateMask)
+	if len(mask) == 0 {
+		mask = providerPatchMask(patch, *existing)
+	}
+	if err := mask.Validate(providerMutableFields, providerImmutableFields); err != nil {
+		return server.Provider{}, fmt.Errorf("%w: %v", ErrInvalidArgument, err)
+	}
+
+	patched := *existing
+	if mask.Contains("name") {
+		patched.Name = valueOrZero(patch.Name)
+	}
+	if mask.Contains("apiHost") {
+		patched.ApiHost = valueOrZero(patch.ApiHost)
+	}
+	if mask.Contains("endpoint") {
+		patched.Endpoint = valueOrZero(patch.Endpoint)
+	}
+	if mask.Contains("description") {
+		patched.Description = valueOrZero(patch.Description)
+	}
+	if mask.Contains("operations") {
+		patched.Operations = valueOrZero(patch.Operations)
+	}
+	if err := v.validateUpdate(ctx, *existing, patched); err != nil {
+		return server.Provider{}, err
+	}
+
+	updated, err := v.store.Provider().Update(ctx, patched)
+	if err != nil {
+		if errors.Is(err, store.ErrVersionConflict) {
+			return server.Provider{}, ErrPreconditionFailed
+		}
+		return server.Provider{}, err
+	}
+	logger.Info("Successfully patched service provider")
+	return toAPIProvider(*updated), nil
 }
 
 func (v *ProviderService) DeleteProvider(ctx context.Context, providerID string) error {
@@ -138,6 +253,9 @@ func (v *ProviderService) DeleteProvider(ctx context.Context, providerID string)
 	logger.Info("Deleting provider by ID")
 
 	providerUUID := uuid.MustParse(providerID)
+	if _, err := v.store.Provider().Get(ctx, providerUUID); err != nil {
+		return lookupError(providerID, err)
+	}
 	err := v.store.Provider().Delete(ctx, providerUUID)
 	if err != nil {
 		return err
@@ -145,3 +263,113 @@ func (v *ProviderService) DeleteProvider(ctx context.Context, providerID string)
 	logger.Info("Successfully deleted service provider")
 	return nil
 }
+
+// validateProvider checks the fields a provider requires, on creation and on
+// every update
+func validateProvider(p model.Provider) error {
+	var empty []string
+	if p.Name == "" {
+		empty = append(empty, "name")
+	}
+	if p.ApiHost == "" {
+		empty = append(empty, "apiHost")
+	}
+	if p.Endpoint == "" {
+		empty = append(empty, "endpoint")
+	}
+	if len(empty) > 0 {
+		return fmt.Errorf("%w: %s must not be empty", ErrInvalidArgument, strings.Join(empty, ", "))
+	}
+	return nil
+}
+
+// validateUpdate validates the updated provider like a new one, and checks
+// the health of its API host when it changed
+func (v *ProviderService) validateUpdate(ctx context.Context, existing, updated model.Provider) error {
+	if err := validateProvider(updated); err != nil {
+		return err
+	}
+	if updated.ApiHost != existing.ApiHost {
+		return v.checkHealth(ctx, updated.ApiHost)
+	}
+	return nil
+}
+
+// checkHealth checks the API host of a provider, in process for the
+// built-in providers and over HTTP for the other ones
+func (v *ProviderService) checkHealth(ctx context.Context, apiHost string) error {
+	if v.builtins != nil {
+		if provider, ok := v.builtins.Lookup(apiHost); ok {
+			if err := provider.Health(ctx); err != nil {
+				return fmt.Errorf("%w: %v", ErrEndpointUnreachable, err)
+			}
+			return nil
+		}
+	}
+
+	result, err := v.restyClient.R().SetContext(ctx).Get(apiHost + "/health")
+	if err != nil || result.StatusCode() != http.StatusOK {
+		return fmt.Errorf("%w: health endpoint does not return OK status", ErrEndpointUnreachable)
+	}
+	return nil
+}
+
+// lookupError converts a store error of a provider lookup into a service error
+func lookupError(providerID string, err error) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return fmt.Errorf("%w: %s", ErrNotFound, providerID)
+	}
+	return err
+}
+
+// providerPatchMask returns the implicit update mask of a patch, which holds
+// every field present in the patch. Immutable fields are only included when
+// they differ from the stored value, so that echoing them back is allowed.
+func providerPatchMask(patch server.ProviderPatch, existing model.Provider) fieldmask.Mask {
+	var mask fieldmask.Mask
+	if patch.Id != nil && *patch.Id != existing.ID.String() {
+		mask = append(mask, "id")
+	}
+	if patch.Type != nil && *patch.Type != existing.ProviderType {
+		mask = append(mask, "type")
+	}
+	if patch.Name != nil {
+		mask = append(mask, "name")
+	}
+	if patch.ApiHost != nil {
+		mask = append(mask, "apiHost")
+	}
+	if patch.Endpoint != nil {
+		mask = append(mask, "endpoint")
+	}
+	if patch.Description != nil {
+		mask = append(mask, "description")
+	}
+	if patch.Operations != nil {
+		mask = append(mask, "operations")
+	}
+	return mask
+}
+
+func valueOrZero[T any](value *T) T {
+	if value == nil {
+		var zero T
+		return zero
+	}
+	return *value
+}
+
+// toAPIProvider converts a stored provider into its API representation
+func toAPIProvider(p model.Provider) server.Provider {
+	etag := p.ETag()
+	return server.Provider{
+		Description: p.Description,
+		Id:          p.ID.String(),
+		Name:        p.Name,
+		Type:        server.ProviderType(p.ProviderType),
+		Endpoint:    p.Endpoint,
+		ApiHost:     p.ApiHost,
+		Operations:  p.Operations,
+		Etag:        &etag,
+	}
+}
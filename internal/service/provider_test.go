@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/dcm-project/service-provider-api/internal/api/server"
+	"github.com/dcm-project/service-provider-api/internal/fieldmask"
+	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/dcm-project/service-provider-api/internal/store/model"
+	"github.com/go-resty/resty/v2"
+	"github.com/google/uuid"
+)
+
+// providerID is the ID of the provider created by newProviderService
+var providerID = uuid.MustParse("6f1c4b9e-3d2a-4c8e-9b7f-2a5d8e1c0f34")
+
+// newProviderService returns a service on a memory store holding a virtual
+// machine provider. The API host of the provider is never checked, as long as
+// it is not changed.
+func newProviderService(t *testing.T) (*ProviderService, model.Provider) {
+	t.Helper()
+
+	s := store.NewMemoryStore()
+	provider, err := s.Provider().Create(context.Background(), model.Provider{
+		ID:           providerID,
+		Name:         "vm-provider",
+		ProviderType: string(server.VirtualMachine),
+		Description:  "VMs",
+		Endpoint:     "http://vms.example/vms",
+		ApiHost:      "http://vms.example",
+		Operations:   []string{"CREATE", "DELETE"},
+	})
+	if err != nil {
+		t.Fatalf("creating the provider: %v", err)
+	}
+	return NewProviderService(s, resty.New(), nil), *provider
+}
+
+func TestUpdateProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(p *server.Provider)
+		wantErr error
+	}{
+		{
+			name:   "mutable fields",
+			update: func(p *server.Provider) { p.Name, p.Description = "renamed", "Virtual machines" },
+		},
+		{
+			name:    "another id",
+			update:  func(p *server.Provider) { p.Id = uuid.NewString() },
+			wantErr: ErrInvalidArgument,
+		},
+		{
+			name:    "another type",
+			update:  func(p *server.Provider) { p.Type = server.Container },
+			wantErr: ErrInvalidArgument,
+		},
+		{
+			name:    "empty name",
+			update:  func(p *server.Provider) { p.Name = "" },
+			wantErr: ErrInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, existing := newProviderService(t)
+			body := toAPIProvider(existing)
+			tt.update(&body)
+
+			updated, err := v.UpdateProvider(context.Background(), existing.ID.String(), body, "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if updated.Id != existing.ID.String() || updated.Name != body.Name || updated.Description != body.Description {
+				t.Errorf("expected the provider to be replaced by %+v, got %+v", body, updated)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestProviderPatchMask(t *testing.T) {
+	existing := model.Provider{ID: uuid.New(), ProviderType: string(server.VirtualMachine)}
+
+	tests := []struct {
+		name  string
+		patch server.ProviderPatch
+		want  fieldmask.Mask
+	}{
+		{name: "empty patch", patch: server.ProviderPatch{}, want: nil},
+		{
+			name:  "present fields",
+			patch: server.ProviderPatch{Name: ptr("renamed"), Operations: ptr([]string{})},
+			want:  fieldmask.Mask{"name", "operations"},
+		},
+		{
+			name:  "empty values",
+			patch: server.ProviderPatch{Description: ptr(""), Endpoint: ptr("")},
+			want:  fieldmask.Mask{"endpoint", "description"},
+		},
+		{
+			name:  "echoed immutable fields",
+			patch: server.ProviderPatch{Id: ptr(existing.ID.String()), Type: ptr(existing.ProviderType), ApiHost: ptr("http://vms.example")},
+			want:  fieldmask.Mask{"apiHost"},
+		},
+		{
+			name:  "changed immutable fields",
+			patch: server.ProviderPatch{Id: ptr(uuid.NewString()), Type: ptr(string(server.Container))},
+			want:  fieldmask.Mask{"id", "type"},
+		},
+		{
+			name:  "etag",
+			patch: server.ProviderPatch{Etag: ptr(`"1"`)},
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := providerPatchMask(tt.patch, existing); !slices.Equal(got, tt.want) {
+				t.Errorf("expected the mask %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPatchProvider(t *testing.T) {
+	tests := []struct {
+		name       string
+		patch      server.ProviderPatch
+		updateMask string
+		// want updates the existing provider into the expected one
+		want    func(p *server.Provider)
+		wantErr error
+	}{
+		{
+			name:  "implicit mask",
+			patch: server.ProviderPatch{Name: ptr("renamed")},
+			want:  func(p *server.Provider) { p.Name = "renamed" },
+		},
+		{
+			name:  "implicit mask clearing a field",
+			patch: server.ProviderPatch{Description: ptr(""), Operations: ptr([]string{})},
+			want:  func(p *server.Provider) { p.Description, p.Operations = "", []string{} },
+		},
+		{
+			name:  "implicit mask with echoed immutable fields",
+			patch: server.ProviderPatch{Id: ptr(providerID.String()), Type: ptr(string(server.VirtualMachine)), Name: ptr("renamed")},
+			want:  func(p *server.Provider) { p.Name = "renamed" },
+		},
+		{
+			name:    "implicit mask with a changed type",
+			patch:   server.ProviderPatch{Type: ptr(string(server.Container))},
+			wantErr: ErrInvalidArgument,
+		},
+		{
+			name:    "implicit mask with a changed id",
+			patch:   server.ProviderPatch{Id: ptr(uuid.NewString())},
+			wantErr: ErrInvalidArgument,
+		},
+		{
+			name:    "implicit mask clearing a required field",
+			patch:   server.ProviderPatch{Endpoint: ptr("")},
+			wantErr: ErrInvalidArgument,
+		},
+		{
+			name:  "empty patch",
+			patch: server.ProviderPatch{},
+			want:  func(p *server.Provider) {},
+		},
+		{
+			name:       "explicit mask",
+			patch:      server.ProviderPatch{Name: ptr("renamed"), Description: ptr("Virtual machines")},
+			updateMask: "description",
+			want:       func(p *server.Provider) { p.Description = "Virtual machines" },
+		},
+		{
+			name:       "explicit mask of a missing field",
+			patch:      server.ProviderPatch{Name: ptr("renamed")},
+			updateMask: "name, description",
+			want:       func(p *server.Provider) { p.Name, p.Description = "renamed", "" },
+		},
+		{
+			name:       "wildcard",
+			patch:      server.ProviderPatch{Name: ptr("renamed")},
+			updateMask: "*",
+			// The endpoint and the API host are cleared
+			wantErr: ErrInvalidArgument,
+		},
+		{
+			name:       "immutable field",
+			patch:      server.ProviderPatch{Type: ptr(string(server.VirtualMachine))},
+			updateMask: "type",
+			wantErr:    ErrInvalidArgument,
+		},
+		{
+			name:       "unknown field",
+			patch:      server.ProviderPatch{Name: ptr("renamed")},
+			updateMask: "name,labels",
+			wantErr:    ErrInvalidArgument,
+		},
+		{
+			name:       "nested field",
+			patch:      server.ProviderPatch{Name: ptr("renamed")},
+			updateMask: "name.first",
+			wantErr:    ErrInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			v, existing := newProviderService(t)
+
+			patched, err := v.PatchProvider(ctx, existing.ID.String(), tt.patch, tt.updateMask, "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			stored, getErr := v.GetProvider(ctx, existing.ID.String())
+			if getErr != nil {
+				t.Fatalf("getting the provider: %v", getErr)
+			}
+			if tt.wantErr != nil {
+				if want := toAPIProvider(existing); !reflect.DeepEqual(stored, want) {
+					t.Errorf("expected the provider to be unchanged, got %+v", stored)
+				}
+				return
+			}
+
+			want := toAPIProvider(existing)
+			tt.want(&want)
+			want.Etag = ptr(model.FormatETag(existing.Version + 1))
+			if !reflect.DeepEqual(patched, want) {
+				t.Errorf("expected the patched provider %+v, got %+v", want, patched)
+			}
+			if !reflect.DeepEqual(stored, want) {
+				t.Errorf("expected the stored provider %+v, got %+v", want, stored)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package service
 import (
 	"time"
 
+	"github.com/dcm-project/service-provider-api/internal/builtin"
+	"github.com/dcm-project/service-provider-api/internal/metrics"
 	"github.com/dcm-project/service-provider-api/internal/store"
 	"github.com/dcm-project/service-provider-api/internal/store/registration"
 	pkgregistration "github.com/dcm-project/service-provider-api/pkg/registration"
@@ -12,12 +14,16 @@ import (
 type RegistrationServiceConfig struct {
 	// Store for database operations
 	Store store.Store
-	
+
 	// EndpointCheckEnabled whether to check endpoint reachability during registration
 	EndpointCheckEnabled bool
-	
+
 	// EndpointCheckTimeout timeout for endpoint health checks
 	EndpointCheckTimeout time.Duration
+
+	// Builtins are the built-in providers, their endpoints are checked with
+	// their Health even when EndpointCheckEnabled is false
+	Builtins *builtin.Registry
 }
 
 // InitializeRegistrationService creates and configures the registration handler
@@ -25,10 +31,10 @@ func InitializeRegistrationService(cfg RegistrationServiceConfig) (*pkgregistrat
 	// Create store adapters
 	registryStore := registration.NewRegistrationRegistryAdapter(cfg.Store)
 	catalogStore := registration.NewRegistrationCatalogAdapter(cfg.Store)
-	
+
 	// Create validator
 	validator := pkgregistration.NewValidator()
-	
+
 	// Create endpoint checker if enabled
 	var endpointChecker pkgregistration.EndpointChecker
 	if cfg.EndpointCheckEnabled {
@@ -38,18 +44,22 @@ func InitializeRegistrationService(cfg RegistrationServiceConfig) (*pkgregistrat
 		}
 		endpointChecker = NewHTTPEndpointChecker(timeout)
 	}
-	
+	if cfg.Builtins != nil {
+		endpointChecker = cfg.Builtins.EndpointChecker(endpointChecker)
+	}
+
 	// Create registration handler
 	registrationHandler, err := pkgregistration.NewHandler(pkgregistration.Config{
 		RegistryStore:   registryStore,
 		CatalogStore:    catalogStore,
 		Validator:       validator,
 		EndpointChecker: endpointChecker,
+		Recorder:        metrics.RegistrationRecorder{},
 	})
 	if err != nil {
 		return nil, err
 	}
-	
+
 	return registrationHandler, nil
 }
 
@@ -61,4 +71,3 @@ func DefaultRegistrationServiceConfig(store store.Store) RegistrationServiceConf
 		EndpointCheckTimeout: 10 * time.Second,
 	}
 }
-
@@ -0,0 +1,393 @@
+// Package serviceprovider reconciles the ServiceProvider custom resources
+// into the registry.
+package serviceprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/api/crd/v1alpha1"
+	"github.com/dcm-project/service-provider-api/internal/api/server"
+	"github.com/dcm-project/service-provider-api/pkg/registration"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Finalizer keeps the deleted ServiceProviders until they are
+	// unregistered
+	Finalizer = "dcm.io/unregister"
+
+	ManagedByLabel = "dcm.io/managed-by"
+	ManagedByValue = "dcm-serviceprovider"
+	// SourceLabel is the namespace and name of the ServiceProvider, joined
+	// with a dot
+	SourceLabel = "dcm.io/serviceprovider"
+)
+
+// Reasons of the conditions
+const (
+	ReasonRegistered          = "Registered"
+	ReasonInvalidSpec         = "InvalidSpec"
+	ReasonRegistrationFailed  = "RegistrationFailed"
+	ReasonEndpointReachable   = "EndpointReachable"
+	ReasonEndpointUnreachable = "EndpointUnreachable"
+	ReasonNotChecked          = "NotChecked"
+)
+
+// Options of the ServiceProvider controller
+type Options struct {
+	// Namespace restricts the controller to one namespace, all namespaces
+	// are watched when empty
+	Namespace string
+	// ResyncPeriod is the period of the reconciliation of all the
+	// ServiceProviders
+	ResyncPeriod time.Duration
+	// Workers is the number of ServiceProviders reconciled concurrently
+	Workers int
+}
+
+// Controller registers the resource kinds of the ServiceProviders, reports
+// the outcome in their status and unregisters them on deletion
+type Controller struct {
+	client       dynamic.Interface
+	registration *registration.Handler
+	opts         Options
+
+	factory dynamicinformer.DynamicSharedInformerFactory
+	lister  cache.GenericLister
+	queue   workqueue.TypedRateLimitingInterface[string]
+}
+
+func NewController(client dynamic.Interface, registrationHandler *registration.Handler, opts Options) *Controller {
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, opts.ResyncPeriod, opts.Namespace, nil)
+	informer := factory.ForResource(v1alpha1.ServiceProviderResource)
+
+	c := &Controller{
+		client:       client,
+		registration: registrationHandler,
+		opts:         opts,
+		factory:      factory,
+		lister:       informer.Lister(),
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: "serviceprovider"},
+		),
+	}
+
+	enqueue := func(obj interface{}) {
+		key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+		if err == nil {
+			c.queue.Add(key)
+		}
+	}
+	_, _ = informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: enqueue,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			// Skip the updates of the status written by the controller
+			oldSP, newSP := oldObj.(*unstructured.Unstructured), newObj.(*unstructured.Unstructured)
+			if oldSP.GetResourceVersion() != newSP.GetResourceVersion() &&
+				oldSP.GetGeneration() == newSP.GetGeneration() &&
+				oldSP.GetDeletionTimestamp().Equal(newSP.GetDeletionTimestamp()) {
+				return
+			}
+			enqueue(newObj)
+		},
+	})
+
+	return c
+}
+
+// Run reconciles the ServiceProviders until ctx is done
+func (c *Controller) Run(ctx context.Context) error {
+	logger := zap.S().Named("serviceprovider")
+	defer c.queue.ShutDown()
+
+	c.factory.Start(ctx.Done())
+	defer c.factory.Shutdown()
+
+	logger.Infow("Waiting for the informer cache to sync", "namespace", c.opts.Namespace)
+	for resource, synced := range c.factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return fmt.Errorf("failed to sync the informer cache of %s", resource)
+		}
+	}
+
+	logger.Infow("Reconciling service providers", "workers", c.opts.Workers)
+	var wg sync.WaitGroup
+	for i := 0; i < c.opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c.processNextItem(ctx) {
+			}
+		}()
+	}
+
+	<-ctx.Done()
+	c.queue.ShutDown()
+	wg.Wait()
+	return nil
+}
+
+func (c *Controller) processNextItem(ctx context.Context) bool {
+	key, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.reconcile(ctx, key); err != nil {
+		zap.S().Named("serviceprovider").Warnw("Failed to reconcile service provider, retrying", "serviceprovider", key, "error", err)
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+// reconcile registers or unregisters the ServiceProvider with key
+func (c *Controller) reconcile(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil
+	}
+	obj, err := c.lister.ByNamespace(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		// Unregistered before the finalizer was removed
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	sp := &v1alpha1.ServiceProvider{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.(*unstructured.Unstructured).UnstructuredContent(), sp); err != nil {
+		return err
+	}
+
+	if sp.DeletionTimestamp != nil {
+		return c.finalize(ctx, sp)
+	}
+	if !slices.Contains(sp.Finalizers, Finalizer) {
+		sp.Finalizers = append(sp.Finalizers, Finalizer)
+		if sp, err = c.update(ctx, sp); err != nil {
+			return err
+		}
+	}
+
+	status := &v1alpha1.ServiceProviderStatus{
+		ObservedGeneration: sp.Generation,
+		Conditions:         slices.Clone(sp.Status.Conditions),
+	}
+
+	// Unregister the resource kinds removed from the spec
+	desired := registrationsFor(sp)
+	var kept []v1alpha1.ServiceProviderRegistration
+	for _, reg := range sp.Status.Registrations {
+		if slices.Contains(desired, reg) {
+			kept = append(kept, reg)
+			continue
+		}
+		if err := c.registration.Unregister(ctx, reg.ServiceID, reg.ResourceKind); err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+	status.Registrations = kept
+
+	// Register the resource kinds, the first failure is reported
+	var regErr error
+	for _, reg := range desired {
+		if regErr = c.register(ctx, sp, reg); regErr != nil {
+			break
+		}
+		if !slices.Contains(status.Registrations, reg) {
+			status.Registrations = append(status.Registrations, reg)
+		}
+	}
+	setConditions(status, sp.Generation, regErr)
+
+	if !reflect.DeepEqual(*status, sp.Status) {
+		sp.Status = *status
+		if err := c.updateStatus(ctx, sp); err != nil {
+			return err
+		}
+	}
+
+	var invalid *registration.RegistrationError
+	if regErr != nil && !(errors.As(regErr, &invalid) && invalid.Code == registration.ErrCodeValidation) {
+		// Retry the failures that are not caused by the spec
+		return regErr
+	}
+	if regErr == nil {
+		zap.S().Named("serviceprovider").Debugw("Reconciled service provider", "serviceprovider", key, "registrations", len(desired))
+	}
+	return nil
+}
+
+func (c *Controller) register(ctx context.Context, sp *v1alpha1.ServiceProvider, reg v1alpha1.ServiceProviderRegistration) error {
+	labels := make(map[string]string, len(sp.Spec.Metadata.Labels)+2)
+	for k, v := range sp.Spec.Metadata.Labels {
+		labels[k] = v
+	}
+	labels[ManagedByLabel] = ManagedByValue
+	labels[SourceLabel] = sp.Namespace + "." + sp.Name
+
+	metadata := server.ProviderMetadata{
+		Zone:   sp.Spec.Metadata.Zone,
+		Region: sp.Spec.Metadata.Region,
+		Labels: &labels,
+	}
+	if len(sp.Spec.Metadata.ResourceConstraints) > 0 {
+		metadata.ResourceConstraints = &sp.Spec.Metadata.ResourceConstraints
+	}
+
+	_, err := c.registration.Register(ctx, reg.ServiceID, reg.ResourceKind, sp.Spec.Endpoint, metadata, sp.Spec.Operations)
+	return err
+}
+
+// finalize unregisters a deleted ServiceProvider and removes its finalizer
+func (c *Controller) finalize(ctx context.Context, sp *v1alpha1.ServiceProvider) error {
+	if !slices.Contains(sp.Finalizers, Finalizer) {
+		return nil
+	}
+	// The spec ones too, in case the status was not updated after registering
+	registrations := registrationsFor(sp)
+	for _, reg := range sp.Status.Registrations {
+		if !slices.Contains(registrations, reg) {
+			registrations = append(registrations, reg)
+		}
+	}
+	for _, reg := range registrations {
+		if err := c.registration.Unregister(ctx, reg.ServiceID, reg.ResourceKind); err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+	sp.Finalizers = slices.DeleteFunc(sp.Finalizers, func(f string) bool { return f == Finalizer })
+	if _, err := c.update(ctx, sp); err != nil {
+		return err
+	}
+	zap.S().Named("serviceprovider").Infow("Unregistered deleted service provider", "serviceprovider", sp.Namespace+"/"+sp.Name)
+	return nil
+}
+
+func (c *Controller) update(ctx context.Context, sp *v1alpha1.ServiceProvider) (*v1alpha1.ServiceProvider, error) {
+	obj, err := toUnstructured(sp)
+	if err != nil {
+		return nil, err
+	}
+	obj, err = c.client.Resource(v1alpha1.ServiceProviderResource).Namespace(sp.Namespace).Update(ctx, obj, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	updated := &v1alpha1.ServiceProvider{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (c *Controller) updateStatus(ctx context.Context, sp *v1alpha1.ServiceProvider) error {
+	obj, err := toUnstructured(sp)
+	if err != nil {
+		return err
+	}
+	_, err = c.client.Resource(v1alpha1.ServiceProviderResource).Namespace(sp.Namespace).UpdateStatus(ctx, obj, metav1.UpdateOptions{})
+	return err
+}
+
+func toUnstructured(sp *v1alpha1.ServiceProvider) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(sp)
+	if err != nil {
+		return nil, err
+	}
+	obj := &unstructured.Unstructured{Object: content}
+	obj.SetGroupVersionKind(v1alpha1.GroupVersion.WithKind(v1alpha1.ServiceProviderKind))
+	return obj, nil
+}
+
+// registrationsFor returns the registrations of the resource kinds of sp. The
+// registry holds a single resource kind per service ID, so when there are
+// several kinds each one is registered with a name based UUID derived from
+// the service ID.
+func registrationsFor(sp *v1alpha1.ServiceProvider) []v1alpha1.ServiceProviderRegistration {
+	var registrations []v1alpha1.ServiceProviderRegistration
+	for _, kind := range sp.Spec.ResourceKinds {
+		serviceID := sp.Spec.ServiceID
+		if len(sp.Spec.ResourceKinds) > 1 {
+			if namespace, err := uuid.Parse(serviceID); err == nil {
+				serviceID = uuid.NewSHA1(namespace, []byte(kind)).String()
+			}
+		}
+		registrations = append(registrations, v1alpha1.ServiceProviderRegistration{ResourceKind: kind, ServiceID: serviceID})
+	}
+	return registrations
+}
+
+// setConditions sets the conditions for the outcome of the registrations
+func setConditions(status *v1alpha1.ServiceProviderStatus, generation int64, err error) {
+	registered := metav1.Condition{
+		Type:               v1alpha1.ConditionRegistered,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonRegistered,
+		Message:            "All resource kinds are registered",
+		ObservedGeneration: generation,
+	}
+	reachable := metav1.Condition{
+		Type:               v1alpha1.ConditionEndpointReachable,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonEndpointReachable,
+		Message:            "The endpoint is reachable",
+		ObservedGeneration: generation,
+	}
+
+	if err != nil {
+		registered.Status = metav1.ConditionFalse
+		registered.Reason = ReasonRegistrationFailed
+		registered.Message = err.Error()
+		reachable.Status = metav1.ConditionUnknown
+		reachable.Reason = ReasonNotChecked
+		reachable.Message = "The registration failed before the endpoint was checked"
+
+		var regErr *registration.RegistrationError
+		if errors.As(err, &regErr) {
+			switch regErr.Code {
+			case registration.ErrCodeValidation:
+				registered.Reason = ReasonInvalidSpec
+			case registration.ErrCodeEndpointUnreachable:
+				registered.Reason = ReasonEndpointUnreachable
+				reachable.Status = metav1.ConditionFalse
+				reachable.Reason = ReasonEndpointUnreachable
+				reachable.Message = regErr.Message
+				if regErr.Err != nil {
+					reachable.Message += ": " + regErr.Err.Error()
+				}
+			}
+		}
+	}
+
+	meta.SetStatusCondition(&status.Conditions, registered)
+	meta.SetStatusCondition(&status.Conditions, reachable)
+}
+
+func isNotFound(err error) bool {
+	var regErr *registration.RegistrationError
+	return errors.As(err, &regErr) && regErr.Code == registration.ErrCodeNotFound
+}
@@ -0,0 +1,275 @@
+package serviceprovider
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/api/crd/v1alpha1"
+	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/dcm-project/service-provider-api/internal/store/model"
+	storeregistration "github.com/dcm-project/service-provider-api/internal/store/registration"
+	"github.com/dcm-project/service-provider-api/pkg/registration"
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+const namespace = "providers"
+
+// endpointChecker fails the endpoints with an unreachable host
+type endpointChecker struct{}
+
+func (endpointChecker) CheckEndpoint(ctx context.Context, endpoint string) error {
+	if strings.Contains(endpoint, "unreachable") {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func newClient(t *testing.T, objects ...*v1alpha1.ServiceProvider) dynamic.Interface {
+	t.Helper()
+
+	var objs []runtime.Object
+	for _, sp := range objects {
+		obj, err := toUnstructured(sp)
+		if err != nil {
+			t.Fatalf("converting the service provider: %v", err)
+		}
+		objs = append(objs, obj)
+	}
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{v1alpha1.ServiceProviderResource: v1alpha1.ServiceProviderListKind},
+		objs...)
+}
+
+// startController runs a controller on client, registering into a memory
+// store, until the end of the test
+func startController(t *testing.T, client dynamic.Interface) store.Store {
+	t.Helper()
+
+	s := store.NewMemoryStore()
+	handler, err := registration.NewHandler(registration.Config{
+		RegistryStore:   storeregistration.NewRegistrationRegistryAdapter(s),
+		CatalogStore:    storeregistration.NewRegistrationCatalogAdapter(s),
+		EndpointChecker: endpointChecker{},
+	})
+	if err != nil {
+		t.Fatalf("creating the registration handler: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- NewController(client, handler, Options{Namespace: namespace}).Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("running the controller: %v", err)
+		}
+	})
+	return s
+}
+
+func newServiceProvider(name string, resourceKinds ...string) *v1alpha1.ServiceProvider {
+	return &v1alpha1.ServiceProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Generation: 1},
+		Spec: v1alpha1.ServiceProviderSpec{
+			ServiceID:     uuid.NewString(),
+			ResourceKinds: resourceKinds,
+			Endpoint:      "http://" + name + ".providers.svc:8080",
+			Metadata: v1alpha1.ServiceProviderMetadata{
+				Zone:   "zone-a",
+				Region: "region-1",
+				Labels: map[string]string{"team": "compute"},
+			},
+			Operations: []string{"CREATE", "DELETE"},
+		},
+	}
+}
+
+func getServiceProvider(t *testing.T, client dynamic.Interface, name string) *v1alpha1.ServiceProvider {
+	t.Helper()
+
+	obj, err := client.Resource(v1alpha1.ServiceProviderResource).Namespace(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting the service provider %s: %v", name, err)
+	}
+	sp := &v1alpha1.ServiceProvider{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), sp); err != nil {
+		t.Fatalf("converting the service provider %s: %v", name, err)
+	}
+	return sp
+}
+
+func updateServiceProvider(t *testing.T, client dynamic.Interface, sp *v1alpha1.ServiceProvider) {
+	t.Helper()
+
+	obj, err := toUnstructured(sp)
+	if err != nil {
+		t.Fatalf("converting the service provider: %v", err)
+	}
+	if _, err := client.Resource(v1alpha1.ServiceProviderResource).Namespace(namespace).Update(context.Background(), obj, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("updating the service provider %s: %v", sp.Name, err)
+	}
+}
+
+func getProvider(t *testing.T, s store.Store, serviceID string) *model.Provider {
+	t.Helper()
+
+	provider, err := s.Provider().Get(context.Background(), uuid.MustParse(serviceID))
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("getting the provider %s: %v", serviceID, err)
+	}
+	return provider
+}
+
+func eventually(t *testing.T, message string, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting: %s", message)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestControllerRegistersServiceProvider(t *testing.T) {
+	sp := newServiceProvider("vm-provider", "vm")
+	client := newClient(t, sp)
+	s := startController(t, client)
+
+	eventually(t, "the provider is registered", func() bool { return getProvider(t, s, sp.Spec.ServiceID) != nil })
+	provider := getProvider(t, s, sp.Spec.ServiceID)
+	if provider.ProviderType != "vm" || provider.Endpoint != sp.Spec.Endpoint || provider.Zone != "zone-a" {
+		t.Errorf("expected the registration of the spec, got %+v", provider)
+	}
+	if !slices.Equal(provider.Operations, sp.Spec.Operations) {
+		t.Errorf("expected the operations %v, got %v", sp.Spec.Operations, provider.Operations)
+	}
+	if provider.Labels["team"] != "compute" || provider.Labels[ManagedByLabel] != ManagedByValue ||
+		provider.Labels[SourceLabel] != "providers.vm-provider" {
+		t.Errorf("expected the spec and controller labels, got %v", provider.Labels)
+	}
+
+	eventually(t, "the status is written", func() bool {
+		return meta.IsStatusConditionTrue(getServiceProvider(t, client, sp.Name).Status.Conditions, v1alpha1.ConditionEndpointReachable)
+	})
+	got := getServiceProvider(t, client, sp.Name)
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, v1alpha1.ConditionRegistered) {
+		t.Errorf("expected the Registered condition to be true, got %+v", got.Status.Conditions)
+	}
+	if got.Status.ObservedGeneration != 1 {
+		t.Errorf("expected the observed generation 1, got %d", got.Status.ObservedGeneration)
+	}
+	want := []v1alpha1.ServiceProviderRegistration{{ResourceKind: "vm", ServiceID: sp.Spec.ServiceID}}
+	if !slices.Equal(got.Status.Registrations, want) {
+		t.Errorf("expected the registrations %v, got %v", want, got.Status.Registrations)
+	}
+	if !slices.Contains(got.Finalizers, Finalizer) {
+		t.Errorf("expected the finalizer %s, got %v", Finalizer, got.Finalizers)
+	}
+}
+
+func TestControllerRegistersEachResourceKind(t *testing.T) {
+	sp := newServiceProvider("storage-provider", "volume", "snapshot")
+	client := newClient(t, sp)
+	s := startController(t, client)
+
+	eventually(t, "the resource kinds are registered", func() bool {
+		return len(getServiceProvider(t, client, sp.Name).Status.Registrations) == 2
+	})
+	for _, reg := range getServiceProvider(t, client, sp.Name).Status.Registrations {
+		if reg.ServiceID == sp.Spec.ServiceID {
+			t.Errorf("expected a service ID derived from %s for %s", sp.Spec.ServiceID, reg.ResourceKind)
+		}
+		if provider := getProvider(t, s, reg.ServiceID); provider == nil || provider.ProviderType != reg.ResourceKind {
+			t.Errorf("expected a %s provider for %s, got %+v", reg.ResourceKind, reg.ServiceID, provider)
+		}
+	}
+}
+
+func TestControllerReportsUnreachableEndpoint(t *testing.T) {
+	sp := newServiceProvider("vm-provider", "vm")
+	sp.Spec.Endpoint = "http://unreachable.providers.svc:8080"
+	client := newClient(t, sp)
+	s := startController(t, client)
+
+	eventually(t, "the status is written", func() bool {
+		return meta.FindStatusCondition(getServiceProvider(t, client, sp.Name).Status.Conditions, v1alpha1.ConditionRegistered) != nil
+	})
+	conditions := getServiceProvider(t, client, sp.Name).Status.Conditions
+	registered := meta.FindStatusCondition(conditions, v1alpha1.ConditionRegistered)
+	if registered.Status != metav1.ConditionFalse || registered.Reason != ReasonEndpointUnreachable {
+		t.Errorf("expected Registered to be false for an unreachable endpoint, got %+v", registered)
+	}
+	reachable := meta.FindStatusCondition(conditions, v1alpha1.ConditionEndpointReachable)
+	if reachable == nil || reachable.Status != metav1.ConditionFalse || !strings.Contains(reachable.Message, "connection refused") {
+		t.Errorf("expected EndpointReachable to be false with the check error, got %+v", reachable)
+	}
+	if provider := getProvider(t, s, sp.Spec.ServiceID); provider != nil {
+		t.Errorf("expected no registration, got %+v", provider)
+	}
+}
+
+func TestControllerUnregistersRemovedResourceKind(t *testing.T) {
+	sp := newServiceProvider("storage-provider", "volume", "snapshot")
+	client := newClient(t, sp)
+	s := startController(t, client)
+	eventually(t, "the resource kinds are registered", func() bool {
+		return len(getServiceProvider(t, client, sp.Name).Status.Registrations) == 2
+	})
+	registrations := getServiceProvider(t, client, sp.Name).Status.Registrations
+
+	updated := getServiceProvider(t, client, sp.Name)
+	updated.Spec.ResourceKinds = []string{"volume"}
+	updated.Generation++
+	updateServiceProvider(t, client, updated)
+
+	eventually(t, "the removed resource kind is unregistered", func() bool {
+		return len(getServiceProvider(t, client, sp.Name).Status.Registrations) == 1
+	})
+	for _, reg := range registrations {
+		provider := getProvider(t, s, reg.ServiceID)
+		if reg.ResourceKind == "snapshot" && provider != nil {
+			t.Errorf("expected the snapshot registration to be removed, got %+v", provider)
+		}
+	}
+	// The remaining kind is registered with the service ID of the spec
+	if provider := getProvider(t, s, sp.Spec.ServiceID); provider == nil || provider.ProviderType != "volume" {
+		t.Errorf("expected a volume provider for %s, got %+v", sp.Spec.ServiceID, provider)
+	}
+}
+
+func TestControllerUnregistersDeletedServiceProvider(t *testing.T) {
+	sp := newServiceProvider("vm-provider", "vm")
+	client := newClient(t, sp)
+	s := startController(t, client)
+	eventually(t, "the provider is registered", func() bool { return getProvider(t, s, sp.Spec.ServiceID) != nil })
+	eventually(t, "the finalizer is added", func() bool {
+		return slices.Contains(getServiceProvider(t, client, sp.Name).Finalizers, Finalizer)
+	})
+
+	// The fake client does not implement the finalizers, deleting is marking
+	// the object as deleted
+	deleted := getServiceProvider(t, client, sp.Name)
+	now := metav1.Now()
+	deleted.DeletionTimestamp = &now
+	updateServiceProvider(t, client, deleted)
+
+	eventually(t, "the provider is unregistered", func() bool { return getProvider(t, s, sp.Spec.ServiceID) == nil })
+	eventually(t, "the finalizer is removed", func() bool {
+		return !slices.Contains(getServiceProvider(t, client, sp.Name).Finalizers, Finalizer)
+	})
+}
@@ -28,7 +28,7 @@ func NewProviderApplication(db *gorm.DB) ProviderApplication {
 
 func (s *ProviderApplicationStore) List(ctx context.Context) (model.ProviderApplicationList, error) {
 	var apps model.ProviderApplicationList
-	tx := s.db.Model(&apps)
+	tx := s.db.WithContext(ctx).Model(&apps)
 	result := tx.Find(&apps)
 	if result.Error != nil {
 		return nil, result.Error
@@ -37,7 +37,7 @@ func (s *ProviderApplicationStore) List(ctx context.Context) (model.ProviderAppl
 }
 
 func (s *ProviderApplicationStore) Delete(ctx context.Context, id uuid.UUID) error {
-	result := s.db.Delete(&model.ProviderApplication{}, id)
+	result := s.db.WithContext(ctx).Delete(&model.ProviderApplication{}, id)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -45,7 +45,7 @@ func (s *ProviderApplicationStore) Delete(ctx context.Context, id uuid.UUID) err
 }
 
 func (s *ProviderApplicationStore) Create(ctx context.Context, app model.ProviderApplication) (*model.ProviderApplication, error) {
-	result := s.db.Clauses(clause.Returning{}).Create(&app)
+	result := s.db.WithContext(ctx).Clauses(clause.Returning{}).Create(&app)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -55,7 +55,7 @@ func (s *ProviderApplicationStore) Create(ctx context.Context, app model.Provide
 
 func (s *ProviderApplicationStore) Get(ctx context.Context, id uuid.UUID) (*model.ProviderApplication, error) {
 	var app model.ProviderApplication
-	result := s.db.First(&app, id)
+	result := s.db.WithContext(ctx).First(&app, id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -0,0 +1,367 @@
+package store
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/internal/store/model"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// The caches of a CachedStore, invalidated as a whole on every write. The
+// views join the providers and the catalog, and are invalidated with both.
+const (
+	CacheProviders = "providers"
+	CacheCatalog   = "catalog"
+	CacheViews     = "views"
+)
+
+// CacheRecorder records the lookups of a CachedStore, it is implemented by
+// metrics.CacheRecorder
+type CacheRecorder interface {
+	RecordLookup(cache string, hit bool)
+}
+
+// Notifier broadcasts the invalidations of a CachedStore to the other
+// replicas of the service
+type Notifier interface {
+	// Notify sends the invalidation of cache to the other replicas
+	Notify(ctx context.Context, cache string) error
+	// Listen calls invalidate with the caches invalidated by the other
+	// replicas until ctx is done
+	Listen(ctx context.Context, invalidate func(cache string)) error
+}
+
+type CacheConfig struct {
+	// TTL bounds the staleness of the entries, in case an invalidation of
+	// another replica is missed
+	TTL time.Duration
+	// Notifier is optional, a single replica needs no notifications
+	Notifier Notifier
+	// Recorder is optional
+	Recorder CacheRecorder
+}
+
+// CachedStore is a read-through cache of the provider and catalog reads of
+// a Store, which back the registry and the catalog views. The cache of a
+// table is flushed on every write, here and through the Notifier on the
+// other replicas. Transactions and the other tables are not cached.
+type CachedStore struct {
+	Store
+	provider *cachedProvider
+	catalog  *cachedCatalog
+	views    *cache
+	notifier Notifier
+}
+
+var _ Store = (*CachedStore)(nil)
+
+func NewCachedStore(inner Store, cfg CacheConfig) *CachedStore {
+	s := &CachedStore{
+		Store:    inner,
+		views:    newCache(CacheViews, cfg.TTL, cfg.Recorder),
+		notifier: cfg.Notifier,
+	}
+	s.provider = &cachedProvider{
+		Provider: inner.Provider(),
+		cache:    newCache(CacheProviders, cfg.TTL, cfg.Recorder),
+		store:    s,
+	}
+	s.catalog = &cachedCatalog{
+		Catalog: inner.Catalog(),
+		cache:   newCache(CacheCatalog, cfg.TTL, cfg.Recorder),
+		store:   s,
+	}
+	return s
+}
+
+func (s *CachedStore) Provider() Provider {
+	return s.provider
+}
+
+func (s *CachedStore) Catalog() Catalog {
+	return s.catalog
+}
+
+// WithTx runs fn on the transaction of the underlying store, whose reads are
+// not cached, and flushes the caches once it is committed
+func (s *CachedStore) WithTx(ctx context.Context, fn func(tx Store) error) error {
+	if err := s.Store.WithTx(ctx, fn); err != nil {
+		return err
+	}
+	s.invalidate(ctx, s.provider.cache)
+	s.invalidate(ctx, s.catalog.cache)
+	return nil
+}
+
+// Notifier returns the notifier of the invalidations, nil for a single
+// replica
+func (s *CachedStore) Notifier() Notifier {
+	return s.notifier
+}
+
+// Listen applies the invalidations of the other replicas until ctx is done
+func (s *CachedStore) Listen(ctx context.Context) error {
+	return s.notifier.Listen(ctx, s.Invalidate)
+}
+
+// Invalidate flushes the named cache, without notifying the other replicas
+func (s *CachedStore) Invalidate(cache string) {
+	switch cache {
+	case CacheProviders:
+		s.provider.cache.flush()
+	case CacheCatalog:
+		s.catalog.cache.flush()
+	default:
+		return
+	}
+	s.views.flush()
+}
+
+// invalidate flushes c after a write, and notifies the other replicas
+func (s *CachedStore) invalidate(ctx context.Context, c *cache) {
+	c.flush()
+	s.views.flush()
+	if s.notifier == nil {
+		return
+	}
+	// The other replicas catch up after the TTL if the notification is lost
+	if err := s.notifier.Notify(context.WithoutCancel(ctx), c.name); err != nil {
+		zap.S().Named("store").Warnw("Failed to notify the cache invalidation", "cache", c.name, "error", err)
+	}
+}
+
+type cacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+type cache struct {
+	name     string
+	ttl      time.Duration
+	recorder CacheRecorder
+
+	mu         sync.Mutex
+	entries    map[string]cacheEntry
+	generation uint64
+}
+
+func newCache(name string, ttl time.Duration, recorder CacheRecorder) *cache {
+	return &cache{name: name, ttl: ttl, recorder: recorder, entries: map[string]cacheEntry{}}
+}
+
+func (c *cache) flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string]cacheEntry{}
+	c.generation++
+}
+
+func (c *cache) record(hit bool) {
+	if c.recorder != nil {
+		c.recorder.RecordLookup(c.name, hit)
+	}
+}
+
+// lookup returns the cached value of key, or loads and caches it. Errors are
+// not cached, and neither are values loaded while the cache was flushed,
+// which may predate the write that flushed it.
+func lookup[T any](c *cache, key string, load func() (T, error)) (T, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	generation := c.generation
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		c.record(true)
+		return entry.value.(T), nil
+	}
+	c.record(false)
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+	c.mu.Lock()
+	if c.generation == generation {
+		c.entries[key] = cacheEntry{value: value, expiresAt: time.Now().Add(c.ttl)}
+	}
+	c.mu.Unlock()
+	return value, nil
+}
+
+// The cached values are shared: the cached lists are copied before they are
+// returned, and callers must not modify the maps of the records
+
+type cachedProvider struct {
+	Provider
+	cache *cache
+	store *CachedStore
+}
+
+func (p *cachedProvider) List(ctx context.Context) (model.ProviderList, error) {
+	providers, err := lookup(p.cache, "list", func() (model.ProviderList, error) {
+		return p.Provider.List(ctx)
+	})
+	return slices.Clone(providers), err
+}
+
+func (p *cachedProvider) ListByType(ctx context.Context, providerType string) (model.ProviderList, error) {
+	providers, err := lookup(p.cache, "type/"+providerType, func() (model.ProviderList, error) {
+		return p.Provider.ListByType(ctx, providerType)
+	})
+	return slices.Clone(providers), err
+}
+
+func (p *cachedProvider) Get(ctx context.Context, id uuid.UUID) (*model.Provider, error) {
+	provider, err := lookup(p.cache, "id/"+id.String(), func() (*model.Provider, error) {
+		return p.Provider.Get(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	copied := *provider
+	return &copied, nil
+}
+
+// The writes flush the cache even when they fail: a version conflict or a
+// missing record may be caused by a stale entry
+
+func (p *cachedProvider) Create(ctx context.Context, provider model.Provider) (*model.Provider, error) {
+	defer p.store.invalidate(ctx, p.cache)
+	return p.Provider.Create(ctx, provider)
+}
+
+func (p *cachedProvider) Delete(ctx context.Context, id uuid.UUID) error {
+	defer p.store.invalidate(ctx, p.cache)
+	return p.Provider.Delete(ctx, id)
+}
+
+func (p *cachedProvider) Update(ctx context.Context, provider model.Provider) (*model.Provider, error) {
+	defer p.store.invalidate(ctx, p.cache)
+	return p.Provider.Update(ctx, provider)
+}
+
+func (p *cachedProvider) Upsert(ctx context.Context, provider model.Provider) error {
+	defer p.store.invalidate(ctx, p.cache)
+	return p.Provider.Upsert(ctx, provider)
+}
+
+func (p *cachedProvider) DeleteAll(ctx context.Context) error {
+	defer p.store.invalidate(ctx, p.cache)
+	return p.Provider.DeleteAll(ctx)
+}
+
+type cachedCatalog struct {
+	Catalog
+	cache *cache
+	store *CachedStore
+}
+
+func (c *cachedCatalog) GetCatalogItem(ctx context.Context, name string) (*model.CatalogItem, error) {
+	item, err := lookup(c.cache, "item/"+name, func() (*model.CatalogItem, error) {
+		return c.Catalog.GetCatalogItem(ctx, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	copied := *item
+	return &copied, nil
+}
+
+func (c *cachedCatalog) ListCatalogItems(ctx context.Context, active bool) ([]model.CatalogItem, error) {
+	items, err := lookup(c.cache, "items/"+strconv.FormatBool(active), func() ([]model.CatalogItem, error) {
+		return c.Catalog.ListCatalogItems(ctx, active)
+	})
+	return slices.Clone(items), err
+}
+
+func (c *cachedCatalog) ListAllCatalogItems(ctx context.Context) ([]model.CatalogItem, error) {
+	items, err := lookup(c.cache, "items", func() ([]model.CatalogItem, error) {
+		return c.Catalog.ListAllCatalogItems(ctx)
+	})
+	return slices.Clone(items), err
+}
+
+func (c *cachedCatalog) GetCatalogMappings(ctx context.Context, catalogName string, active bool) ([]model.CatalogProviderMapping, error) {
+	mappings, err := lookup(c.cache, "mappings/"+strconv.FormatBool(active)+"/"+catalogName, func() ([]model.CatalogProviderMapping, error) {
+		return c.Catalog.GetCatalogMappings(ctx, catalogName, active)
+	})
+	return slices.Clone(mappings), err
+}
+
+func (c *cachedCatalog) ListAllCatalogMappings(ctx context.Context, active bool) ([]model.CatalogProviderMapping, error) {
+	mappings, err := lookup(c.cache, "mappings/"+strconv.FormatBool(active), func() ([]model.CatalogProviderMapping, error) {
+		return c.Catalog.ListAllCatalogMappings(ctx, active)
+	})
+	return slices.Clone(mappings), err
+}
+
+func (c *cachedCatalog) GetDistinctResourceKinds(ctx context.Context) ([]string, error) {
+	resourceKinds, err := lookup(c.cache, "resource_kinds", func() ([]string, error) {
+		return c.Catalog.GetDistinctResourceKinds(ctx)
+	})
+	return slices.Clone(resourceKinds), err
+}
+
+func (c *cachedCatalog) ListRegisteredProviders(ctx context.Context) (model.ProviderList, error) {
+	providers, err := lookup(c.store.views, "registry", func() (model.ProviderList, error) {
+		return c.Catalog.ListRegisteredProviders(ctx)
+	})
+	return slices.Clone(providers), err
+}
+
+func (c *cachedCatalog) ListCatalogProviders(ctx context.Context) ([]model.CatalogItemProviders, error) {
+	items, err := lookup(c.store.views, "catalog", func() ([]model.CatalogItemProviders, error) {
+		return c.Catalog.ListCatalogProviders(ctx)
+	})
+	return slices.Clone(items), err
+}
+
+func (c *cachedCatalog) CreateCatalogItem(ctx context.Context, item *model.CatalogItem) error {
+	defer c.store.invalidate(ctx, c.cache)
+	return c.Catalog.CreateCatalogItem(ctx, item)
+}
+
+func (c *cachedCatalog) UpdateCatalogItem(ctx context.Context, item *model.CatalogItem) error {
+	defer c.store.invalidate(ctx, c.cache)
+	return c.Catalog.UpdateCatalogItem(ctx, item)
+}
+
+func (c *cachedCatalog) DeleteCatalogItem(ctx context.Context, name string) error {
+	defer c.store.invalidate(ctx, c.cache)
+	return c.Catalog.DeleteCatalogItem(ctx, name)
+}
+
+func (c *cachedCatalog) UpsertCatalogItem(ctx context.Context, item *model.CatalogItem) error {
+	defer c.store.invalidate(ctx, c.cache)
+	return c.Catalog.UpsertCatalogItem(ctx, item)
+}
+
+func (c *cachedCatalog) DeleteAllCatalogItems(ctx context.Context) error {
+	defer c.store.invalidate(ctx, c.cache)
+	return c.Catalog.DeleteAllCatalogItems(ctx)
+}
+
+func (c *cachedCatalog) UpsertCatalogMapping(ctx context.Context, mapping *model.CatalogProviderMapping) error {
+	defer c.store.invalidate(ctx, c.cache)
+	return c.Catalog.UpsertCatalogMapping(ctx, mapping)
+}
+
+func (c *cachedCatalog) DeactivateMappings(ctx context.Context, serviceID, resourceKind string) error {
+	defer c.store.invalidate(ctx, c.cache)
+	return c.Catalog.DeactivateMappings(ctx, serviceID, resourceKind)
+}
+
+func (c *cachedCatalog) SaveCatalogMapping(ctx context.Context, mapping *model.CatalogProviderMapping) error {
+	defer c.store.invalidate(ctx, c.cache)
+	return c.Catalog.SaveCatalogMapping(ctx, mapping)
+}
+
+func (c *cachedCatalog) DeleteAllCatalogMappings(ctx context.Context) error {
+	defer c.store.invalidate(ctx, c.cache)
+	return c.Catalog.DeleteAllCatalogMappings(ctx)
+}
@@ -5,7 +5,9 @@ import (
 	"time"
 
 	"github.com/dcm-project/service-provider-api/internal/store/model"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Catalog interface {
@@ -14,6 +16,12 @@ type Catalog interface {
 	ListCatalogItems(ctx context.Context, active bool) ([]model.CatalogItem, error)
 	ListAllCatalogItems(ctx context.Context) ([]model.CatalogItem, error)
 	CreateCatalogItem(ctx context.Context, item *model.CatalogItem) error
+	UpdateCatalogItem(ctx context.Context, item *model.CatalogItem) error
+	DeleteCatalogItem(ctx context.Context, name string) error
+	// UpsertCatalogItem saves the catalog item as is, replacing the catalog
+	// item with the same name
+	UpsertCatalogItem(ctx context.Context, item *model.CatalogItem) error
+	DeleteAllCatalogItems(ctx context.Context) error
 
 	// CatalogProviderMapping operations
 	GetCatalogMappings(ctx context.Context, catalogName string, active bool) ([]model.CatalogProviderMapping, error)
@@ -21,6 +29,19 @@ type Catalog interface {
 	GetDistinctResourceKinds(ctx context.Context) ([]string, error)
 	UpsertCatalogMapping(ctx context.Context, mapping *model.CatalogProviderMapping) error
 	DeactivateMappings(ctx context.Context, serviceID, resourceKind string) error
+	// SaveCatalogMapping saves the mapping as is, replacing the mapping with
+	// the same ID
+	SaveCatalogMapping(ctx context.Context, mapping *model.CatalogProviderMapping) error
+	DeleteAllCatalogMappings(ctx context.Context) error
+
+	// Views, each read in a single query
+
+	// ListRegisteredProviders lists the providers of the resource kinds of
+	// the catalog mappings, ordered by registration
+	ListRegisteredProviders(ctx context.Context) (model.ProviderList, error)
+	// ListCatalogProviders lists the catalog items ordered by name, with the
+	// providers of their resource kind ordered by registration
+	ListCatalogProviders(ctx context.Context) ([]model.CatalogItemProviders, error)
 }
 
 type CatalogStore struct {
@@ -35,7 +56,7 @@ func NewCatalog(db *gorm.DB) Catalog {
 
 func (s *CatalogStore) GetCatalogItem(ctx context.Context, name string) (*model.CatalogItem, error) {
 	var item model.CatalogItem
-	result := s.db.Where("name = ?", name).First(&item)
+	result := s.db.WithContext(ctx).Where("name = ?", name).First(&item)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -44,7 +65,7 @@ func (s *CatalogStore) GetCatalogItem(ctx context.Context, name string) (*model.
 
 func (s *CatalogStore) ListCatalogItems(ctx context.Context, active bool) ([]model.CatalogItem, error) {
 	var items []model.CatalogItem
-	result := s.db.
+	result := s.db.WithContext(ctx).
 		Where("active = ?", active).
 		Order("resource_kind ASC, name ASC").
 		Find(&items)
@@ -56,7 +77,7 @@ func (s *CatalogStore) ListCatalogItems(ctx context.Context, active bool) ([]mod
 
 func (s *CatalogStore) ListAllCatalogItems(ctx context.Context) ([]model.CatalogItem, error) {
 	var items []model.CatalogItem
-	result := s.db.Find(&items)
+	result := s.db.WithContext(ctx).Find(&items)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -64,13 +85,54 @@ func (s *CatalogStore) ListAllCatalogItems(ctx context.Context) ([]model.Catalog
 }
 
 func (s *CatalogStore) CreateCatalogItem(ctx context.Context, item *model.CatalogItem) error {
-	result := s.db.Create(item)
+	result := s.db.WithContext(ctx).Create(item)
 	return result.Error
 }
 
+// UpdateCatalogItem saves the catalog item with the name of item
+func (s *CatalogStore) UpdateCatalogItem(ctx context.Context, item *model.CatalogItem) error {
+	result := s.db.WithContext(ctx).Model(&model.CatalogItem{}).
+		Where("name = ?", item.Name).
+		Select("display_name", "description", "resource_kind", "active", "labels", "annotations", "template", "updated_at").
+		Updates(item)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// DeleteCatalogItem removes the catalog item, permanently so that the name
+// can be reused
+func (s *CatalogStore) DeleteCatalogItem(ctx context.Context, name string) error {
+	result := s.db.WithContext(ctx).Unscoped().Where("name = ?", name).Delete(&model.CatalogItem{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *CatalogStore) UpsertCatalogItem(ctx context.Context, item *model.CatalogItem) error {
+	return s.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, UpdateAll: true}).
+		Select("*").
+		Create(item).Error
+}
+
+func (s *CatalogStore) DeleteAllCatalogItems(ctx context.Context) error {
+	return s.db.WithContext(ctx).Session(&gorm.Session{AllowGlobalUpdate: true}).
+		Unscoped().
+		Delete(&model.CatalogItem{}).Error
+}
+
 func (s *CatalogStore) GetCatalogMappings(ctx context.Context, catalogName string, active bool) ([]model.CatalogProviderMapping, error) {
 	var mappings []model.CatalogProviderMapping
-	result := s.db.
+	result := s.db.WithContext(ctx).
 		Where("catalog_name = ? AND active = ?", catalogName, active).
 		Find(&mappings)
 	if result.Error != nil {
@@ -81,7 +143,7 @@ func (s *CatalogStore) GetCatalogMappings(ctx context.Context, catalogName strin
 
 func (s *CatalogStore) ListAllCatalogMappings(ctx context.Context, active bool) ([]model.CatalogProviderMapping, error) {
 	var mappings []model.CatalogProviderMapping
-	result := s.db.
+	result := s.db.WithContext(ctx).
 		Where("active = ?", active).
 		Order("catalog_name ASC, service_id ASC").
 		Find(&mappings)
@@ -93,7 +155,7 @@ func (s *CatalogStore) ListAllCatalogMappings(ctx context.Context, active bool)
 
 func (s *CatalogStore) GetDistinctResourceKinds(ctx context.Context) ([]string, error) {
 	var resourceKinds []string
-	result := s.db.Table("catalog_provider_mappings").
+	result := s.db.WithContext(ctx).Table("catalog_provider_mappings").
 		Distinct("resource_kind").
 		Pluck("resource_kind", &resourceKinds)
 	if result.Error != nil {
@@ -104,7 +166,7 @@ func (s *CatalogStore) GetDistinctResourceKinds(ctx context.Context) ([]string,
 
 func (s *CatalogStore) UpsertCatalogMapping(ctx context.Context, mapping *model.CatalogProviderMapping) error {
 	now := time.Now()
-	result := s.db.
+	result := s.db.WithContext(ctx).
 		Where("catalog_name = ? AND service_id = ? AND resource_kind = ?",
 			mapping.CatalogName, mapping.ServiceID, mapping.ResourceKind).
 		Assign(map[string]interface{}{
@@ -117,8 +179,74 @@ func (s *CatalogStore) UpsertCatalogMapping(ctx context.Context, mapping *model.
 }
 
 func (s *CatalogStore) DeactivateMappings(ctx context.Context, serviceID, resourceKind string) error {
-	result := s.db.Model(&model.CatalogProviderMapping{}).
+	result := s.db.WithContext(ctx).Model(&model.CatalogProviderMapping{}).
 		Where("service_id = ? AND resource_kind = ?", serviceID, resourceKind).
 		Update("active", false)
 	return result.Error
 }
+
+func (s *CatalogStore) SaveCatalogMapping(ctx context.Context, mapping *model.CatalogProviderMapping) error {
+	return s.db.WithContext(ctx).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Select("*").
+		Create(mapping).Error
+}
+
+func (s *CatalogStore) DeleteAllCatalogMappings(ctx context.Context) error {
+	return s.db.WithContext(ctx).Session(&gorm.Session{AllowGlobalUpdate: true}).
+		Unscoped().
+		Delete(&model.CatalogProviderMapping{}).Error
+}
+
+func (s *CatalogStore) ListRegisteredProviders(ctx context.Context) (model.ProviderList, error) {
+	var providers model.ProviderList
+	resourceKinds := s.db.Table("catalog_provider_mappings").Distinct("resource_kind")
+	result := s.db.WithContext(ctx).
+		Where("provider_type IN (?)", resourceKinds).
+		Order("created_at, id").
+		Find(&providers)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return providers, nil
+}
+
+// catalogProviderRow is a row of the catalog view, ProviderID is null for
+// the catalog items without providers
+type catalogProviderRow struct {
+	Name         string
+	DisplayName  string
+	ResourceKind string
+	ProviderID   uuid.NullUUID
+}
+
+func (s *CatalogStore) ListCatalogProviders(ctx context.Context) ([]model.CatalogItemProviders, error) {
+	var rows []catalogProviderRow
+	result := s.db.WithContext(ctx).Table("catalog_items").
+		Select("catalog_items.name, catalog_items.display_name, catalog_items.resource_kind, providers.id AS provider_id").
+		Joins("LEFT JOIN providers ON providers.provider_type = catalog_items.resource_kind AND providers.deleted_at IS NULL").
+		Where("catalog_items.deleted_at IS NULL").
+		Order("catalog_items.name, providers.created_at, providers.id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// The rows of a catalog item are consecutive
+	items := []model.CatalogItemProviders{}
+	for _, row := range rows {
+		if len(items) == 0 || items[len(items)-1].Name != row.Name {
+			items = append(items, model.CatalogItemProviders{
+				Name:         row.Name,
+				DisplayName:  row.DisplayName,
+				ResourceKind: row.ResourceKind,
+				ProviderIDs:  []uuid.UUID{},
+			})
+		}
+		if row.ProviderID.Valid {
+			item := &items[len(items)-1]
+			item.ProviderIDs = append(item.ProviderIDs, row.ProviderID.UUID)
+		}
+	}
+	return items, nil
+}
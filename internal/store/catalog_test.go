@@ -0,0 +1,70 @@
+package store_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/dcm-project/service-provider-api/internal/config"
+	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/dcm-project/service-provider-api/internal/store/storetest"
+)
+
+const (
+	benchmarkProviders     = 10000
+	benchmarkResourceKinds = 20
+)
+
+// openBenchmarkStore opens a SQLite store seeded with 10k registrations
+func openBenchmarkStore(b *testing.B) store.Store {
+	b.Helper()
+
+	cfg, err := config.Load("")
+	if err != nil {
+		b.Fatalf("loading the configuration: %v", err)
+	}
+	cfg.Database.Type = "sqlite"
+	cfg.Database.Name = filepath.Join(b.TempDir(), "benchmark.db")
+	s, err := store.Open(context.Background(), cfg)
+	if err != nil {
+		b.Fatalf("opening the store: %v", err)
+	}
+	b.Cleanup(func() { _ = s.Close() })
+
+	storetest.Seed(b, s, benchmarkProviders, benchmarkResourceKinds)
+	return s
+}
+
+func BenchmarkListRegisteredProviders(b *testing.B) {
+	s := openBenchmarkStore(b)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		providers, err := s.Catalog().ListRegisteredProviders(ctx)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(providers) != benchmarkProviders {
+			b.Fatalf("expected %d providers, got %d", benchmarkProviders, len(providers))
+		}
+	}
+}
+
+func BenchmarkListCatalogProviders(b *testing.B) {
+	s := openBenchmarkStore(b)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		items, err := s.Catalog().ListCatalogProviders(ctx)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(items) != benchmarkResourceKinds {
+			b.Fatalf("expected %d catalog items, got %d", benchmarkResourceKinds, len(items))
+		}
+	}
+}
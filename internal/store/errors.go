@@ -0,0 +1,18 @@
+package store
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrNotFound is returned when a requested record does not exist
+	ErrNotFound = gorm.ErrRecordNotFound
+
+	// ErrAlreadyExists is returned when a record violates a unique constraint
+	ErrAlreadyExists = gorm.ErrDuplicatedKey
+
+	// ErrVersionConflict is returned when an update is based on a stale version of a resource
+	ErrVersionConflict = errors.New("resource version conflict")
+)
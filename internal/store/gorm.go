@@ -1,90 +1,201 @@
 package store
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"net/url"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/dcm-project/service-provider-api/internal/config"
+	"github.com/dcm-project/service-provider-api/internal/logging"
 	"github.com/dcm-project/service-provider-api/internal/store/model"
-	"github.com/sirupsen/logrus"
+	"github.com/dcm-project/service-provider-api/internal/tracing"
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 var (
 	registerSync sync.Once
 )
 
-func InitDB(cfg *config.Config) (*gorm.DB, error) {
-	var dia gorm.Dialector
+// models are the tables migrated by InitDB
+var models = []interface{}{
+	&model.ProviderApplication{},
+	&model.Provider{},
+	&model.CatalogItem{},
+	&model.CatalogProviderMapping{},
+	&model.IdempotencyRecord{},
+}
 
-	if cfg.Database.Type == "pgsql" {
-		dsn := fmt.Sprintf("host=%s user=%s password=%s port=%s",
-			cfg.Database.Hostname,
-			cfg.Database.User,
-			cfg.Database.Password,
-			cfg.Database.Port,
-		)
-		if cfg.Database.Name != "" {
-			dsn = fmt.Sprintf("%s dbname=%s", dsn, cfg.Database.Name)
-		}
+// maxConnectDelay caps the delay between the attempts to connect
+const maxConnectDelay = 10 * time.Second
 
-		dia = postgres.Open(dsn)
+// InitDB connects to the configured database and migrates it. Connecting is
+// retried with an exponential backoff for cfg.Database.ConnectTimeout, so
+// that the service can start before the database is ready.
+func InitDB(ctx context.Context, cfg *config.Config) (*gorm.DB, error) {
+	var dia gorm.Dialector
+	system := "sqlite"
+	if cfg.Database.Type == "pgsql" {
+		dia = postgres.Open(postgresDSN(cfg))
+		system = "postgresql"
 	} else {
-		dia = sqlite.Open(cfg.Database.Name)
+		dia = sqlite.Open(sqliteDSN(cfg.Database.Name))
 	}
 
-	newLogger := logger.New(
-		logrus.New(),
-		logger.Config{
-			SlowThreshold:             time.Second, // Slow SQL threshold
-			LogLevel:                  logger.Warn, // Log level
-			IgnoreRecordNotFoundError: true,        // Ignore ErrRecordNotFound error for logger
-			ParameterizedQueries:      true,        // Don't include params in the SQL log
-			Colorful:                  false,       // Disable color
-		},
-	)
+	newLogger := logging.NewGormLogger(zap.S().Named("gorm"), time.Second)
 
-	newDB, err := gorm.Open(dia, &gorm.Config{Logger: newLogger, TranslateError: true})
+	// The database is pinged below, with retries
+	newDB, err := gorm.Open(dia, &gorm.Config{Logger: newLogger, TranslateError: true, DisableAutomaticPing: true})
 	if err != nil {
-		zap.S().Named("gorm").Fatalf("failed to connect database: %v", err)
-		return nil, err
+		return nil, fmt.Errorf("opening the database: %w", err)
 	}
-
 	sqlDB, err := newDB.DB()
 	if err != nil {
-		zap.S().Named("gorm").Fatalf("failed to configure connections: %v", err)
+		return nil, fmt.Errorf("configuring the connections: %w", err)
+	}
+	sqlDB.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+
+	if err := setup(ctx, newDB, sqlDB, cfg, system); err != nil {
+		_ = sqlDB.Close()
 		return nil, err
 	}
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
+	return newDB, nil
+}
+
+func setup(ctx context.Context, db *gorm.DB, sqlDB *sql.DB, cfg *config.Config, system string) error {
+	if err := ping(ctx, sqlDB, cfg.Database.ConnectTimeout); err != nil {
+		return err
+	}
+
+	if err := db.Use(tracing.NewGormPlugin(system)); err != nil {
+		return err
+	}
 
 	if cfg.Database.Type == "pgsql" {
 		var minorVersion string
-		if result := newDB.Raw("SELECT version()").Scan(&minorVersion); result.Error != nil {
-			zap.S().Named("gorm").Infoln(result.Error.Error())
-			return nil, result.Error
+		if result := db.WithContext(ctx).Raw("SELECT version()").Scan(&minorVersion); result.Error != nil {
+			return fmt.Errorf("reading the PostgreSQL version: %w", result.Error)
 		}
 
 		zap.S().Named("gorm").Infof("PostgreSQL information: '%s'", minorVersion)
 	}
 
+	if err := migrateOperations(db); err != nil {
+		return fmt.Errorf("migrating the operations of the providers: %w", err)
+	}
+
 	// FIXME: replace with proper migration system
-	if err := newDB.AutoMigrate(
-		&model.ProviderApplication{},
-		&model.Provider{},
-		&model.CatalogItem{},
-		&model.CatalogProviderMapping{},
-	); err != nil {
-		zap.S().Named("gorm").Fatalf("failed to migrate database: %v", err)
-		return nil, err
+	if err := db.AutoMigrate(models...); err != nil {
+		return fmt.Errorf("migrating the database: %w", err)
 	}
 
 	zap.S().Named("gorm").Info("Database migration completed successfully")
+	return nil
+}
 
-	return newDB, nil
+// ping waits for the database to accept connections, retrying with an
+// exponential backoff until timeout
+func ping(ctx context.Context, sqlDB *sql.DB, timeout time.Duration) error {
+	if timeout <= 0 {
+		if err := sqlDB.PingContext(ctx); err != nil {
+			return fmt.Errorf("connecting to the database: %w", err)
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	delay := 500 * time.Millisecond
+	for attempt := 1; ; attempt++ {
+		err := sqlDB.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+		zap.S().Named("gorm").Warnw("Database is not reachable, retrying", "attempt", attempt, "delay", delay, "error", err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("connecting to the database, %d attempts in %s: %w", attempt, timeout, err)
+		case <-time.After(delay):
+		}
+		delay = min(2*delay, maxConnectDelay)
+	}
+}
+
+// postgresDSN returns the connection string of cfg.Database.URL, with the
+// password of the password file if any, or built from the connection fields
+func postgresDSN(cfg *config.Config) string {
+	if cfg.Database.URL != "" {
+		if cfg.Database.PasswordFile == "" {
+			return cfg.Database.URL
+		}
+		if u, err := url.Parse(cfg.Database.URL); err == nil && u.Scheme != "" {
+			u.User = url.UserPassword(u.User.Username(), cfg.Database.Password)
+			return u.String()
+		}
+		return cfg.Database.URL + " password=" + dsnValue(cfg.Database.Password)
+	}
+
+	params := []string{
+		"host=" + dsnValue(cfg.Database.Hostname),
+		"port=" + dsnValue(cfg.Database.Port),
+		"user=" + dsnValue(cfg.Database.User),
+		"password=" + dsnValue(cfg.Database.Password),
+	}
+	if cfg.Database.Name != "" {
+		params = append(params, "dbname="+dsnValue(cfg.Database.Name))
+	}
+	if cfg.Database.SSLMode != "" {
+		params = append(params, "sslmode="+dsnValue(cfg.Database.SSLMode))
+	}
+	if cfg.Database.SSLRootCert != "" {
+		params = append(params, "sslrootcert="+dsnValue(cfg.Database.SSLRootCert))
+	}
+	return strings.Join(params, " ")
+}
+
+// dsnValue quotes a value of a key/value connection string
+func dsnValue(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	return "'" + replacer.Replace(value) + "'"
+}
+
+// sqliteDSN enables WAL mode, so that reads do not block on writes, waits for
+// the locks of concurrent writers instead of failing, and starts transactions
+// with the write lock so that they do not fail to upgrade their read lock
+func sqliteDSN(name string) string {
+	separator := "?"
+	if strings.Contains(name, "?") {
+		separator = "&"
+	}
+	return name + separator + "_journal_mode=WAL&_busy_timeout=5000&_txlock=immediate&_foreign_keys=on"
+}
+
+// migrateOperations converts the text[] operations column of the providers
+// created by earlier versions to the JSON array stored by every database
+func migrateOperations(db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" || !db.Migrator().HasTable(&model.Provider{}) {
+		return nil
+	}
+
+	var dataType string
+	result := db.Raw(`SELECT data_type FROM information_schema.columns
+		WHERE table_schema = CURRENT_SCHEMA() AND table_name = ? AND column_name = ?`, "providers", "operations").
+		Scan(&dataType)
+	if result.Error != nil {
+		return result.Error
+	}
+	if dataType != "ARRAY" {
+		return nil
+	}
+
+	zap.S().Named("gorm").Info("Converting the operations of the providers to JSON")
+	return db.Exec("ALTER TABLE providers ALTER COLUMN operations TYPE text USING array_to_json(operations)::text").Error
 }
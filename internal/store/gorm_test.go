@@ -0,0 +1,27 @@
+package store_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/dcm-project/service-provider-api/internal/store/storetest"
+)
+
+// TestDataStore runs the contract suite against the database selected by
+// DB_TYPE, a SQLite file in a temporary directory unless DB_NAME is set
+func TestDataStore(t *testing.T) {
+	switch os.Getenv("DB_TYPE") {
+	case "", "memory":
+		t.Skip("DB_TYPE selects no database, set DB_TYPE=sqlite or DB_TYPE=pgsql")
+	case "sqlite":
+		if os.Getenv("DB_NAME") == "" {
+			t.Setenv("DB_NAME", filepath.Join(t.TempDir(), "storetest.db"))
+		}
+	}
+
+	storetest.Test(t, func(t *testing.T) store.Store {
+		return storetest.Open(t)
+	})
+}
@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/internal/store/model"
+	"gorm.io/gorm"
+)
+
+type Idempotency interface {
+	// Get returns the unexpired record for key
+	Get(ctx context.Context, key string) (*model.IdempotencyRecord, error)
+	// Create claims key, replacing an expired record. gorm.ErrDuplicatedKey is
+	// returned if an unexpired record already exists.
+	Create(ctx context.Context, record model.IdempotencyRecord) error
+	// Complete stores the response of the request that claimed key
+	Complete(ctx context.Context, key string, statusCode int, header map[string][]string, body []byte) error
+	Delete(ctx context.Context, key string) error
+	// DeleteExpired removes all records that expired before now
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type IdempotencyStore struct {
+	db *gorm.DB
+}
+
+var _ Idempotency = (*IdempotencyStore)(nil)
+
+func NewIdempotency(db *gorm.DB) Idempotency {
+	return &IdempotencyStore{db: db}
+}
+
+func (s *IdempotencyStore) Get(ctx context.Context, key string) (*model.IdempotencyRecord, error) {
+	var record model.IdempotencyRecord
+	result := s.db.WithContext(ctx).Where("key = ? AND expires_at > ?", key, time.Now()).First(&record)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &record, nil
+}
+
+func (s *IdempotencyStore) Create(ctx context.Context, record model.IdempotencyRecord) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("key = ? AND expires_at <= ?", record.Key, time.Now()).
+			Delete(&model.IdempotencyRecord{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&record).Error
+	})
+}
+
+func (s *IdempotencyStore) Complete(ctx context.Context, key string, statusCode int, header map[string][]string, body []byte) error {
+	result := s.db.WithContext(ctx).Model(&model.IdempotencyRecord{Key: key}).
+		Select("status_code", "response_header", "response_body").
+		Updates(&model.IdempotencyRecord{
+			StatusCode:     statusCode,
+			ResponseHeader: header,
+			ResponseBody:   body,
+		})
+	return result.Error
+}
+
+func (s *IdempotencyStore) Delete(ctx context.Context, key string) error {
+	result := s.db.WithContext(ctx).Where("key = ?", key).Delete(&model.IdempotencyRecord{})
+	return result.Error
+}
+
+func (s *IdempotencyStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&model.IdempotencyRecord{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,666 @@
+package store
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/internal/store/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// memoryData holds the records of a MemoryStore. The records are copied in
+// and out so that callers never share the maps and slices of stored records.
+type memoryData struct {
+	applications map[uuid.UUID]model.ProviderApplication
+	providers    map[uuid.UUID]model.Provider
+	catalogItems map[string]model.CatalogItem
+	mappings     map[uuid.UUID]model.CatalogProviderMapping
+	idempotency  map[string]model.IdempotencyRecord
+}
+
+func newMemoryData() *memoryData {
+	return &memoryData{
+		applications: map[uuid.UUID]model.ProviderApplication{},
+		providers:    map[uuid.UUID]model.Provider{},
+		catalogItems: map[string]model.CatalogItem{},
+		mappings:     map[uuid.UUID]model.CatalogProviderMapping{},
+		idempotency:  map[string]model.IdempotencyRecord{},
+	}
+}
+
+// clone returns a copy of the data, the stored records are never modified in
+// place so they can be shared
+func (d *memoryData) clone() *memoryData {
+	return &memoryData{
+		applications: maps.Clone(d.applications),
+		providers:    maps.Clone(d.providers),
+		catalogItems: maps.Clone(d.catalogItems),
+		mappings:     maps.Clone(d.mappings),
+		idempotency:  maps.Clone(d.idempotency),
+	}
+}
+
+type memoryDB struct {
+	mu   sync.RWMutex
+	data *memoryData
+}
+
+// MemoryStore is a Store holding the records in memory, for tests and local
+// runs without a database. It has the semantics of DataStore: applications
+// are soft deleted, providers and catalog items are removed permanently,
+// catalog item names are unique and missing records are reported with
+// ErrNotFound.
+type MemoryStore struct {
+	db          *memoryDB
+	application ProviderApplication
+	provider    Provider
+	catalog     Catalog
+	idempotency Idempotency
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+func NewMemoryStore() Store {
+	return newMemoryStore(&memoryDB{data: newMemoryData()})
+}
+
+func newMemoryStore(db *memoryDB) *MemoryStore {
+	return &MemoryStore{
+		db:          db,
+		application: &memoryApplicationStore{db: db},
+		provider:    &memoryProviderStore{db: db},
+		catalog:     &memoryCatalogStore{db: db},
+		idempotency: &memoryIdempotencyStore{db: db},
+	}
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+func (s *MemoryStore) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (s *MemoryStore) CheckMigrations(ctx context.Context) error {
+	return nil
+}
+
+func (s *MemoryStore) Application() ProviderApplication {
+	return s.application
+}
+
+func (s *MemoryStore) Provider() Provider {
+	return s.provider
+}
+
+func (s *MemoryStore) Catalog() Catalog {
+	return s.catalog
+}
+
+func (s *MemoryStore) Idempotency() Idempotency {
+	return s.idempotency
+}
+
+// WithTx runs fn on a copy of the data, which replaces the data if fn
+// succeeds. The store is locked until fn returns, so fn must only use tx.
+func (s *MemoryStore) WithTx(ctx context.Context, fn func(tx Store) error) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	tx := &memoryDB{data: s.db.data.clone()}
+	if err := fn(newMemoryStore(tx)); err != nil {
+		return err
+	}
+	s.db.data = tx.data
+	return nil
+}
+
+type memoryApplicationStore struct {
+	db *memoryDB
+}
+
+var _ ProviderApplication = (*memoryApplicationStore)(nil)
+
+func (s *memoryApplicationStore) List(ctx context.Context) (model.ProviderApplicationList, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	apps := model.ProviderApplicationList{}
+	for _, app := range s.db.data.applications {
+		if !app.DeletedAt.Valid {
+			apps = append(apps, app)
+		}
+	}
+	sort.Slice(apps, func(i, j int) bool {
+		return createdBefore(apps[i].CreatedAt, apps[j].CreatedAt, apps[i].ID, apps[j].ID)
+	})
+	return apps, nil
+}
+
+func (s *memoryApplicationStore) Create(ctx context.Context, app model.ProviderApplication) (*model.ProviderApplication, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if _, ok := s.db.data.applications[app.ID]; ok {
+		return nil, ErrAlreadyExists
+	}
+	setTimestamps(&app.CreatedAt, &app.UpdatedAt)
+	s.db.data.applications[app.ID] = app
+	return &app, nil
+}
+
+func (s *memoryApplicationStore) Delete(ctx context.Context, id uuid.UUID) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if app, ok := s.db.data.applications[id]; ok && !app.DeletedAt.Valid {
+		app.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+		s.db.data.applications[id] = app
+	}
+	return nil
+}
+
+func (s *memoryApplicationStore) Get(ctx context.Context, id uuid.UUID) (*model.ProviderApplication, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	app, ok := s.db.data.applications[id]
+	if !ok || app.DeletedAt.Valid {
+		return nil, ErrNotFound
+	}
+	return &app, nil
+}
+
+type memoryProviderStore struct {
+	db *memoryDB
+}
+
+var _ Provider = (*memoryProviderStore)(nil)
+
+func (s *memoryProviderStore) List(ctx context.Context) (model.ProviderList, error) {
+	return s.list(func(model.Provider) bool { return true }), nil
+}
+
+func (s *memoryProviderStore) ListByType(ctx context.Context, providerType string) (model.ProviderList, error) {
+	return s.list(func(p model.Provider) bool { return p.ProviderType == providerType }), nil
+}
+
+func (s *memoryProviderStore) list(match func(model.Provider) bool) model.ProviderList {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	return s.db.data.listProviders(match)
+}
+
+// listProviders returns the matching providers ordered by registration
+func (d *memoryData) listProviders(match func(model.Provider) bool) model.ProviderList {
+	providers := model.ProviderList{}
+	for _, p := range d.providers {
+		if !p.DeletedAt.Valid && match(p) {
+			providers = append(providers, copyProvider(p))
+		}
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		return createdBefore(providers[i].CreatedAt, providers[j].CreatedAt, providers[i].ID, providers[j].ID)
+	})
+	return providers
+}
+
+func (s *memoryProviderStore) Create(ctx context.Context, provider model.Provider) (*model.Provider, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if _, ok := s.db.data.providers[provider.ID]; ok {
+		return nil, ErrAlreadyExists
+	}
+	if provider.Version == 0 {
+		provider.Version = 1
+	}
+	setTimestamps(&provider.CreatedAt, &provider.UpdatedAt)
+	s.db.data.providers[provider.ID] = copyProvider(provider)
+	return &provider, nil
+}
+
+func (s *memoryProviderStore) Delete(ctx context.Context, id uuid.UUID) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	delete(s.db.data.providers, id)
+	return nil
+}
+
+func (s *memoryProviderStore) Get(ctx context.Context, id uuid.UUID) (*model.Provider, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	p, ok := s.db.data.providers[id]
+	if !ok || p.DeletedAt.Valid {
+		return nil, ErrNotFound
+	}
+	p = copyProvider(p)
+	return &p, nil
+}
+
+// Update saves the provider only if its Version matches the stored one, and
+// increments the version. ErrVersionConflict is returned on a mismatch.
+func (s *memoryProviderStore) Update(ctx context.Context, provider model.Provider) (*model.Provider, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	stored, ok := s.db.data.providers[provider.ID]
+	if !ok || stored.DeletedAt.Valid {
+		return nil, ErrNotFound
+	}
+	if stored.Version != provider.Version {
+		return nil, ErrVersionConflict
+	}
+	provider.Version++
+	provider.CreatedAt = stored.CreatedAt
+	provider.UpdatedAt = time.Now()
+	s.db.data.providers[provider.ID] = copyProvider(provider)
+	return &provider, nil
+}
+
+func (s *memoryProviderStore) Upsert(ctx context.Context, provider model.Provider) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	setTimestamps(&provider.CreatedAt, &provider.UpdatedAt)
+	s.db.data.providers[provider.ID] = copyProvider(provider)
+	return nil
+}
+
+func (s *memoryProviderStore) DeleteAll(ctx context.Context) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	s.db.data.providers = map[uuid.UUID]model.Provider{}
+	return nil
+}
+
+type memoryCatalogStore struct {
+	db *memoryDB
+}
+
+var _ Catalog = (*memoryCatalogStore)(nil)
+
+func (s *memoryCatalogStore) GetCatalogItem(ctx context.Context, name string) (*model.CatalogItem, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	item, ok := s.db.data.catalogItems[name]
+	if !ok || item.DeletedAt.Valid {
+		return nil, ErrNotFound
+	}
+	item = copyCatalogItem(item)
+	return &item, nil
+}
+
+func (s *memoryCatalogStore) ListCatalogItems(ctx context.Context, active bool) ([]model.CatalogItem, error) {
+	items := s.listCatalogItems(func(item model.CatalogItem) bool { return item.Active == active })
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].ResourceKind < items[j].ResourceKind
+	})
+	return items, nil
+}
+
+func (s *memoryCatalogStore) ListAllCatalogItems(ctx context.Context) ([]model.CatalogItem, error) {
+	return s.listCatalogItems(func(model.CatalogItem) bool { return true }), nil
+}
+
+func (s *memoryCatalogStore) listCatalogItems(match func(model.CatalogItem) bool) []model.CatalogItem {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	return s.db.data.listCatalogItems(match)
+}
+
+// listCatalogItems returns the matching catalog items ordered by name
+func (d *memoryData) listCatalogItems(match func(model.CatalogItem) bool) []model.CatalogItem {
+	items := []model.CatalogItem{}
+	for _, item := range d.catalogItems {
+		if !item.DeletedAt.Valid && match(item) {
+			items = append(items, copyCatalogItem(item))
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Name < items[j].Name
+	})
+	return items
+}
+
+func (s *memoryCatalogStore) CreateCatalogItem(ctx context.Context, item *model.CatalogItem) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if _, ok := s.db.data.catalogItems[item.Name]; ok {
+		return ErrAlreadyExists
+	}
+	if item.ID == uuid.Nil {
+		item.ID = uuid.New()
+	} else if s.catalogItemIDExists(item.ID) {
+		return ErrAlreadyExists
+	}
+	setTimestamps(&item.CreatedAt, &item.UpdatedAt)
+	s.db.data.catalogItems[item.Name] = copyCatalogItem(*item)
+	return nil
+}
+
+func (s *memoryCatalogStore) catalogItemIDExists(id uuid.UUID) bool {
+	for _, item := range s.db.data.catalogItems {
+		if item.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateCatalogItem saves the catalog item with the name of item
+func (s *memoryCatalogStore) UpdateCatalogItem(ctx context.Context, item *model.CatalogItem) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	stored, ok := s.db.data.catalogItems[item.Name]
+	if !ok || stored.DeletedAt.Valid {
+		return ErrNotFound
+	}
+	stored.DisplayName = item.DisplayName
+	stored.Description = item.Description
+	stored.ResourceKind = item.ResourceKind
+	stored.Active = item.Active
+	stored.Labels = item.Labels
+	stored.Annotations = item.Annotations
+	stored.Template = item.Template
+	stored.UpdatedAt = time.Now()
+	s.db.data.catalogItems[item.Name] = copyCatalogItem(stored)
+	return nil
+}
+
+// DeleteCatalogItem removes the catalog item, permanently so that the name
+// can be reused
+func (s *memoryCatalogStore) DeleteCatalogItem(ctx context.Context, name string) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if _, ok := s.db.data.catalogItems[name]; !ok {
+		return ErrNotFound
+	}
+	delete(s.db.data.catalogItems, name)
+	return nil
+}
+
+func (s *memoryCatalogStore) UpsertCatalogItem(ctx context.Context, item *model.CatalogItem) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if stored, ok := s.db.data.catalogItems[item.Name]; ok {
+		// The primary key of the existing catalog item is kept
+		item.ID = stored.ID
+	} else if item.ID == uuid.Nil {
+		item.ID = uuid.New()
+	}
+	setTimestamps(&item.CreatedAt, &item.UpdatedAt)
+	s.db.data.catalogItems[item.Name] = copyCatalogItem(*item)
+	return nil
+}
+
+func (s *memoryCatalogStore) DeleteAllCatalogItems(ctx context.Context) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	s.db.data.catalogItems = map[string]model.CatalogItem{}
+	return nil
+}
+
+func (s *memoryCatalogStore) GetCatalogMappings(ctx context.Context, catalogName string, active bool) ([]model.CatalogProviderMapping, error) {
+	return s.listCatalogMappings(func(m model.CatalogProviderMapping) bool {
+		return m.CatalogName == catalogName && m.Active == active
+	}), nil
+}
+
+func (s *memoryCatalogStore) ListAllCatalogMappings(ctx context.Context, active bool) ([]model.CatalogProviderMapping, error) {
+	return s.listCatalogMappings(func(m model.CatalogProviderMapping) bool { return m.Active == active }), nil
+}
+
+// listCatalogMappings returns the matching mappings ordered by catalog name
+// and service ID
+func (s *memoryCatalogStore) listCatalogMappings(match func(model.CatalogProviderMapping) bool) []model.CatalogProviderMapping {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	mappings := []model.CatalogProviderMapping{}
+	for _, m := range s.db.data.mappings {
+		if !m.DeletedAt.Valid && match(m) {
+			mappings = append(mappings, m)
+		}
+	}
+	sort.Slice(mappings, func(i, j int) bool {
+		if mappings[i].CatalogName != mappings[j].CatalogName {
+			return mappings[i].CatalogName < mappings[j].CatalogName
+		}
+		return mappings[i].ServiceID < mappings[j].ServiceID
+	})
+	return mappings
+}
+
+func (s *memoryCatalogStore) GetDistinctResourceKinds(ctx context.Context) ([]string, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	resourceKinds := []string{}
+	for _, m := range s.db.data.mappings {
+		if !slices.Contains(resourceKinds, m.ResourceKind) {
+			resourceKinds = append(resourceKinds, m.ResourceKind)
+		}
+	}
+	sort.Strings(resourceKinds)
+	return resourceKinds, nil
+}
+
+func (s *memoryCatalogStore) UpsertCatalogMapping(ctx context.Context, mapping *model.CatalogProviderMapping) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	now := time.Now()
+	for id, stored := range s.db.data.mappings {
+		if stored.DeletedAt.Valid || stored.CatalogName != mapping.CatalogName ||
+			stored.ServiceID != mapping.ServiceID || stored.ResourceKind != mapping.ResourceKind {
+			continue
+		}
+		stored.Endpoint = mapping.Endpoint
+		stored.Active = mapping.Active
+		stored.UpdatedAt = now
+		s.db.data.mappings[id] = stored
+		*mapping = stored
+		return nil
+	}
+
+	if mapping.ID == uuid.Nil {
+		mapping.ID = uuid.New()
+	} else if _, ok := s.db.data.mappings[mapping.ID]; ok {
+		return ErrAlreadyExists
+	}
+	if mapping.CreatedAt.IsZero() {
+		mapping.CreatedAt = now
+	}
+	mapping.UpdatedAt = now
+	s.db.data.mappings[mapping.ID] = *mapping
+	return nil
+}
+
+func (s *memoryCatalogStore) DeactivateMappings(ctx context.Context, serviceID, resourceKind string) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	now := time.Now()
+	for id, m := range s.db.data.mappings {
+		if !m.DeletedAt.Valid && m.ServiceID == serviceID && m.ResourceKind == resourceKind {
+			m.Active = false
+			m.UpdatedAt = now
+			s.db.data.mappings[id] = m
+		}
+	}
+	return nil
+}
+
+func (s *memoryCatalogStore) SaveCatalogMapping(ctx context.Context, mapping *model.CatalogProviderMapping) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if mapping.ID == uuid.Nil {
+		mapping.ID = uuid.New()
+	}
+	setTimestamps(&mapping.CreatedAt, &mapping.UpdatedAt)
+	s.db.data.mappings[mapping.ID] = *mapping
+	return nil
+}
+
+func (s *memoryCatalogStore) DeleteAllCatalogMappings(ctx context.Context) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	s.db.data.mappings = map[uuid.UUID]model.CatalogProviderMapping{}
+	return nil
+}
+
+func (s *memoryCatalogStore) ListRegisteredProviders(ctx context.Context) (model.ProviderList, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	resourceKinds := map[string]bool{}
+	for _, m := range s.db.data.mappings {
+		resourceKinds[m.ResourceKind] = true
+	}
+	return s.db.data.listProviders(func(p model.Provider) bool { return resourceKinds[p.ProviderType] }), nil
+}
+
+func (s *memoryCatalogStore) ListCatalogProviders(ctx context.Context) ([]model.CatalogItemProviders, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	items := s.db.data.listCatalogItems(func(model.CatalogItem) bool { return true })
+	result := make([]model.CatalogItemProviders, 0, len(items))
+	for _, item := range items {
+		providers := s.db.data.listProviders(func(p model.Provider) bool { return p.ProviderType == item.ResourceKind })
+		providerIDs := make([]uuid.UUID, 0, len(providers))
+		for _, p := range providers {
+			providerIDs = append(providerIDs, p.ID)
+		}
+		result = append(result, model.CatalogItemProviders{
+			Name:         item.Name,
+			DisplayName:  item.DisplayName,
+			ResourceKind: item.ResourceKind,
+			ProviderIDs:  providerIDs,
+		})
+	}
+	return result, nil
+}
+
+type memoryIdempotencyStore struct {
+	db *memoryDB
+}
+
+var _ Idempotency = (*memoryIdempotencyStore)(nil)
+
+func (s *memoryIdempotencyStore) Get(ctx context.Context, key string) (*model.IdempotencyRecord, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	record, ok := s.db.data.idempotency[key]
+	if !ok || !record.ExpiresAt.After(time.Now()) {
+		return nil, ErrNotFound
+	}
+	record.ResponseBody = slices.Clone(record.ResponseBody)
+	return &record, nil
+}
+
+func (s *memoryIdempotencyStore) Create(ctx context.Context, record model.IdempotencyRecord) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if existing, ok := s.db.data.idempotency[record.Key]; ok && existing.ExpiresAt.After(time.Now()) {
+		return ErrAlreadyExists
+	}
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+	record.ResponseBody = slices.Clone(record.ResponseBody)
+	s.db.data.idempotency[record.Key] = record
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Complete(ctx context.Context, key string, statusCode int, header map[string][]string, body []byte) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if record, ok := s.db.data.idempotency[key]; ok {
+		record.StatusCode = statusCode
+		record.ResponseHeader = maps.Clone(header)
+		record.ResponseBody = slices.Clone(body)
+		s.db.data.idempotency[key] = record
+	}
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Delete(ctx context.Context, key string) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	delete(s.db.data.idempotency, key)
+	return nil
+}
+
+func (s *memoryIdempotencyStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	var deleted int64
+	for key, record := range s.db.data.idempotency {
+		if !record.ExpiresAt.After(now) {
+			delete(s.db.data.idempotency, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// setTimestamps sets the unset creation and update times to now, as gorm
+// does on create
+func setTimestamps(createdAt, updatedAt *time.Time) {
+	now := time.Now()
+	if createdAt.IsZero() {
+		*createdAt = now
+	}
+	if updatedAt.IsZero() {
+		*updatedAt = now
+	}
+}
+
+// createdBefore orders records by creation time, then by ID
+func createdBefore(createdAtI, createdAtJ time.Time, idI, idJ uuid.UUID) bool {
+	if !createdAtI.Equal(createdAtJ) {
+		return createdAtI.Before(createdAtJ)
+	}
+	return idI.String() < idJ.String()
+}
+
+func copyProvider(p model.Provider) model.Provider {
+	p.Operations = slices.Clone(p.Operations)
+	p.Labels = maps.Clone(p.Labels)
+	p.ResourceConstraints = maps.Clone(p.ResourceConstraints)
+	p.Annotations = maps.Clone(p.Annotations)
+	return p
+}
+
+func copyCatalogItem(item model.CatalogItem) model.CatalogItem {
+	item.Labels = maps.Clone(item.Labels)
+	item.Annotations = maps.Clone(item.Annotations)
+	item.Template = maps.Clone(item.Template)
+	return item
+}
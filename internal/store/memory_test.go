@@ -0,0 +1,14 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/dcm-project/service-provider-api/internal/store/storetest"
+)
+
+func TestMemoryStore(t *testing.T) {
+	storetest.Test(t, func(t *testing.T) store.Store {
+		return store.NewMemoryStore()
+	})
+}
@@ -11,14 +11,22 @@ import (
 // Examples: "vm-small", "vm-large", "file-storage", "postgresql-ha"
 type CatalogItem struct {
 	gorm.Model
-	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey"`
 	Name         string    `gorm:"name;not null;uniqueIndex"`
 	DisplayName  string    `gorm:"display_name;not null"`
 	Description  string    `gorm:"description"`
 	ResourceKind string    `gorm:"resource_kind;not null;index"`
 	Active       bool      `gorm:"active;not null;default:true"`
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	// Labels are set by the users and returned by the API
+	Labels map[string]string `gorm:"labels;serializer:json"`
+	// Annotations hold internal markers, such as the catalog items managed by
+	// apply. Unlike the labels they are not exposed by the API.
+	Annotations map[string]string `gorm:"annotations;serializer:json"`
+	// Template holds the parameters of the resources created from the item,
+	// such as the cpu, memory and image of a vm
+	Template  map[string]string `gorm:"template;serializer:json"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 // TableName specifies the table name for GORM
@@ -26,11 +34,19 @@ func (CatalogItem) TableName() string {
 	return "catalog_items"
 }
 
+// BeforeCreate generates the ID of a new catalog item
+func (c *CatalogItem) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
 // CatalogProviderMapping represents which services can fulfill which catalog items
 // This is the many-to-many relationship between catalog items and services
 type CatalogProviderMapping struct {
 	gorm.Model
-	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey"`
 	CatalogName  string    `gorm:"catalog_name;not null;index:idx_catalog_service"`
 	ServiceID    string    `gorm:"service_id;not null;index:idx_catalog_service"`
 	ResourceKind string    `gorm:"resource_kind;not null"`
@@ -44,3 +60,20 @@ type CatalogProviderMapping struct {
 func (CatalogProviderMapping) TableName() string {
 	return "catalog_provider_mappings"
 }
+
+// BeforeCreate generates the ID of a new mapping
+func (m *CatalogProviderMapping) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+// CatalogItemProviders is an entry of the catalog view: a catalog item and
+// the providers of its resource kind
+type CatalogItemProviders struct {
+	Name         string
+	DisplayName  string
+	ResourceKind string
+	ProviderIDs  []uuid.UUID
+}
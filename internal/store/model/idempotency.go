@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+)
+
+// IdempotencyRecord stores the response of a request sent with an
+// Idempotency-Key header, so that retries of the request can be replayed
+type IdempotencyRecord struct {
+	Key         string `gorm:"primaryKey"`
+	RequestHash string `gorm:"request_hash;not null"`
+	// StatusCode is zero while the original request is still in progress
+	StatusCode int `gorm:"status_code;not null"`
+	// ResponseHeader holds the headers of the response, e.g. its ETag
+	ResponseHeader map[string][]string `gorm:"response_header;serializer:json"`
+	ResponseBody   []byte              `gorm:"response_body"`
+	CreatedAt      time.Time
+	ExpiresAt      time.Time `gorm:"expires_at;not null;index"`
+}
+
+// TableName specifies the table name for GORM
+func (IdempotencyRecord) TableName() string {
+	return "idempotency_records"
+}
+
+// Completed reports whether the response of the original request is stored
+func (r IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
@@ -1,20 +1,74 @@
 package model
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/google/uuid"
-	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
 type Provider struct {
 	gorm.Model
-	ID           uuid.UUID      `gorm:"primaryKey;"`
-	Name         string         `gorm:"name;not null"`
-	ProviderType string         `gorm:"provider_type;not null"`
-	Description  string         `gorm:"description;not null"`
-	Endpoint     string         `gorm:"endpoint;not null"`
-	ApiHost      string         `gorm:"api_host;not null"`
-	Operations   pq.StringArray `gorm:"operations;type:text[]"`
+	ID           uuid.UUID `gorm:"primaryKey;"`
+	Name         string    `gorm:"name;not null"`
+	ProviderType string    `gorm:"provider_type;not null"`
+	Description  string    `gorm:"description;not null"`
+	Endpoint     string    `gorm:"endpoint;not null"`
+	ApiHost      string    `gorm:"api_host;not null"`
+	// Operations are stored as a JSON array, supported by every database
+	Operations []string `gorm:"operations;serializer:json"`
+	// Registration metadata
+	Zone                string            `gorm:"zone"`
+	Region              string            `gorm:"region"`
+	Labels              map[string]string `gorm:"labels;serializer:json"`
+	ResourceConstraints map[string]string `gorm:"resource_constraints;serializer:json"`
+	// Annotations hold internal markers, such as the providers managed by
+	// apply. Unlike the labels they are not exposed by the API.
+	Annotations map[string]string `gorm:"annotations;serializer:json"`
+	// Version is incremented on every update and used for optimistic concurrency
+	Version int64 `gorm:"version;not null;default:1"`
 }
 
 type ProviderList []Provider
+
+// ETag returns the entity tag of the current version of the provider
+func (p Provider) ETag() string {
+	return FormatETag(p.Version)
+}
+
+// FormatETag formats a resource version as a strong entity tag
+func FormatETag(version int64) string {
+	return fmt.Sprintf("%q", strconv.FormatInt(version, 10))
+}
+
+// ParseETag returns the resource version encoded in an entity tag
+func ParseETag(etag string) (int64, error) {
+	value := strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		unquoted = value
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid etag %q: %w", etag, err)
+	}
+	return version, nil
+}
+
+// MatchETag reports whether an If-Match or If-None-Match header value matches
+// the given entity tag. The wildcard "*" matches any existing entity tag, and
+// weak comparison is used so that W/ prefixed tags are accepted.
+func MatchETag(condition, etag string) bool {
+	if etag == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(condition, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// NotifyChannel is the Postgres channel of the cache invalidations
+const NotifyChannel = "dcm_cache_invalidation"
+
+type notification struct {
+	// Origin identifies the replica which sent the notification, which
+	// ignores its own notifications
+	Origin string `json:"origin"`
+	Cache  string `json:"cache"`
+}
+
+// PGNotifier is the Notifier of the replicas sharing a Postgres database,
+// through LISTEN/NOTIFY
+type PGNotifier struct {
+	db     *gorm.DB
+	origin string
+}
+
+var _ Notifier = (*PGNotifier)(nil)
+
+func NewPGNotifier(db *gorm.DB) *PGNotifier {
+	return &PGNotifier{db: db, origin: uuid.NewString()}
+}
+
+func (n *PGNotifier) Notify(ctx context.Context, cache string) error {
+	payload, err := json.Marshal(notification{Origin: n.origin, Cache: cache})
+	if err != nil {
+		return err
+	}
+	return n.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", NotifyChannel, string(payload)).Error
+}
+
+// Listen holds a connection of the pool to listen to the notifications, and
+// reconnects with an exponential backoff when it is lost. All the caches are
+// flushed on every connection, since notifications are lost in between.
+func (n *PGNotifier) Listen(ctx context.Context, invalidate func(cache string)) error {
+	sqlDB, err := n.db.DB()
+	if err != nil {
+		return err
+	}
+	delay := 500 * time.Millisecond
+	for {
+		conn, err := sqlDB.Conn(ctx)
+		if err == nil {
+			err = conn.Raw(func(driverConn any) error {
+				c, ok := driverConn.(*stdlib.Conn)
+				if !ok {
+					return fmt.Errorf("unsupported driver connection %T", driverConn)
+				}
+				return n.listen(ctx, c.Conn(), invalidate, func() { delay = 500 * time.Millisecond })
+			})
+			_ = conn.Close()
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		zap.S().Named("store").Warnw("Lost the cache invalidation notifications, reconnecting", "delay", delay, "error", err)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+		delay = min(2*delay, maxConnectDelay)
+	}
+}
+
+func (n *PGNotifier) listen(ctx context.Context, conn *pgx.Conn, invalidate func(cache string), connected func()) error {
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{NotifyChannel}.Sanitize()); err != nil {
+		return err
+	}
+	// The connection is released to the pool once the listener returns
+	defer func() {
+		_, _ = conn.Exec(context.Background(), "UNLISTEN *")
+	}()
+	connected()
+	invalidate(CacheProviders)
+	invalidate(CacheCatalog)
+
+	for {
+		pgNotification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		var msg notification
+		if err := json.Unmarshal([]byte(pgNotification.Payload), &msg); err != nil {
+			zap.S().Named("store").Warnw("Ignoring an invalid cache invalidation", "payload", pgNotification.Payload, "error", err)
+			continue
+		}
+		if msg.Origin != n.origin {
+			invalidate(msg.Cache)
+		}
+	}
+}
@@ -13,9 +13,16 @@ type Provider interface {
 	List(ctx context.Context) (model.ProviderList, error)
 	ListByType(ctx context.Context, providerType string) (model.ProviderList, error)
 	Create(ctx context.Context, app model.Provider) (*model.Provider, error)
+	// Delete removes the provider permanently, so that a service can register
+	// again with the same ID
 	Delete(ctx context.Context, id uuid.UUID) error
 	Update(ctx context.Context, app model.Provider) (*model.Provider, error)
 	Get(ctx context.Context, id uuid.UUID) (*model.Provider, error)
+	// Upsert saves the provider as is, including its version and timestamps,
+	// replacing the provider with the same ID
+	Upsert(ctx context.Context, provider model.Provider) error
+	// DeleteAll permanently removes all providers
+	DeleteAll(ctx context.Context) error
 }
 
 type ProviderStore struct {
@@ -30,7 +37,7 @@ func NewProvider(db *gorm.DB) Provider {
 
 func (s *ProviderStore) List(ctx context.Context) (model.ProviderList, error) {
 	var provider model.ProviderList
-	tx := s.db.Model(&provider)
+	tx := s.db.WithContext(ctx).Model(&provider)
 	result := tx.Find(&provider)
 	if result.Error != nil {
 		return nil, result.Error
@@ -39,7 +46,7 @@ func (s *ProviderStore) List(ctx context.Context) (model.ProviderList, error) {
 }
 
 func (s *ProviderStore) Delete(ctx context.Context, id uuid.UUID) error {
-	result := s.db.Delete(&model.Provider{}, id)
+	result := s.db.WithContext(ctx).Unscoped().Delete(&model.Provider{}, id)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -47,7 +54,7 @@ func (s *ProviderStore) Delete(ctx context.Context, id uuid.UUID) error {
 }
 
 func (s *ProviderStore) Create(ctx context.Context, app model.Provider) (*model.Provider, error) {
-	result := s.db.Clauses(clause.Returning{}).Create(&app)
+	result := s.db.WithContext(ctx).Clauses(clause.Returning{}).Create(&app)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -57,26 +64,55 @@ func (s *ProviderStore) Create(ctx context.Context, app model.Provider) (*model.
 
 func (s *ProviderStore) Get(ctx context.Context, id uuid.UUID) (*model.Provider, error) {
 	var provider model.Provider
-	result := s.db.First(&provider, id)
+	result := s.db.WithContext(ctx).First(&provider, id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return &provider, nil
 }
 
+// Update saves the provider only if its Version matches the stored one, and
+// increments the version. ErrVersionConflict is returned on a mismatch.
 func (s *ProviderStore) Update(ctx context.Context, provider model.Provider) (*model.Provider, error) {
-	result := s.db.Clauses(clause.Returning{}).Save(&provider)
+	expectedVersion := provider.Version
+	provider.Version = expectedVersion + 1
+
+	result := s.db.WithContext(ctx).Model(&provider).
+		Clauses(clause.Returning{}).
+		Where("version = ?", expectedVersion).
+		Select("*").
+		Omit("id", "created_at").
+		Updates(&provider)
 	if result.Error != nil {
 		return nil, result.Error
 	}
+	if result.RowsAffected == 0 {
+		if _, err := s.Get(ctx, provider.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrVersionConflict
+	}
 	return &provider, nil
 }
 
 func (s *ProviderStore) ListByType(ctx context.Context, providerType string) (model.ProviderList, error) {
 	var provider model.ProviderList
-	result := s.db.Where("provider_type = ?", providerType).Find(&provider)
+	result := s.db.WithContext(ctx).Where("provider_type = ?", providerType).Find(&provider)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return provider, nil
 }
+
+func (s *ProviderStore) Upsert(ctx context.Context, provider model.Provider) error {
+	return s.db.WithContext(ctx).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Select("*").
+		Create(&provider).Error
+}
+
+func (s *ProviderStore) DeleteAll(ctx context.Context) error {
+	return s.db.WithContext(ctx).Session(&gorm.Session{AllowGlobalUpdate: true}).
+		Unscoped().
+		Delete(&model.Provider{}).Error
+}
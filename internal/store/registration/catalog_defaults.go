@@ -14,6 +14,11 @@ var DefaultCatalogDefinitions = []CatalogDefinition{
 		DisplayName:  "Virtual Machine",
 		Description:  "Standard virtual machine",
 		ResourceKind: "vm",
+		Template: map[string]string{
+			"cpu":    "1",
+			"memory": "2Gi",
+			"image":  "quay.io/containerdisks/fedora:latest",
+		},
 	},
 	{
 		Name:         "container",
@@ -35,4 +40,6 @@ type CatalogDefinition struct {
 	DisplayName  string
 	Description  string
 	ResourceKind string
+	// Template holds the parameters of the resources created from the item
+	Template map[string]string
 }
@@ -26,6 +26,7 @@ func SeedDefaultCatalogItems(s store.Store) error {
 			Description:  def.Description,
 			ResourceKind: def.ResourceKind,
 			Active:       true,
+			Template:     def.Template,
 			CreatedAt:    time.Now(),
 			UpdatedAt:    time.Now(),
 		}
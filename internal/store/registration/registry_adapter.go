@@ -2,14 +2,14 @@ package registration
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/dcm-project/service-provider-api/internal/api/server"
 	"github.com/dcm-project/service-provider-api/internal/store"
 	"github.com/dcm-project/service-provider-api/internal/store/model"
 	"github.com/dcm-project/service-provider-api/pkg/registration"
 	"github.com/google/uuid"
-	"github.com/lib/pq"
-	"gorm.io/gorm"
 )
 
 // RegistrationRegistryAdapter adapts the Store to implement registration.RegistryStore
@@ -23,10 +23,10 @@ func NewRegistrationRegistryAdapter(s store.Store) *RegistrationRegistryAdapter
 }
 
 // UpsertProvider creates or updates a service registration
-func (a *RegistrationRegistryAdapter) UpsertProvider(ctx context.Context, provider registration.RegisteredProvider) error {
+func (a *RegistrationRegistryAdapter) UpsertProvider(ctx context.Context, provider registration.RegisteredProvider) (*registration.RegisteredProvider, error) {
 	serviceUUID, err := uuid.Parse(provider.ServiceID)
 	if err != nil {
-		return fmt.Errorf("invalid service ID: %w", err)
+		return nil, fmt.Errorf("invalid service ID: %w", err)
 	}
 
 	// Check if service exists
@@ -39,18 +39,45 @@ func (a *RegistrationRegistryAdapter) UpsertProvider(ctx context.Context, provid
 		Description:  fmt.Sprintf("Service for %s resources in %s/%s", provider.ResourceKind, provider.Metadata.Region, provider.Metadata.Zone),
 		Endpoint:     provider.Endpoint,
 		ApiHost:      provider.Endpoint,
-		Operations:   pq.StringArray(provider.Operations),
+		Operations:   provider.Operations,
+		Zone:         provider.Metadata.Zone,
+		Region:       provider.Metadata.Region,
+	}
+	if provider.Metadata.Labels != nil {
+		dbProvider.Labels = *provider.Metadata.Labels
+	}
+	if provider.Metadata.ResourceConstraints != nil {
+		dbProvider.ResourceConstraints = *provider.Metadata.ResourceConstraints
 	}
 
+	var stored *model.Provider
 	if err != nil || existing == nil {
 		// Create new service
-		_, err = a.store.Provider().Create(ctx, dbProvider)
-		return err
+		stored, err = a.store.Provider().Create(ctx, dbProvider)
+	} else {
+		// Update existing service, based on the version the caller has seen.
+		// The annotations are not part of a registration.
+		dbProvider.Annotations = existing.Annotations
+		dbProvider.Version = existing.Version
+		if provider.ETag != "" {
+			if dbProvider.Version, err = model.ParseETag(provider.ETag); err != nil {
+				return nil, err
+			}
+		}
+		stored, err = a.store.Provider().Update(ctx, dbProvider)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrVersionConflict):
+			return nil, registration.ErrConflict
+		case errors.Is(err, store.ErrAlreadyExists):
+			return nil, registration.ErrAlreadyRegistered
+		}
+		return nil, err
 	}
 
-	// Update existing service
-	_, err = a.store.Provider().Update(ctx, dbProvider)
-	return err
+	result := toRegisteredProvider(*stored)
+	return &result, nil
 }
 
 // GetProvider retrieves a service by ID and resource kind
@@ -62,7 +89,7 @@ func (a *RegistrationRegistryAdapter) GetProvider(ctx context.Context, serviceID
 
 	dbProvider, err := a.store.Provider().Get(ctx, serviceUUID)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
+		if errors.Is(err, store.ErrNotFound) {
 			return nil, fmt.Errorf("service not found")
 		}
 		return nil, err
@@ -73,15 +100,8 @@ func (a *RegistrationRegistryAdapter) GetProvider(ctx context.Context, serviceID
 		return nil, fmt.Errorf("service not found for resource kind %s", resourceKind)
 	}
 
-	return &registration.RegisteredProvider{
-		ServiceID:    serviceID,
-		ResourceKind: dbProvider.ProviderType,
-		Endpoint:     dbProvider.Endpoint,
-		Operations:   []string(dbProvider.Operations),
-		Status:       "active",
-		RegisteredAt: dbProvider.CreatedAt,
-		UpdatedAt:    dbProvider.UpdatedAt,
-	}, nil
+	provider := toRegisteredProvider(*dbProvider)
+	return &provider, nil
 }
 
 // DeleteProvider removes a service registration
@@ -109,16 +129,50 @@ func (a *RegistrationRegistryAdapter) ListProviders(ctx context.Context, resourc
 
 	providers := make([]registration.RegisteredProvider, 0, len(dbProviders))
 	for _, dbProvider := range dbProviders {
-		providers = append(providers, registration.RegisteredProvider{
-			ServiceID:    dbProvider.ID.String(),
-			ResourceKind: dbProvider.ProviderType,
-			Endpoint:     dbProvider.Endpoint,
-			Operations:   []string(dbProvider.Operations),
-			Status:       "active",
-			RegisteredAt: dbProvider.CreatedAt,
-			UpdatedAt:    dbProvider.UpdatedAt,
-		})
+		providers = append(providers, toRegisteredProvider(dbProvider))
+	}
+
+	return providers, nil
+}
+
+// ListRegisteredProviders lists the services of all the resource kinds of
+// the catalog in a single query, ordered by registration
+func (a *RegistrationRegistryAdapter) ListRegisteredProviders(ctx context.Context) ([]registration.RegisteredProvider, error) {
+	dbProviders, err := a.store.Catalog().ListRegisteredProviders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	providers := make([]registration.RegisteredProvider, 0, len(dbProviders))
+	for _, dbProvider := range dbProviders {
+		providers = append(providers, toRegisteredProvider(dbProvider))
 	}
 
 	return providers, nil
 }
+
+// toRegisteredProvider converts a stored provider into a registration
+func toRegisteredProvider(dbProvider model.Provider) registration.RegisteredProvider {
+	metadata := server.ProviderMetadata{
+		Zone:   dbProvider.Zone,
+		Region: dbProvider.Region,
+	}
+	if len(dbProvider.Labels) > 0 {
+		metadata.Labels = &dbProvider.Labels
+	}
+	if len(dbProvider.ResourceConstraints) > 0 {
+		metadata.ResourceConstraints = &dbProvider.ResourceConstraints
+	}
+
+	return registration.RegisteredProvider{
+		ServiceID:    dbProvider.ID.String(),
+		ResourceKind: dbProvider.ProviderType,
+		Endpoint:     dbProvider.Endpoint,
+		Metadata:     metadata,
+		Operations:   []string(dbProvider.Operations),
+		Status:       "active",
+		RegisteredAt: dbProvider.CreatedAt,
+		UpdatedAt:    dbProvider.UpdatedAt,
+		ETag:         dbProvider.ETag(),
+	}
+}
@@ -1,14 +1,29 @@
 package store
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/dcm-project/service-provider-api/internal/config"
 	"gorm.io/gorm"
 )
 
+// TypeMemory is the database type of the MemoryStore
+const TypeMemory = "memory"
+
 type Store interface {
 	Close() error
+	// Ping checks that the database is reachable
+	Ping(ctx context.Context) error
+	// CheckMigrations checks that the tables of all models exist
+	CheckMigrations(ctx context.Context) error
 	Application() ProviderApplication
 	Provider() Provider
 	Catalog() Catalog
+	Idempotency() Idempotency
+	// WithTx runs fn with a store whose operations are part of a transaction,
+	// committed if fn returns nil and rolled back otherwise
+	WithTx(ctx context.Context, fn func(tx Store) error) error
 }
 
 type DataStore struct {
@@ -16,6 +31,7 @@ type DataStore struct {
 	application ProviderApplication
 	provider    Provider
 	catalog     Catalog
+	idempotency Idempotency
 }
 
 func NewStore(db *gorm.DB) Store {
@@ -24,9 +40,27 @@ func NewStore(db *gorm.DB) Store {
 		application: NewProviderApplication(db),
 		provider:    NewProvider(db),
 		catalog:     NewCatalog(db),
+		idempotency: NewIdempotency(db),
 	}
 }
 
+// Open returns the store of the configured database type
+func Open(ctx context.Context, cfg *config.Config) (Store, error) {
+	if cfg.Database.Type == TypeMemory {
+		return NewMemoryStore(), nil
+	}
+	db, err := InitDB(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(db), nil
+}
+
+// DB returns the database of the store
+func (s *DataStore) DB() *gorm.DB {
+	return s.db
+}
+
 func (s *DataStore) Close() error {
 	sqlDB, err := s.db.DB()
 	if err != nil {
@@ -35,6 +69,24 @@ func (s *DataStore) Close() error {
 	return sqlDB.Close()
 }
 
+func (s *DataStore) Ping(ctx context.Context) error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+func (s *DataStore) CheckMigrations(ctx context.Context) error {
+	migrator := s.db.WithContext(ctx).Migrator()
+	for _, m := range models {
+		if !migrator.HasTable(m) {
+			return fmt.Errorf("table of %T is missing", m)
+		}
+	}
+	return nil
+}
+
 func (s *DataStore) Application() ProviderApplication {
 	return s.application
 }
@@ -46,3 +98,13 @@ func (s *DataStore) Provider() Provider {
 func (s *DataStore) Catalog() Catalog {
 	return s.catalog
 }
+
+func (s *DataStore) Idempotency() Idempotency {
+	return s.idempotency
+}
+
+func (s *DataStore) WithTx(ctx context.Context, fn func(tx Store) error) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewStore(tx))
+	})
+}
@@ -0,0 +1,48 @@
+package storetest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/dcm-project/service-provider-api/internal/store/model"
+)
+
+// Seed registers n providers spread over resourceKinds resource kinds, each
+// with a catalog item and a catalog mapping, to measure the registry and
+// catalog views
+func Seed(tb testing.TB, s store.Store, n, resourceKinds int) {
+	tb.Helper()
+
+	ctx := context.Background()
+	err := s.WithTx(ctx, func(tx store.Store) error {
+		for k := 0; k < resourceKinds; k++ {
+			resourceKind := fmt.Sprintf("kind-%d", k)
+			item := newCatalogItem(resourceKind)
+			if err := tx.Catalog().CreateCatalogItem(ctx, item); err != nil {
+				return err
+			}
+			if err := tx.Catalog().UpsertCatalogMapping(ctx, &model.CatalogProviderMapping{
+				CatalogName:  item.Name,
+				ServiceID:    fmt.Sprintf("service-%d", k),
+				ResourceKind: resourceKind,
+				Endpoint:     "http://localhost:9000/api/" + resourceKind,
+				Active:       true,
+				RegisteredAt: time.Now(),
+			}); err != nil {
+				return err
+			}
+		}
+		for i := 0; i < n; i++ {
+			if _, err := tx.Provider().Create(ctx, newProvider(fmt.Sprintf("kind-%d", i%resourceKinds))); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		tb.Fatalf("seeding %d providers: %v", n, err)
+	}
+}
@@ -0,0 +1,706 @@
+// Package storetest is the contract test suite of the store.Store
+// implementations. It runs against every backend so that the memory store
+// behaves like the database ones, and only checks the records it creates so
+// that it can run against a database in use.
+package storetest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/internal/config"
+	"github.com/dcm-project/service-provider-api/internal/store"
+	"github.com/dcm-project/service-provider-api/internal/store/model"
+	"github.com/google/uuid"
+)
+
+// Open returns the store configured by the environment, the database selected
+// by DB_TYPE, and closes it at the end of the test. DB_TYPE=memory runs the
+// suite without a database.
+func Open(t *testing.T) store.Store {
+	t.Helper()
+
+	cfg, err := config.Load("")
+	if err != nil {
+		t.Fatalf("loading the configuration: %v", err)
+	}
+	s, err := store.Open(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("opening the %s store: %v", cfg.Database.Type, err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+// Test runs the suite, newStore returns the store of each subtest
+func Test(t *testing.T, newStore func(t *testing.T) store.Store) {
+	t.Helper()
+
+	t.Run("Provider", func(t *testing.T) { testProvider(t, newStore(t)) })
+	t.Run("ProviderVersion", func(t *testing.T) { testProviderVersion(t, newStore(t)) })
+	t.Run("ProviderUpsert", func(t *testing.T) { testProviderUpsert(t, newStore(t)) })
+	t.Run("Application", func(t *testing.T) { testApplication(t, newStore(t)) })
+	t.Run("CatalogItem", func(t *testing.T) { testCatalogItem(t, newStore(t)) })
+	t.Run("CatalogItemUpsert", func(t *testing.T) { testCatalogItemUpsert(t, newStore(t)) })
+	t.Run("CatalogMapping", func(t *testing.T) { testCatalogMapping(t, newStore(t)) })
+	t.Run("Idempotency", func(t *testing.T) { testIdempotency(t, newStore(t)) })
+	t.Run("Transaction", func(t *testing.T) { testTransaction(t, newStore(t)) })
+	t.Run("Views", func(t *testing.T) { testViews(t, newStore(t)) })
+}
+
+func newProvider(providerType string) model.Provider {
+	return model.Provider{
+		ID:           uuid.New(),
+		Name:         "provider-" + uuid.NewString()[:8],
+		ProviderType: providerType,
+		Description:  "contract test provider",
+		Endpoint:     "http://localhost:9000/api/" + providerType,
+		ApiHost:      "localhost",
+		Operations:   []string{"CREATE", "DELETE", "READ"},
+		Zone:         "zone-a",
+		Region:       "region-1",
+		Labels:       map[string]string{"suite": "storetest"},
+		Annotations:  map[string]string{"owner": "storetest"},
+	}
+}
+
+func testProvider(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	providerType := "kind-" + uuid.NewString()[:8]
+
+	created, err := s.Provider().Create(ctx, newProvider(providerType))
+	if err != nil {
+		t.Fatalf("creating a provider: %v", err)
+	}
+	if created.Version != 1 {
+		t.Errorf("expected version 1 on creation, got %d", created.Version)
+	}
+	if created.CreatedAt.IsZero() {
+		t.Error("expected the creation time to be set")
+	}
+
+	if _, err := s.Provider().Create(ctx, *created); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists creating a provider twice, got %v", err)
+	}
+
+	got, err := s.Provider().Get(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("getting the provider: %v", err)
+	}
+	if got.Name != created.Name || !slices.Equal(got.Operations, created.Operations) || got.Labels["suite"] != "storetest" ||
+		got.Annotations["owner"] != "storetest" {
+		t.Errorf("expected the created provider, got %+v", got)
+	}
+
+	other, err := s.Provider().Create(ctx, newProvider(providerType))
+	if err != nil {
+		t.Fatalf("creating a provider: %v", err)
+	}
+	byType, err := s.Provider().ListByType(ctx, providerType)
+	if err != nil {
+		t.Fatalf("listing the providers by type: %v", err)
+	}
+	if len(byType) != 2 {
+		t.Errorf("expected 2 providers of type %s, got %d", providerType, len(byType))
+	}
+
+	if err := s.Provider().Delete(ctx, other.ID); err != nil {
+		t.Fatalf("deleting the provider: %v", err)
+	}
+	if _, err := s.Provider().Get(ctx, other.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected ErrNotFound getting a deleted provider, got %v", err)
+	}
+	all, err := s.Provider().List(ctx)
+	if err != nil {
+		t.Fatalf("listing the providers: %v", err)
+	}
+	if containsProvider(all, other.ID) || !containsProvider(all, created.ID) {
+		t.Errorf("expected the list to hold %s and not the deleted %s", created.ID, other.ID)
+	}
+	if _, err := s.Provider().Create(ctx, *created); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists creating a provider with the ID of an existing one, got %v", err)
+	}
+
+	// A service registers again with the same ID after unregistering
+	other.Version = 0
+	other.Description = "registered again"
+	recreated, err := s.Provider().Create(ctx, *other)
+	if err != nil {
+		t.Fatalf("creating a provider with the ID of a deleted one: %v", err)
+	}
+	got, err = s.Provider().Get(ctx, other.ID)
+	if err != nil {
+		t.Fatalf("getting the recreated provider: %v", err)
+	}
+	if got.Description != "registered again" || got.Version != recreated.Version {
+		t.Errorf("expected the recreated provider, got %+v", got)
+	}
+
+	if _, err := s.Provider().Get(ctx, uuid.New()); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected ErrNotFound getting a missing provider, got %v", err)
+	}
+}
+
+func testProviderVersion(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	created, err := s.Provider().Create(ctx, newProvider("kind-"+uuid.NewString()[:8]))
+	if err != nil {
+		t.Fatalf("creating a provider: %v", err)
+	}
+
+	update := *created
+	update.Description = "updated"
+	update.Annotations = map[string]string{"owner": "updated"}
+	updated, err := s.Provider().Update(ctx, update)
+	if err != nil {
+		t.Fatalf("updating the provider: %v", err)
+	}
+	if updated.Version != created.Version+1 || updated.Description != "updated" {
+		t.Errorf("expected version %d with the new description, got %d %q", created.Version+1, updated.Version, updated.Description)
+	}
+	if updated.Annotations["owner"] != "updated" {
+		t.Errorf("expected the updated annotations, got %v", updated.Annotations)
+	}
+
+	// update still holds the version before the update
+	if _, err := s.Provider().Update(ctx, update); !errors.Is(err, store.ErrVersionConflict) {
+		t.Errorf("expected ErrVersionConflict updating a stale version, got %v", err)
+	}
+	got, err := s.Provider().Get(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("getting the provider: %v", err)
+	}
+	if got.Version != updated.Version {
+		t.Errorf("expected version %d after the conflict, got %d", updated.Version, got.Version)
+	}
+
+	missing := newProvider("missing")
+	missing.Version = 1
+	if _, err := s.Provider().Update(ctx, missing); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected ErrNotFound updating a missing provider, got %v", err)
+	}
+}
+
+func testProviderUpsert(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	provider := newProvider("kind-" + uuid.NewString()[:8])
+	provider.Version = 7
+	if err := s.Provider().Upsert(ctx, provider); err != nil {
+		t.Fatalf("upserting a provider: %v", err)
+	}
+	got, err := s.Provider().Get(ctx, provider.ID)
+	if err != nil {
+		t.Fatalf("getting the provider: %v", err)
+	}
+	if got.Version != 7 {
+		t.Errorf("expected the upserted version 7, got %d", got.Version)
+	}
+
+	if err := s.Provider().Delete(ctx, provider.ID); err != nil {
+		t.Fatalf("deleting the provider: %v", err)
+	}
+	provider.Description = "restored"
+	if err := s.Provider().Upsert(ctx, provider); err != nil {
+		t.Fatalf("upserting a deleted provider: %v", err)
+	}
+	got, err = s.Provider().Get(ctx, provider.ID)
+	if err != nil {
+		t.Fatalf("getting the restored provider: %v", err)
+	}
+	if got.Description != "restored" {
+		t.Errorf("expected the restored description, got %q", got.Description)
+	}
+}
+
+func testApplication(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	app := model.ProviderApplication{ID: uuid.New(), ProviderID: uuid.New()}
+	if _, err := s.Application().Create(ctx, app); err != nil {
+		t.Fatalf("creating an application: %v", err)
+	}
+	got, err := s.Application().Get(ctx, app.ID)
+	if err != nil {
+		t.Fatalf("getting the application: %v", err)
+	}
+	if got.ProviderID != app.ProviderID {
+		t.Errorf("expected provider %s, got %s", app.ProviderID, got.ProviderID)
+	}
+
+	if err := s.Application().Delete(ctx, app.ID); err != nil {
+		t.Fatalf("deleting the application: %v", err)
+	}
+	if _, err := s.Application().Get(ctx, app.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected ErrNotFound getting a deleted application, got %v", err)
+	}
+	apps, err := s.Application().List(ctx)
+	if err != nil {
+		t.Fatalf("listing the applications: %v", err)
+	}
+	for _, a := range apps {
+		if a.ID == app.ID {
+			t.Errorf("expected the deleted application %s not to be listed", app.ID)
+		}
+	}
+}
+
+func newCatalogItem(resourceKind string) *model.CatalogItem {
+	return &model.CatalogItem{
+		Name:         "item-" + uuid.NewString()[:8],
+		DisplayName:  "Contract test item",
+		ResourceKind: resourceKind,
+		Active:       true,
+		Labels:       map[string]string{"suite": "storetest"},
+		Annotations:  map[string]string{"owner": "storetest"},
+		Template:     map[string]string{"cpu": "2"},
+	}
+}
+
+func testCatalogItem(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	resourceKind := "kind-" + uuid.NewString()[:8]
+
+	item := newCatalogItem(resourceKind)
+	if err := s.Catalog().CreateCatalogItem(ctx, item); err != nil {
+		t.Fatalf("creating a catalog item: %v", err)
+	}
+	if item.ID == uuid.Nil {
+		t.Error("expected the ID of the catalog item to be set")
+	}
+
+	duplicate := newCatalogItem(resourceKind)
+	duplicate.Name = item.Name
+	if err := s.Catalog().CreateCatalogItem(ctx, duplicate); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists creating a catalog item with a used name, got %v", err)
+	}
+
+	got, err := s.Catalog().GetCatalogItem(ctx, item.Name)
+	if err != nil {
+		t.Fatalf("getting the catalog item: %v", err)
+	}
+	if got.ID != item.ID || got.Template["cpu"] != "2" || got.Annotations["owner"] != "storetest" {
+		t.Errorf("expected the created catalog item, got %+v", got)
+	}
+
+	update := *got
+	update.Active = false
+	update.Template = map[string]string{"cpu": "4"}
+	update.Annotations = map[string]string{"owner": "updated"}
+	if err := s.Catalog().UpdateCatalogItem(ctx, &update); err != nil {
+		t.Fatalf("updating the catalog item: %v", err)
+	}
+	inactive, err := s.Catalog().ListCatalogItems(ctx, false)
+	if err != nil {
+		t.Fatalf("listing the inactive catalog items: %v", err)
+	}
+	found := findCatalogItem(inactive, item.Name)
+	if found == nil || found.Template["cpu"] != "4" || found.Annotations["owner"] != "updated" {
+		t.Errorf("expected the updated catalog item to be listed as inactive, got %+v", found)
+	}
+	active, err := s.Catalog().ListCatalogItems(ctx, true)
+	if err != nil {
+		t.Fatalf("listing the active catalog items: %v", err)
+	}
+	if findCatalogItem(active, item.Name) != nil {
+		t.Error("expected the inactive catalog item not to be listed as active")
+	}
+
+	missing := newCatalogItem(resourceKind)
+	if err := s.Catalog().UpdateCatalogItem(ctx, missing); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected ErrNotFound updating a missing catalog item, got %v", err)
+	}
+
+	if err := s.Catalog().DeleteCatalogItem(ctx, item.Name); err != nil {
+		t.Fatalf("deleting the catalog item: %v", err)
+	}
+	if _, err := s.Catalog().GetCatalogItem(ctx, item.Name); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected ErrNotFound getting a deleted catalog item, got %v", err)
+	}
+	if err := s.Catalog().DeleteCatalogItem(ctx, item.Name); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected ErrNotFound deleting a missing catalog item, got %v", err)
+	}
+
+	// The name of a deleted catalog item can be reused
+	reused := newCatalogItem(resourceKind)
+	reused.Name = item.Name
+	if err := s.Catalog().CreateCatalogItem(ctx, reused); err != nil {
+		t.Errorf("expected the name of a deleted catalog item to be reusable, got %v", err)
+	}
+	_ = s.Catalog().DeleteCatalogItem(ctx, reused.Name)
+}
+
+func testCatalogItemUpsert(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	item := newCatalogItem("kind-" + uuid.NewString()[:8])
+	if err := s.Catalog().CreateCatalogItem(ctx, item); err != nil {
+		t.Fatalf("creating a catalog item: %v", err)
+	}
+	defer func() { _ = s.Catalog().DeleteCatalogItem(ctx, item.Name) }()
+
+	upsert := newCatalogItem(item.ResourceKind)
+	upsert.ID = item.ID
+	upsert.Name = item.Name
+	upsert.DisplayName = "Upserted"
+	if err := s.Catalog().UpsertCatalogItem(ctx, upsert); err != nil {
+		t.Fatalf("upserting the catalog item: %v", err)
+	}
+	got, err := s.Catalog().GetCatalogItem(ctx, item.Name)
+	if err != nil {
+		t.Fatalf("getting the catalog item: %v", err)
+	}
+	if got.ID != item.ID || got.DisplayName != "Upserted" {
+		t.Errorf("expected the upserted catalog item with ID %s, got %s %q", item.ID, got.ID, got.DisplayName)
+	}
+
+	all, err := s.Catalog().ListAllCatalogItems(ctx)
+	if err != nil {
+		t.Fatalf("listing the catalog items: %v", err)
+	}
+	n := 0
+	for _, i := range all {
+		if i.Name == item.Name {
+			n++
+		}
+	}
+	if n != 1 {
+		t.Errorf("expected a single catalog item named %s, got %d", item.Name, n)
+	}
+}
+
+func testCatalogMapping(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	catalogName := "item-" + uuid.NewString()[:8]
+	resourceKind := "kind-" + uuid.NewString()[:8]
+	serviceIDs := []string{uuid.NewString(), uuid.NewString()}
+	slices.Sort(serviceIDs)
+
+	for _, serviceID := range []string{serviceIDs[1], serviceIDs[0]} {
+		mapping := &model.CatalogProviderMapping{
+			CatalogName:  catalogName,
+			ServiceID:    serviceID,
+			ResourceKind: resourceKind,
+			Endpoint:     "http://localhost:9000/v1",
+			Active:       true,
+			RegisteredAt: time.Now(),
+		}
+		if err := s.Catalog().UpsertCatalogMapping(ctx, mapping); err != nil {
+			t.Fatalf("creating a catalog mapping: %v", err)
+		}
+	}
+
+	// Upserting the same catalog item, service and resource kind updates the
+	// mapping
+	mapping := &model.CatalogProviderMapping{
+		CatalogName:  catalogName,
+		ServiceID:    serviceIDs[0],
+		ResourceKind: resourceKind,
+		Endpoint:     "http://localhost:9000/v2",
+		Active:       true,
+		RegisteredAt: time.Now(),
+	}
+	if err := s.Catalog().UpsertCatalogMapping(ctx, mapping); err != nil {
+		t.Fatalf("updating the catalog mapping: %v", err)
+	}
+	if mapping.ID == uuid.Nil {
+		t.Error("expected the ID of the updated mapping to be set")
+	}
+
+	mappings, err := s.Catalog().GetCatalogMappings(ctx, catalogName, true)
+	if err != nil {
+		t.Fatalf("getting the catalog mappings: %v", err)
+	}
+	if len(mappings) != 2 {
+		t.Fatalf("expected 2 mappings of %s, got %d", catalogName, len(mappings))
+	}
+	for _, m := range mappings {
+		if m.ServiceID == serviceIDs[0] && m.Endpoint != "http://localhost:9000/v2" {
+			t.Errorf("expected the updated endpoint, got %s", m.Endpoint)
+		}
+	}
+
+	all, err := s.Catalog().ListAllCatalogMappings(ctx, true)
+	if err != nil {
+		t.Fatalf("listing the catalog mappings: %v", err)
+	}
+	var listed []string
+	for _, m := range all {
+		if m.CatalogName == catalogName {
+			listed = append(listed, m.ServiceID)
+		}
+	}
+	if !slices.Equal(listed, serviceIDs) {
+		t.Errorf("expected the mappings ordered by service ID %v, got %v", serviceIDs, listed)
+	}
+
+	kinds, err := s.Catalog().GetDistinctResourceKinds(ctx)
+	if err != nil {
+		t.Fatalf("getting the resource kinds: %v", err)
+	}
+	if n := countString(kinds, resourceKind); n != 1 {
+		t.Errorf("expected resource kind %s once, got %d times", resourceKind, n)
+	}
+
+	if err := s.Catalog().DeactivateMappings(ctx, serviceIDs[0], resourceKind); err != nil {
+		t.Fatalf("deactivating the mappings: %v", err)
+	}
+	inactive, err := s.Catalog().GetCatalogMappings(ctx, catalogName, false)
+	if err != nil {
+		t.Fatalf("getting the inactive catalog mappings: %v", err)
+	}
+	if len(inactive) != 1 || inactive[0].ServiceID != serviceIDs[0] {
+		t.Errorf("expected the mapping of %s to be inactive, got %+v", serviceIDs[0], inactive)
+	}
+}
+
+func testIdempotency(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	key := uuid.NewString()
+
+	record := model.IdempotencyRecord{Key: key, RequestHash: "hash", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := s.Idempotency().Create(ctx, record); err != nil {
+		t.Fatalf("creating an idempotency record: %v", err)
+	}
+	if err := s.Idempotency().Create(ctx, record); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists claiming a key twice, got %v", err)
+	}
+
+	got, err := s.Idempotency().Get(ctx, key)
+	if err != nil {
+		t.Fatalf("getting the idempotency record: %v", err)
+	}
+	if got.Completed() || got.RequestHash != "hash" {
+		t.Errorf("expected the record in progress, got %+v", got)
+	}
+
+	header := map[string][]string{"Content-Type": {"application/json"}, "Etag": {`"1"`}}
+	if err := s.Idempotency().Complete(ctx, key, 201, header, []byte(`{}`)); err != nil {
+		t.Fatalf("completing the idempotency record: %v", err)
+	}
+	got, err = s.Idempotency().Get(ctx, key)
+	if err != nil {
+		t.Fatalf("getting the idempotency record: %v", err)
+	}
+	if !got.Completed() || got.StatusCode != 201 || string(got.ResponseBody) != `{}` {
+		t.Errorf("expected the completed record, got %+v", got)
+	}
+	if !reflect.DeepEqual(got.ResponseHeader, header) {
+		t.Errorf("expected the response header %v, got %v", header, got.ResponseHeader)
+	}
+	if err := s.Idempotency().Create(ctx, record); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists claiming a completed key, got %v", err)
+	}
+
+	if err := s.Idempotency().Delete(ctx, key); err != nil {
+		t.Fatalf("deleting the idempotency record: %v", err)
+	}
+	if _, err := s.Idempotency().Get(ctx, key); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected ErrNotFound getting a deleted record, got %v", err)
+	}
+
+	// An expired record is not returned and can be claimed again
+	expired := model.IdempotencyRecord{Key: key, RequestHash: "hash", ExpiresAt: time.Now().Add(-time.Minute)}
+	if err := s.Idempotency().Create(ctx, expired); err != nil {
+		t.Fatalf("creating an expired idempotency record: %v", err)
+	}
+	if _, err := s.Idempotency().Get(ctx, key); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected ErrNotFound getting an expired record, got %v", err)
+	}
+	if err := s.Idempotency().Create(ctx, record); err != nil {
+		t.Errorf("expected an expired key to be claimed again, got %v", err)
+	}
+
+	expired.Key = uuid.NewString()
+	if err := s.Idempotency().Create(ctx, expired); err != nil {
+		t.Fatalf("creating an expired idempotency record: %v", err)
+	}
+	deleted, err := s.Idempotency().DeleteExpired(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("deleting the expired records: %v", err)
+	}
+	if deleted < 1 {
+		t.Errorf("expected the expired record to be deleted, got %d deleted", deleted)
+	}
+	if _, err := s.Idempotency().Get(ctx, key); err != nil {
+		t.Errorf("expected the unexpired record to be kept, got %v", err)
+	}
+	_ = s.Idempotency().Delete(ctx, key)
+}
+
+func testTransaction(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	rolledBack := newProvider("kind-" + uuid.NewString()[:8])
+	errRollback := errors.New("rollback")
+	err := s.WithTx(ctx, func(tx store.Store) error {
+		if _, err := tx.Provider().Create(ctx, rolledBack); err != nil {
+			return err
+		}
+		if _, err := tx.Provider().Get(ctx, rolledBack.ID); err != nil {
+			t.Errorf("expected the provider to be visible in the transaction, got %v", err)
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("expected the error of the transaction, got %v", err)
+	}
+	if _, err := s.Provider().Get(ctx, rolledBack.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected the provider of a rolled back transaction not to be saved, got %v", err)
+	}
+
+	committed := newProvider("kind-" + uuid.NewString()[:8])
+	item := newCatalogItem(committed.ProviderType)
+	err = s.WithTx(ctx, func(tx store.Store) error {
+		if _, err := tx.Provider().Create(ctx, committed); err != nil {
+			return err
+		}
+		return tx.Catalog().CreateCatalogItem(ctx, item)
+	})
+	if err != nil {
+		t.Fatalf("running the transaction: %v", err)
+	}
+	defer func() { _ = s.Catalog().DeleteCatalogItem(ctx, item.Name) }()
+	if _, err := s.Provider().Get(ctx, committed.ID); err != nil {
+		t.Errorf("expected the provider of a committed transaction to be saved, got %v", err)
+	}
+	if _, err := s.Catalog().GetCatalogItem(ctx, item.Name); err != nil {
+		t.Errorf("expected the catalog item of a committed transaction to be saved, got %v", err)
+	}
+}
+
+func testViews(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	resourceKind := "kind-" + uuid.NewString()[:8]
+	unmappedKind := "kind-" + uuid.NewString()[:8]
+
+	// The providers are saved with their registration time, which orders
+	// the views
+	var providers []model.Provider
+	for i, providerType := range []string{resourceKind, resourceKind, resourceKind, unmappedKind} {
+		provider := newProvider(providerType)
+		provider.Version = 1
+		provider.CreatedAt = time.Now().Add(time.Duration(i-10) * time.Minute)
+		provider.UpdatedAt = provider.CreatedAt
+		if err := s.Provider().Upsert(ctx, provider); err != nil {
+			t.Fatalf("saving a provider: %v", err)
+		}
+		providers = append(providers, provider)
+	}
+	if err := s.Provider().Delete(ctx, providers[2].ID); err != nil {
+		t.Fatalf("deleting the provider: %v", err)
+	}
+	mapping := &model.CatalogProviderMapping{
+		CatalogName:  "item-" + uuid.NewString()[:8],
+		ServiceID:    providers[0].ID.String(),
+		ResourceKind: resourceKind,
+		Endpoint:     providers[0].Endpoint,
+		Active:       true,
+		RegisteredAt: time.Now(),
+	}
+	if err := s.Catalog().UpsertCatalogMapping(ctx, mapping); err != nil {
+		t.Fatalf("creating a catalog mapping: %v", err)
+	}
+	item := newCatalogItem(resourceKind)
+	item.Name = "a-" + item.Name
+	empty := newCatalogItem(unmappedKind + "-none")
+	empty.Name = "b-" + empty.Name
+	for _, i := range []*model.CatalogItem{empty, item} {
+		if err := s.Catalog().CreateCatalogItem(ctx, i); err != nil {
+			t.Fatalf("creating a catalog item: %v", err)
+		}
+	}
+
+	registered, err := s.Catalog().ListRegisteredProviders(ctx)
+	if err != nil {
+		t.Fatalf("listing the registered providers: %v", err)
+	}
+	var got []uuid.UUID
+	for _, p := range registered {
+		if p.ProviderType == resourceKind || p.ProviderType == unmappedKind {
+			got = append(got, p.ID)
+		}
+	}
+	if want := []uuid.UUID{providers[0].ID, providers[1].ID}; !slices.Equal(got, want) {
+		t.Errorf("expected the registered providers %v, got %v", want, got)
+	}
+	if i := slices.IndexFunc(registered, func(p model.Provider) bool { return p.ID == providers[0].ID }); i >= 0 &&
+		!slices.Equal(registered[i].Operations, providers[0].Operations) {
+		t.Errorf("expected the operations %v, got %v", providers[0].Operations, registered[i].Operations)
+	}
+
+	view, err := s.Catalog().ListCatalogProviders(ctx)
+	if err != nil {
+		t.Fatalf("listing the catalog providers: %v", err)
+	}
+	itemIndex := slices.IndexFunc(view, func(i model.CatalogItemProviders) bool { return i.Name == item.Name })
+	emptyIndex := slices.IndexFunc(view, func(i model.CatalogItemProviders) bool { return i.Name == empty.Name })
+	if itemIndex < 0 || emptyIndex < 0 {
+		t.Fatalf("expected the catalog items %s and %s in the view, got %+v", item.Name, empty.Name, view)
+	}
+	if itemIndex > emptyIndex {
+		t.Errorf("expected the catalog items ordered by name")
+	}
+	if want := []uuid.UUID{providers[0].ID, providers[1].ID}; !slices.Equal(view[itemIndex].ProviderIDs, want) {
+		t.Errorf("expected the providers %v of %s, got %v", want, item.Name, view[itemIndex].ProviderIDs)
+	}
+	if view[itemIndex].DisplayName != item.DisplayName || view[itemIndex].ResourceKind != resourceKind {
+		t.Errorf("expected the catalog item %+v, got %+v", item, view[itemIndex])
+	}
+	if view[emptyIndex].ProviderIDs == nil || len(view[emptyIndex].ProviderIDs) != 0 {
+		t.Errorf("expected no providers for %s, got %v", empty.Name, view[emptyIndex].ProviderIDs)
+	}
+
+	// The views follow the writes
+	if err := s.Provider().Delete(ctx, providers[1].ID); err != nil {
+		t.Fatalf("deleting the provider: %v", err)
+	}
+	registered, err = s.Catalog().ListRegisteredProviders(ctx)
+	if err != nil {
+		t.Fatalf("listing the registered providers: %v", err)
+	}
+	if containsProvider(registered, providers[1].ID) {
+		t.Errorf("expected the deleted provider %s not to be registered", providers[1].ID)
+	}
+	view, err = s.Catalog().ListCatalogProviders(ctx)
+	if err != nil {
+		t.Fatalf("listing the catalog providers: %v", err)
+	}
+	if i := slices.IndexFunc(view, func(i model.CatalogItemProviders) bool { return i.Name == item.Name }); i < 0 ||
+		!slices.Equal(view[i].ProviderIDs, []uuid.UUID{providers[0].ID}) {
+		t.Errorf("expected the provider %s of %s after the deletion", providers[0].ID, item.Name)
+	}
+}
+
+func containsProvider(providers model.ProviderList, id uuid.UUID) bool {
+	for _, p := range providers {
+		if p.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func findCatalogItem(items []model.CatalogItem, name string) *model.CatalogItem {
+	for i := range items {
+		if items[i].Name == name {
+			return &items[i]
+		}
+	}
+	return nil
+}
+
+func countString(values []string, value string) int {
+	n := 0
+	for _, v := range values {
+		if v == value {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,100 @@
+package tracing
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	gormSpanKey = "tracing:span"
+	callbackPfx = "tracing:"
+)
+
+// GormPlugin creates a span for every gorm operation, as a child of the span
+// in the context passed with db.WithContext. Statements are recorded with
+// placeholders, query parameters are never recorded.
+type GormPlugin struct {
+	system string
+}
+
+var _ gorm.Plugin = (*GormPlugin)(nil)
+
+// NewGormPlugin returns a plugin tracing queries to a database of the given
+// type, e.g. "postgresql"
+func NewGormPlugin(system string) *GormPlugin {
+	return &GormPlugin{system: system}
+}
+
+func (p *GormPlugin) Name() string {
+	return "tracing"
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	registrations := []struct {
+		name     string
+		before   func(string, func(*gorm.DB)) error
+		after    func(string, func(*gorm.DB)) error
+		spanName string
+	}{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register, "gorm.Create"},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register, "gorm.Query"},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register, "gorm.Update"},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register, "gorm.Delete"},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register, "gorm.Row"},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register, "gorm.Raw"},
+	}
+	for _, r := range registrations {
+		if err := r.before(callbackPfx+"before_"+r.name, p.before(r.spanName)); err != nil {
+			return err
+		}
+		if err := r.after(callbackPfx+"after_"+r.name, p.after); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *GormPlugin) before(spanName string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement.Context == nil {
+			return
+		}
+		_, span := Tracer().Start(db.Statement.Context, spanName,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemKey.String(p.system)),
+		)
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func (p *GormPlugin) after(db *gorm.DB) {
+	value, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	attrs := []attribute.KeyValue{
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	}
+	if db.Statement.Table != "" {
+		attrs = append(attrs, semconv.DBCollectionName(db.Statement.Table))
+	}
+	span.SetAttributes(attrs...)
+
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -0,0 +1,116 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ServiceName = "service-provider-api"
+
+	instrumentationName = "github.com/dcm-project/service-provider-api"
+)
+
+// Options configure the exporter of the spans
+type Options struct {
+	Enabled bool
+	// Endpoint is the URL of an OTLP/HTTP collector. When empty the standard
+	// OTEL_EXPORTER_OTLP_* variables are used if set, otherwise spans are
+	// written to File, or to stdout when File is empty.
+	Endpoint    string
+	File        string
+	SampleRatio float64
+}
+
+// Init installs the global tracer provider and the W3C trace context
+// propagator. The returned function flushes and stops the exporter.
+func Init(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	if !opts.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, closeOutput, err := newExporter(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(ServiceName),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closeErr := closeOutput(); err == nil {
+			err = closeErr
+		}
+		return err
+	}, nil
+}
+
+func newExporter(ctx context.Context, opts Options) (sdktrace.SpanExporter, func() error, error) {
+	noClose := func() error { return nil }
+
+	if opts.Endpoint != "" {
+		exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(opts.Endpoint))
+		return exporter, noClose, err
+	}
+	if collectorConfigured() {
+		exporter, err := otlptracehttp.New(ctx)
+		return exporter, noClose, err
+	}
+
+	var out io.Writer = os.Stdout
+	closeOutput := noClose
+	if opts.File != "" {
+		f, err := os.OpenFile(opts.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("opening trace file: %w", err)
+		}
+		out = f
+		closeOutput = f.Close
+	}
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(out))
+	return exporter, closeOutput, err
+}
+
+// collectorConfigured reports whether the OTLP exporter is configured with
+// the standard environment variables
+func collectorConfigured() bool {
+	for _, key := range []string{"OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"} {
+		if os.Getenv(key) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Tracer returns the tracer of the service
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
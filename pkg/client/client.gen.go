@@ -91,51 +91,80 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// ApplyManifestsWithBody request with any body
+	ApplyManifestsWithBody(ctx context.Context, params *ApplyManifestsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ApplyManifests(ctx context.Context, params *ApplyManifestsParams, body ApplyManifestsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetCatalog request
 	GetCatalog(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetLogLevel request
+	GetLogLevel(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetLogLevelWithBody request with any body
+	SetLogLevelWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetLogLevel(ctx context.Context, body SetLogLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetRegistry request
 	GetRegistry(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ListHealth request
 	ListHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetLiveness request
+	GetLiveness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ListProviders request
 	ListProviders(ctx context.Context, params *ListProvidersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// CreateProviderWithBody request with any body
-	CreateProviderWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+	CreateProviderWithBody(ctx context.Context, params *CreateProviderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	CreateProvider(ctx context.Context, body CreateProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	CreateProvider(ctx context.Context, params *CreateProviderParams, body CreateProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// DeleteProvider request
 	DeleteProvider(ctx context.Context, providerId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetProvider request
-	GetProvider(ctx context.Context, providerId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+	GetProvider(ctx context.Context, providerId openapi_types.UUID, params *GetProviderParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchProviderWithBody request with any body
+	PatchProviderWithBody(ctx context.Context, providerId openapi_types.UUID, params *PatchProviderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchProviderWithApplicationMergePatchPlusJSONBody(ctx context.Context, providerId openapi_types.UUID, params *PatchProviderParams, body PatchProviderApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ApplyProviderWithBody request with any body
-	ApplyProviderWithBody(ctx context.Context, providerId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+	ApplyProviderWithBody(ctx context.Context, providerId openapi_types.UUID, params *ApplyProviderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	ApplyProvider(ctx context.Context, providerId openapi_types.UUID, body ApplyProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	ApplyProvider(ctx context.Context, providerId openapi_types.UUID, params *ApplyProviderParams, body ApplyProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetReadiness request
+	GetReadiness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ListRegisteredProviders request
 	ListRegisteredProviders(ctx context.Context, resourceKind string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// RegisterProviderWithBody request with any body
-	RegisterProviderWithBody(ctx context.Context, resourceKind string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+	RegisterProviderWithBody(ctx context.Context, resourceKind string, params *RegisterProviderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	RegisterProvider(ctx context.Context, resourceKind string, body RegisterProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	RegisterProvider(ctx context.Context, resourceKind string, params *RegisterProviderParams, body RegisterProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// UnregisterProvider request
 	UnregisterProvider(ctx context.Context, resourceKind string, providerId string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetRegisteredProvider request
-	GetRegisteredProvider(ctx context.Context, resourceKind string, providerId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+	GetRegisteredProvider(ctx context.Context, resourceKind string, providerId string, params *GetRegisteredProviderParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchRegisteredProviderWithBody request with any body
+	PatchRegisteredProviderWithBody(ctx context.Context, resourceKind string, providerId string, params *PatchRegisteredProviderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchRegisteredProviderWithApplicationMergePatchPlusJSONBody(ctx context.Context, resourceKind string, providerId string, params *PatchRegisteredProviderParams, body PatchRegisteredProviderApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
-func (c *Client) GetCatalog(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetCatalogRequest(c.Server)
+func (c *Client) ApplyManifestsWithBody(ctx context.Context, params *ApplyManifestsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewApplyManifestsRequestWithBody(c.Server, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -146,8 +175,8 @@ func (c *Client) GetCatalog(ctx context.Context, reqEditors ...RequestEditorFn)
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetRegistry(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetRegistryRequest(c.Server)
+func (c *Client) ApplyManifests(ctx context.Context, params *ApplyManifestsParams, body ApplyManifestsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewApplyManifestsRequest(c.Server, params, body)
 	if err != nil {
 		return nil, err
 	}
@@ -158,8 +187,8 @@ func (c *Client) GetRegistry(ctx context.Context, reqEditors ...RequestEditorFn)
 	return c.Client.Do(req)
 }
 
-func (c *Client) ListHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewListHealthRequest(c.Server)
+func (c *Client) GetCatalog(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCatalogRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -170,8 +199,8 @@ func (c *Client) ListHealth(ctx context.Context, reqEditors ...RequestEditorFn)
 	return c.Client.Do(req)
 }
 
-func (c *Client) ListProviders(ctx context.Context, params *ListProvidersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewListProvidersRequest(c.Server, params)
+func (c *Client) GetLogLevel(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLogLevelRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -182,8 +211,8 @@ func (c *Client) ListProviders(ctx context.Context, params *ListProvidersParams,
 	return c.Client.Do(req)
 }
 
-func (c *Client) CreateProviderWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewCreateProviderRequestWithBody(c.Server, contentType, body)
+func (c *Client) SetLogLevelWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetLogLevelRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -194,8 +223,8 @@ func (c *Client) CreateProviderWithBody(ctx context.Context, contentType string,
 	return c.Client.Do(req)
 }
 
-func (c *Client) CreateProvider(ctx context.Context, body CreateProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewCreateProviderRequest(c.Server, body)
+func (c *Client) SetLogLevel(ctx context.Context, body SetLogLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetLogLevelRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -206,8 +235,8 @@ func (c *Client) CreateProvider(ctx context.Context, body CreateProviderJSONRequ
 	return c.Client.Do(req)
 }
 
-func (c *Client) DeleteProvider(ctx context.Context, providerId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDeleteProviderRequest(c.Server, providerId)
+func (c *Client) GetRegistry(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRegistryRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -218,8 +247,8 @@ func (c *Client) DeleteProvider(ctx context.Context, providerId openapi_types.UU
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetProvider(ctx context.Context, providerId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetProviderRequest(c.Server, providerId)
+func (c *Client) ListHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListHealthRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -230,8 +259,8 @@ func (c *Client) GetProvider(ctx context.Context, providerId openapi_types.UUID,
 	return c.Client.Do(req)
 }
 
-func (c *Client) ApplyProviderWithBody(ctx context.Context, providerId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewApplyProviderRequestWithBody(c.Server, providerId, contentType, body)
+func (c *Client) GetLiveness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLivenessRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -242,8 +271,8 @@ func (c *Client) ApplyProviderWithBody(ctx context.Context, providerId openapi_t
 	return c.Client.Do(req)
 }
 
-func (c *Client) ApplyProvider(ctx context.Context, providerId openapi_types.UUID, body ApplyProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewApplyProviderRequest(c.Server, providerId, body)
+func (c *Client) ListProviders(ctx context.Context, params *ListProvidersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListProvidersRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -254,8 +283,8 @@ func (c *Client) ApplyProvider(ctx context.Context, providerId openapi_types.UUI
 	return c.Client.Do(req)
 }
 
-func (c *Client) ListRegisteredProviders(ctx context.Context, resourceKind string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewListRegisteredProvidersRequest(c.Server, resourceKind)
+func (c *Client) CreateProviderWithBody(ctx context.Context, params *CreateProviderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateProviderRequestWithBody(c.Server, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -266,8 +295,8 @@ func (c *Client) ListRegisteredProviders(ctx context.Context, resourceKind strin
 	return c.Client.Do(req)
 }
 
-func (c *Client) RegisterProviderWithBody(ctx context.Context, resourceKind string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewRegisterProviderRequestWithBody(c.Server, resourceKind, contentType, body)
+func (c *Client) CreateProvider(ctx context.Context, params *CreateProviderParams, body CreateProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateProviderRequest(c.Server, params, body)
 	if err != nil {
 		return nil, err
 	}
@@ -278,8 +307,8 @@ func (c *Client) RegisterProviderWithBody(ctx context.Context, resourceKind stri
 	return c.Client.Do(req)
 }
 
-func (c *Client) RegisterProvider(ctx context.Context, resourceKind string, body RegisterProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewRegisterProviderRequest(c.Server, resourceKind, body)
+func (c *Client) DeleteProvider(ctx context.Context, providerId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteProviderRequest(c.Server, providerId)
 	if err != nil {
 		return nil, err
 	}
@@ -290,8 +319,8 @@ func (c *Client) RegisterProvider(ctx context.Context, resourceKind string, body
 	return c.Client.Do(req)
 }
 
-func (c *Client) UnregisterProvider(ctx context.Context, resourceKind string, providerId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewUnregisterProviderRequest(c.Server, resourceKind, providerId)
+func (c *Client) GetProvider(ctx context.Context, providerId openapi_types.UUID, params *GetProviderParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetProviderRequest(c.Server, providerId, params)
 	if err != nil {
 		return nil, err
 	}
@@ -302,8 +331,8 @@ func (c *Client) UnregisterProvider(ctx context.Context, resourceKind string, pr
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetRegisteredProvider(ctx context.Context, resourceKind string, providerId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetRegisteredProviderRequest(c.Server, resourceKind, providerId)
+func (c *Client) PatchProviderWithBody(ctx context.Context, providerId openapi_types.UUID, params *PatchProviderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchProviderRequestWithBody(c.Server, providerId, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -314,89 +343,151 @@ func (c *Client) GetRegisteredProvider(ctx context.Context, resourceKind string,
 	return c.Client.Do(req)
 }
 
-// NewGetCatalogRequest generates requests for GetCatalog
-func NewGetCatalogRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) PatchProviderWithApplicationMergePatchPlusJSONBody(ctx context.Context, providerId openapi_types.UUID, params *PatchProviderParams, body PatchProviderApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchProviderRequestWithApplicationMergePatchPlusJSONBody(c.Server, providerId, params, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/admin/catalog")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) ApplyProviderWithBody(ctx context.Context, providerId openapi_types.UUID, params *ApplyProviderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewApplyProviderRequestWithBody(c.Server, providerId, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) ApplyProvider(ctx context.Context, providerId openapi_types.UUID, params *ApplyProviderParams, body ApplyProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewApplyProviderRequest(c.Server, providerId, params, body)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewGetRegistryRequest generates requests for GetRegistry
-func NewGetRegistryRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) GetReadiness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetReadinessRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/admin/registry")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) ListRegisteredProviders(ctx context.Context, resourceKind string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListRegisteredProvidersRequest(c.Server, resourceKind)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) RegisterProviderWithBody(ctx context.Context, resourceKind string, params *RegisterProviderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterProviderRequestWithBody(c.Server, resourceKind, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewListHealthRequest generates requests for ListHealth
-func NewListHealthRequest(server string) (*http.Request, error) {
-	var err error
+func (c *Client) RegisterProvider(ctx context.Context, resourceKind string, params *RegisterProviderParams, body RegisterProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterProviderRequest(c.Server, resourceKind, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) UnregisterProvider(ctx context.Context, resourceKind string, providerId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnregisterProviderRequest(c.Server, resourceKind, providerId)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	operationPath := fmt.Sprintf("/health")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+func (c *Client) GetRegisteredProvider(ctx context.Context, resourceKind string, providerId string, params *GetRegisteredProviderParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRegisteredProviderRequest(c.Server, resourceKind, providerId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PatchRegisteredProviderWithBody(ctx context.Context, resourceKind string, providerId string, params *PatchRegisteredProviderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchRegisteredProviderRequestWithBody(c.Server, resourceKind, providerId, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) PatchRegisteredProviderWithApplicationMergePatchPlusJSONBody(ctx context.Context, resourceKind string, providerId string, params *PatchRegisteredProviderParams, body PatchRegisteredProviderApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchRegisteredProviderRequestWithApplicationMergePatchPlusJSONBody(c.Server, resourceKind, providerId, params, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	return req, nil
+// NewApplyManifestsRequest calls the generic ApplyManifests builder with application/json body
+func NewApplyManifestsRequest(server string, params *ApplyManifestsParams, body ApplyManifestsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewApplyManifestsRequestWithBody(server, params, "application/json", bodyReader)
 }
 
-// NewListProvidersRequest generates requests for ListProviders
-func NewListProvidersRequest(server string, params *ListProvidersParams) (*http.Request, error) {
+// NewApplyManifestsRequestWithBody generates requests for ApplyManifests with any type of body
+func NewApplyManifestsRequestWithBody(server string, params *ApplyManifestsParams, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -404,7 +495,7 @@ func NewListProvidersRequest(server string, params *ListProvidersParams) (*http.
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/providers")
+	operationPath := fmt.Sprintf("/admin/apply")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -417,9 +508,25 @@ func NewListProvidersRequest(server string, params *ListProvidersParams) (*http.
 	if params != nil {
 		queryValues := queryURL.Query()
 
-		if params.Type != nil {
+		if params.DryRun != nil {
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "type", runtime.ParamLocationQuery, *params.Type); err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "dry_run", runtime.ParamLocationQuery, *params.DryRun); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Prune != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "prune", runtime.ParamLocationQuery, *params.Prune); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -436,27 +543,18 @@ func NewListProvidersRequest(server string, params *ListProvidersParams) (*http.
 		queryURL.RawQuery = queryValues.Encode()
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
-	return req, nil
-}
+	req.Header.Add("Content-Type", contentType)
 
-// NewCreateProviderRequest calls the generic CreateProvider builder with application/json body
-func NewCreateProviderRequest(server string, body CreateProviderJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader = bytes.NewReader(buf)
-	return NewCreateProviderRequestWithBody(server, "application/json", bodyReader)
+	return req, nil
 }
 
-// NewCreateProviderRequestWithBody generates requests for CreateProvider with any type of body
-func NewCreateProviderRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewGetCatalogRequest generates requests for GetCatalog
+func NewGetCatalogRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -464,7 +562,7 @@ func NewCreateProviderRequestWithBody(server string, contentType string, body io
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/providers")
+	operationPath := fmt.Sprintf("/admin/catalog")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -474,33 +572,24 @@ func NewCreateProviderRequestWithBody(server string, contentType string, body io
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
-
 	return req, nil
 }
 
-// NewDeleteProviderRequest generates requests for DeleteProvider
-func NewDeleteProviderRequest(server string, providerId openapi_types.UUID) (*http.Request, error) {
+// NewGetLogLevelRequest generates requests for GetLogLevel
+func NewGetLogLevelRequest(server string) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "providerId", runtime.ParamLocationPath, providerId)
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/providers/%s", pathParam0)
+	operationPath := fmt.Sprintf("/admin/loglevel")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -510,7 +599,7 @@ func NewDeleteProviderRequest(server string, providerId openapi_types.UUID) (*ht
 		return nil, err
 	}
 
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -518,23 +607,27 @@ func NewDeleteProviderRequest(server string, providerId openapi_types.UUID) (*ht
 	return req, nil
 }
 
-// NewGetProviderRequest generates requests for GetProvider
-func NewGetProviderRequest(server string, providerId openapi_types.UUID) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "providerId", runtime.ParamLocationPath, providerId)
+// NewSetLogLevelRequest calls the generic SetLogLevel builder with application/json body
+func NewSetLogLevelRequest(server string, body SetLogLevelJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetLogLevelRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewSetLogLevelRequestWithBody generates requests for SetLogLevel with any type of body
+func NewSetLogLevelRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/providers/%s", pathParam0)
+	operationPath := fmt.Sprintf("/admin/loglevel")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -544,42 +637,26 @@ func NewGetProviderRequest(server string, providerId openapi_types.UUID) (*http.
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
-	return req, nil
-}
+	req.Header.Add("Content-Type", contentType)
 
-// NewApplyProviderRequest calls the generic ApplyProvider builder with application/json body
-func NewApplyProviderRequest(server string, providerId openapi_types.UUID, body ApplyProviderJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader = bytes.NewReader(buf)
-	return NewApplyProviderRequestWithBody(server, providerId, "application/json", bodyReader)
+	return req, nil
 }
 
-// NewApplyProviderRequestWithBody generates requests for ApplyProvider with any type of body
-func NewApplyProviderRequestWithBody(server string, providerId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+// NewGetRegistryRequest generates requests for GetRegistry
+func NewGetRegistryRequest(server string) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "providerId", runtime.ParamLocationPath, providerId)
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/providers/%s", pathParam0)
+	operationPath := fmt.Sprintf("/admin/registry")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -589,33 +666,445 @@ func NewApplyProviderRequestWithBody(server string, providerId openapi_types.UUI
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
-
 	return req, nil
 }
 
-// NewListRegisteredProvidersRequest generates requests for ListRegisteredProviders
-func NewListRegisteredProvidersRequest(server string, resourceKind string) (*http.Request, error) {
+// NewListHealthRequest generates requests for ListHealth
+func NewListHealthRequest(server string) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "resourceKind", runtime.ParamLocationPath, resourceKind)
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/resource/%s/provider", pathParam0)
+	operationPath := fmt.Sprintf("/health")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetLivenessRequest generates requests for GetLiveness
+func NewGetLivenessRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/livez")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListProvidersRequest generates requests for ListProviders
+func NewListProvidersRequest(server string, params *ListProvidersParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/providers")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Type != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "type", runtime.ParamLocationQuery, *params.Type); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateProviderRequest calls the generic CreateProvider builder with application/json body
+func NewCreateProviderRequest(server string, params *CreateProviderParams, body CreateProviderJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateProviderRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewCreateProviderRequestWithBody generates requests for CreateProvider with any type of body
+func NewCreateProviderRequestWithBody(server string, params *CreateProviderParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/providers")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDeleteProviderRequest generates requests for DeleteProvider
+func NewDeleteProviderRequest(server string, providerId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "providerId", runtime.ParamLocationPath, providerId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/providers/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetProviderRequest generates requests for GetProvider
+func NewGetProviderRequest(server string, providerId openapi_types.UUID, params *GetProviderParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "providerId", runtime.ParamLocationPath, providerId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/providers/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPatchProviderRequestWithApplicationMergePatchPlusJSONBody calls the generic PatchProvider builder with application/merge-patch+json body
+func NewPatchProviderRequestWithApplicationMergePatchPlusJSONBody(server string, providerId openapi_types.UUID, params *PatchProviderParams, body PatchProviderApplicationMergePatchPlusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchProviderRequestWithBody(server, providerId, params, "application/merge-patch+json", bodyReader)
+}
+
+// NewPatchProviderRequestWithBody generates requests for PatchProvider with any type of body
+func NewPatchProviderRequestWithBody(server string, providerId openapi_types.UUID, params *PatchProviderParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "providerId", runtime.ParamLocationPath, providerId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/providers/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.UpdateMask != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "update_mask", runtime.ParamLocationQuery, *params.UpdateMask); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewApplyProviderRequest calls the generic ApplyProvider builder with application/json body
+func NewApplyProviderRequest(server string, providerId openapi_types.UUID, params *ApplyProviderParams, body ApplyProviderJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewApplyProviderRequestWithBody(server, providerId, params, "application/json", bodyReader)
+}
+
+// NewApplyProviderRequestWithBody generates requests for ApplyProvider with any type of body
+func NewApplyProviderRequestWithBody(server string, providerId openapi_types.UUID, params *ApplyProviderParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "providerId", runtime.ParamLocationPath, providerId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/providers/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetReadinessRequest generates requests for GetReadiness
+func NewGetReadinessRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/readyz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListRegisteredProvidersRequest generates requests for ListRegisteredProviders
+func NewListRegisteredProvidersRequest(server string, resourceKind string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "resourceKind", runtime.ParamLocationPath, resourceKind)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/resource/%s/provider", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -634,18 +1123,18 @@ func NewListRegisteredProvidersRequest(server string, resourceKind string) (*htt
 }
 
 // NewRegisterProviderRequest calls the generic RegisterProvider builder with application/json body
-func NewRegisterProviderRequest(server string, resourceKind string, body RegisterProviderJSONRequestBody) (*http.Request, error) {
+func NewRegisterProviderRequest(server string, resourceKind string, params *RegisterProviderParams, body RegisterProviderJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewRegisterProviderRequestWithBody(server, resourceKind, "application/json", bodyReader)
+	return NewRegisterProviderRequestWithBody(server, resourceKind, params, "application/json", bodyReader)
 }
 
 // NewRegisterProviderRequestWithBody generates requests for RegisterProvider with any type of body
-func NewRegisterProviderRequestWithBody(server string, resourceKind string, contentType string, body io.Reader) (*http.Request, error) {
+func NewRegisterProviderRequestWithBody(server string, resourceKind string, params *RegisterProviderParams, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -677,6 +1166,32 @@ func NewRegisterProviderRequestWithBody(server string, resourceKind string, cont
 
 	req.Header.Add("Content-Type", contentType)
 
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+		if params.IdempotencyKey != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam1)
+		}
+
+	}
+
 	return req, nil
 }
 
@@ -722,7 +1237,7 @@ func NewUnregisterProviderRequest(server string, resourceKind string, providerId
 }
 
 // NewGetRegisteredProviderRequest generates requests for GetRegisteredProvider
-func NewGetRegisteredProviderRequest(server string, resourceKind string, providerId string) (*http.Request, error) {
+func NewGetRegisteredProviderRequest(server string, resourceKind string, providerId string, params *GetRegisteredProviderParams) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -759,6 +1274,112 @@ func NewGetRegisteredProviderRequest(server string, resourceKind string, provide
 		return nil, err
 	}
 
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPatchRegisteredProviderRequestWithApplicationMergePatchPlusJSONBody calls the generic PatchRegisteredProvider builder with application/merge-patch+json body
+func NewPatchRegisteredProviderRequestWithApplicationMergePatchPlusJSONBody(server string, resourceKind string, providerId string, params *PatchRegisteredProviderParams, body PatchRegisteredProviderApplicationMergePatchPlusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchRegisteredProviderRequestWithBody(server, resourceKind, providerId, params, "application/merge-patch+json", bodyReader)
+}
+
+// NewPatchRegisteredProviderRequestWithBody generates requests for PatchRegisteredProvider with any type of body
+func NewPatchRegisteredProviderRequestWithBody(server string, resourceKind string, providerId string, params *PatchRegisteredProviderParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "resourceKind", runtime.ParamLocationPath, resourceKind)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "providerId", runtime.ParamLocationPath, providerId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/resource/%s/provider/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.UpdateMask != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "update_mask", runtime.ParamLocationQuery, *params.UpdateMask); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
 	return req, nil
 }
 
@@ -805,58 +1426,159 @@ func WithBaseURL(baseURL string) ClientOption {
 
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// ApplyManifestsWithBodyWithResponse request with any body
+	ApplyManifestsWithBodyWithResponse(ctx context.Context, params *ApplyManifestsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApplyManifestsResponse, error)
+
+	ApplyManifestsWithResponse(ctx context.Context, params *ApplyManifestsParams, body ApplyManifestsJSONRequestBody, reqEditors ...RequestEditorFn) (*ApplyManifestsResponse, error)
+
 	// GetCatalogWithResponse request
 	GetCatalogWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCatalogResponse, error)
 
+	// GetLogLevelWithResponse request
+	GetLogLevelWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLogLevelResponse, error)
+
+	// SetLogLevelWithBodyWithResponse request with any body
+	SetLogLevelWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error)
+
+	SetLogLevelWithResponse(ctx context.Context, body SetLogLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error)
+
 	// GetRegistryWithResponse request
 	GetRegistryWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetRegistryResponse, error)
 
 	// ListHealthWithResponse request
 	ListHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListHealthResponse, error)
 
+	// GetLivenessWithResponse request
+	GetLivenessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLivenessResponse, error)
+
 	// ListProvidersWithResponse request
 	ListProvidersWithResponse(ctx context.Context, params *ListProvidersParams, reqEditors ...RequestEditorFn) (*ListProvidersResponse, error)
 
 	// CreateProviderWithBodyWithResponse request with any body
-	CreateProviderWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateProviderResponse, error)
+	CreateProviderWithBodyWithResponse(ctx context.Context, params *CreateProviderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateProviderResponse, error)
 
-	CreateProviderWithResponse(ctx context.Context, body CreateProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateProviderResponse, error)
+	CreateProviderWithResponse(ctx context.Context, params *CreateProviderParams, body CreateProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateProviderResponse, error)
 
 	// DeleteProviderWithResponse request
 	DeleteProviderWithResponse(ctx context.Context, providerId openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteProviderResponse, error)
 
 	// GetProviderWithResponse request
-	GetProviderWithResponse(ctx context.Context, providerId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetProviderResponse, error)
+	GetProviderWithResponse(ctx context.Context, providerId openapi_types.UUID, params *GetProviderParams, reqEditors ...RequestEditorFn) (*GetProviderResponse, error)
+
+	// PatchProviderWithBodyWithResponse request with any body
+	PatchProviderWithBodyWithResponse(ctx context.Context, providerId openapi_types.UUID, params *PatchProviderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchProviderResponse, error)
+
+	PatchProviderWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, providerId openapi_types.UUID, params *PatchProviderParams, body PatchProviderApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchProviderResponse, error)
 
 	// ApplyProviderWithBodyWithResponse request with any body
-	ApplyProviderWithBodyWithResponse(ctx context.Context, providerId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApplyProviderResponse, error)
+	ApplyProviderWithBodyWithResponse(ctx context.Context, providerId openapi_types.UUID, params *ApplyProviderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApplyProviderResponse, error)
 
-	ApplyProviderWithResponse(ctx context.Context, providerId openapi_types.UUID, body ApplyProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*ApplyProviderResponse, error)
+	ApplyProviderWithResponse(ctx context.Context, providerId openapi_types.UUID, params *ApplyProviderParams, body ApplyProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*ApplyProviderResponse, error)
+
+	// GetReadinessWithResponse request
+	GetReadinessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetReadinessResponse, error)
 
 	// ListRegisteredProvidersWithResponse request
 	ListRegisteredProvidersWithResponse(ctx context.Context, resourceKind string, reqEditors ...RequestEditorFn) (*ListRegisteredProvidersResponse, error)
 
 	// RegisterProviderWithBodyWithResponse request with any body
-	RegisterProviderWithBodyWithResponse(ctx context.Context, resourceKind string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterProviderResponse, error)
+	RegisterProviderWithBodyWithResponse(ctx context.Context, resourceKind string, params *RegisterProviderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterProviderResponse, error)
+
+	RegisterProviderWithResponse(ctx context.Context, resourceKind string, params *RegisterProviderParams, body RegisterProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterProviderResponse, error)
+
+	// UnregisterProviderWithResponse request
+	UnregisterProviderWithResponse(ctx context.Context, resourceKind string, providerId string, reqEditors ...RequestEditorFn) (*UnregisterProviderResponse, error)
+
+	// GetRegisteredProviderWithResponse request
+	GetRegisteredProviderWithResponse(ctx context.Context, resourceKind string, providerId string, params *GetRegisteredProviderParams, reqEditors ...RequestEditorFn) (*GetRegisteredProviderResponse, error)
+
+	// PatchRegisteredProviderWithBodyWithResponse request with any body
+	PatchRegisteredProviderWithBodyWithResponse(ctx context.Context, resourceKind string, providerId string, params *PatchRegisteredProviderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchRegisteredProviderResponse, error)
+
+	PatchRegisteredProviderWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, resourceKind string, providerId string, params *PatchRegisteredProviderParams, body PatchRegisteredProviderApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRegisteredProviderResponse, error)
+}
+
+type ApplyManifestsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ApplyResult
+	JSON400      *Error
+	JSON409      *Error
+	JSON422      *Error
+	JSON500      *Error
+	JSON502      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ApplyManifestsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ApplyManifestsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCatalogResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *CatalogView
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCatalogResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCatalogResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
 
-	RegisterProviderWithResponse(ctx context.Context, resourceKind string, body RegisterProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterProviderResponse, error)
+type GetLogLevelResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LogLevel
+}
 
-	// UnregisterProviderWithResponse request
-	UnregisterProviderWithResponse(ctx context.Context, resourceKind string, providerId string, reqEditors ...RequestEditorFn) (*UnregisterProviderResponse, error)
+// Status returns HTTPResponse.Status
+func (r GetLogLevelResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
 
-	// GetRegisteredProviderWithResponse request
-	GetRegisteredProviderWithResponse(ctx context.Context, resourceKind string, providerId string, reqEditors ...RequestEditorFn) (*GetRegisteredProviderResponse, error)
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLogLevelResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
 }
 
-type GetCatalogResponse struct {
+type SetLogLevelResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *CatalogView
-	JSON500      *Error500
+	JSON200      *LogLevel
+	JSON400      *Error
 }
 
 // Status returns HTTPResponse.Status
-func (r GetCatalogResponse) Status() string {
+func (r SetLogLevelResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -864,7 +1586,7 @@ func (r GetCatalogResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetCatalogResponse) StatusCode() int {
+func (r SetLogLevelResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
@@ -875,7 +1597,7 @@ type GetRegistryResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *RegistryView
-	JSON500      *Error500
+	JSON500      *Error
 }
 
 // Status returns HTTPResponse.Status
@@ -915,12 +1637,34 @@ func (r ListHealthResponse) StatusCode() int {
 	return 0
 }
 
+type GetLivenessResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HealthReport
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLivenessResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLivenessResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type ListProvidersResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *ProviderList
-	JSON400      *Error400
-	JSON500      *Error500
+	JSON400      *Error
+	JSON500      *Error
 }
 
 // Status returns HTTPResponse.Status
@@ -943,8 +1687,11 @@ type CreateProviderResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON201      *Provider
-	JSON400      *Error400
-	JSON500      *Error500
+	JSON400      *Error
+	JSON409      *Error
+	JSON422      *Error
+	JSON500      *Error
+	JSON502      *Error
 }
 
 // Status returns HTTPResponse.Status
@@ -967,9 +1714,9 @@ type DeleteProviderResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON204      *Provider
-	JSON400      *Error400
-	JSON404      *Error404
-	JSON500      *Error500
+	JSON400      *Error
+	JSON404      *Error
+	JSON500      *Error
 }
 
 // Status returns HTTPResponse.Status
@@ -992,9 +1739,9 @@ type GetProviderResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *Provider
-	JSON400      *Error400
-	JSON404      *Error404
-	JSON500      *Error500
+	JSON400      *Error
+	JSON404      *Error
+	JSON500      *Error
 }
 
 // Status returns HTTPResponse.Status
@@ -1013,13 +1760,42 @@ func (r GetProviderResponse) StatusCode() int {
 	return 0
 }
 
+type PatchProviderResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Provider
+	JSON400      *Error
+	JSON404      *Error
+	JSON412      *Error
+	JSON500      *Error
+	JSON502      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchProviderResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchProviderResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type ApplyProviderResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON201      *Provider
-	JSON400      *Error400
-	JSON404      *Error404
-	JSON500      *Error500
+	JSON200      *Provider
+	JSON400      *Error
+	JSON404      *Error
+	JSON412      *Error
+	JSON500      *Error
+	JSON502      *Error
 }
 
 // Status returns HTTPResponse.Status
@@ -1038,12 +1814,36 @@ func (r ApplyProviderResponse) StatusCode() int {
 	return 0
 }
 
+type GetReadinessResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HealthReport
+	JSON503      *HealthReport
+}
+
+// Status returns HTTPResponse.Status
+func (r GetReadinessResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetReadinessResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type ListRegisteredProvidersResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *[]RegisteredProvider
-	JSON400      *Error400
-	JSON500      *Error500
+	JSON400      *Error
+	JSON422      *Error
+	JSON500      *Error
 }
 
 // Status returns HTTPResponse.Status
@@ -1066,8 +1866,12 @@ type RegisterProviderResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *RegistrationResponse
-	JSON400      *Error400
-	JSON500      *Error500
+	JSON400      *Error
+	JSON409      *Error
+	JSON412      *Error
+	JSON422      *Error
+	JSON500      *Error
+	JSON502      *Error
 }
 
 // Status returns HTTPResponse.Status
@@ -1089,8 +1893,10 @@ func (r RegisterProviderResponse) StatusCode() int {
 type UnregisterProviderResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON404      *Error404
-	JSON500      *Error500
+	JSON400      *Error
+	JSON404      *Error
+	JSON422      *Error
+	JSON500      *Error
 }
 
 // Status returns HTTPResponse.Status
@@ -1113,8 +1919,10 @@ type GetRegisteredProviderResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *RegisteredProvider
-	JSON404      *Error404
-	JSON500      *Error500
+	JSON400      *Error
+	JSON404      *Error
+	JSON422      *Error
+	JSON500      *Error
 }
 
 // Status returns HTTPResponse.Status
@@ -1133,6 +1941,51 @@ func (r GetRegisteredProviderResponse) StatusCode() int {
 	return 0
 }
 
+type PatchRegisteredProviderResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RegisteredProvider
+	JSON400      *Error
+	JSON404      *Error
+	JSON412      *Error
+	JSON422      *Error
+	JSON500      *Error
+	JSON502      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchRegisteredProviderResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchRegisteredProviderResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ApplyManifestsWithBodyWithResponse request with arbitrary body returning *ApplyManifestsResponse
+func (c *ClientWithResponses) ApplyManifestsWithBodyWithResponse(ctx context.Context, params *ApplyManifestsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApplyManifestsResponse, error) {
+	rsp, err := c.ApplyManifestsWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseApplyManifestsResponse(rsp)
+}
+
+func (c *ClientWithResponses) ApplyManifestsWithResponse(ctx context.Context, params *ApplyManifestsParams, body ApplyManifestsJSONRequestBody, reqEditors ...RequestEditorFn) (*ApplyManifestsResponse, error) {
+	rsp, err := c.ApplyManifests(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseApplyManifestsResponse(rsp)
+}
+
 // GetCatalogWithResponse request returning *GetCatalogResponse
 func (c *ClientWithResponses) GetCatalogWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCatalogResponse, error) {
 	rsp, err := c.GetCatalog(ctx, reqEditors...)
@@ -1142,6 +1995,32 @@ func (c *ClientWithResponses) GetCatalogWithResponse(ctx context.Context, reqEdi
 	return ParseGetCatalogResponse(rsp)
 }
 
+// GetLogLevelWithResponse request returning *GetLogLevelResponse
+func (c *ClientWithResponses) GetLogLevelWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLogLevelResponse, error) {
+	rsp, err := c.GetLogLevel(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLogLevelResponse(rsp)
+}
+
+// SetLogLevelWithBodyWithResponse request with arbitrary body returning *SetLogLevelResponse
+func (c *ClientWithResponses) SetLogLevelWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error) {
+	rsp, err := c.SetLogLevelWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetLogLevelResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetLogLevelWithResponse(ctx context.Context, body SetLogLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error) {
+	rsp, err := c.SetLogLevel(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetLogLevelResponse(rsp)
+}
+
 // GetRegistryWithResponse request returning *GetRegistryResponse
 func (c *ClientWithResponses) GetRegistryWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetRegistryResponse, error) {
 	rsp, err := c.GetRegistry(ctx, reqEditors...)
@@ -1160,6 +2039,15 @@ func (c *ClientWithResponses) ListHealthWithResponse(ctx context.Context, reqEdi
 	return ParseListHealthResponse(rsp)
 }
 
+// GetLivenessWithResponse request returning *GetLivenessResponse
+func (c *ClientWithResponses) GetLivenessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLivenessResponse, error) {
+	rsp, err := c.GetLiveness(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLivenessResponse(rsp)
+}
+
 // ListProvidersWithResponse request returning *ListProvidersResponse
 func (c *ClientWithResponses) ListProvidersWithResponse(ctx context.Context, params *ListProvidersParams, reqEditors ...RequestEditorFn) (*ListProvidersResponse, error) {
 	rsp, err := c.ListProviders(ctx, params, reqEditors...)
@@ -1170,16 +2058,16 @@ func (c *ClientWithResponses) ListProvidersWithResponse(ctx context.Context, par
 }
 
 // CreateProviderWithBodyWithResponse request with arbitrary body returning *CreateProviderResponse
-func (c *ClientWithResponses) CreateProviderWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateProviderResponse, error) {
-	rsp, err := c.CreateProviderWithBody(ctx, contentType, body, reqEditors...)
+func (c *ClientWithResponses) CreateProviderWithBodyWithResponse(ctx context.Context, params *CreateProviderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateProviderResponse, error) {
+	rsp, err := c.CreateProviderWithBody(ctx, params, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParseCreateProviderResponse(rsp)
 }
 
-func (c *ClientWithResponses) CreateProviderWithResponse(ctx context.Context, body CreateProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateProviderResponse, error) {
-	rsp, err := c.CreateProvider(ctx, body, reqEditors...)
+func (c *ClientWithResponses) CreateProviderWithResponse(ctx context.Context, params *CreateProviderParams, body CreateProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateProviderResponse, error) {
+	rsp, err := c.CreateProvider(ctx, params, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -1196,31 +2084,57 @@ func (c *ClientWithResponses) DeleteProviderWithResponse(ctx context.Context, pr
 }
 
 // GetProviderWithResponse request returning *GetProviderResponse
-func (c *ClientWithResponses) GetProviderWithResponse(ctx context.Context, providerId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetProviderResponse, error) {
-	rsp, err := c.GetProvider(ctx, providerId, reqEditors...)
+func (c *ClientWithResponses) GetProviderWithResponse(ctx context.Context, providerId openapi_types.UUID, params *GetProviderParams, reqEditors ...RequestEditorFn) (*GetProviderResponse, error) {
+	rsp, err := c.GetProvider(ctx, providerId, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParseGetProviderResponse(rsp)
 }
 
+// PatchProviderWithBodyWithResponse request with arbitrary body returning *PatchProviderResponse
+func (c *ClientWithResponses) PatchProviderWithBodyWithResponse(ctx context.Context, providerId openapi_types.UUID, params *PatchProviderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchProviderResponse, error) {
+	rsp, err := c.PatchProviderWithBody(ctx, providerId, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchProviderResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchProviderWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, providerId openapi_types.UUID, params *PatchProviderParams, body PatchProviderApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchProviderResponse, error) {
+	rsp, err := c.PatchProviderWithApplicationMergePatchPlusJSONBody(ctx, providerId, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchProviderResponse(rsp)
+}
+
 // ApplyProviderWithBodyWithResponse request with arbitrary body returning *ApplyProviderResponse
-func (c *ClientWithResponses) ApplyProviderWithBodyWithResponse(ctx context.Context, providerId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApplyProviderResponse, error) {
-	rsp, err := c.ApplyProviderWithBody(ctx, providerId, contentType, body, reqEditors...)
+func (c *ClientWithResponses) ApplyProviderWithBodyWithResponse(ctx context.Context, providerId openapi_types.UUID, params *ApplyProviderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApplyProviderResponse, error) {
+	rsp, err := c.ApplyProviderWithBody(ctx, providerId, params, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParseApplyProviderResponse(rsp)
 }
 
-func (c *ClientWithResponses) ApplyProviderWithResponse(ctx context.Context, providerId openapi_types.UUID, body ApplyProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*ApplyProviderResponse, error) {
-	rsp, err := c.ApplyProvider(ctx, providerId, body, reqEditors...)
+func (c *ClientWithResponses) ApplyProviderWithResponse(ctx context.Context, providerId openapi_types.UUID, params *ApplyProviderParams, body ApplyProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*ApplyProviderResponse, error) {
+	rsp, err := c.ApplyProvider(ctx, providerId, params, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParseApplyProviderResponse(rsp)
 }
 
+// GetReadinessWithResponse request returning *GetReadinessResponse
+func (c *ClientWithResponses) GetReadinessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetReadinessResponse, error) {
+	rsp, err := c.GetReadiness(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetReadinessResponse(rsp)
+}
+
 // ListRegisteredProvidersWithResponse request returning *ListRegisteredProvidersResponse
 func (c *ClientWithResponses) ListRegisteredProvidersWithResponse(ctx context.Context, resourceKind string, reqEditors ...RequestEditorFn) (*ListRegisteredProvidersResponse, error) {
 	rsp, err := c.ListRegisteredProviders(ctx, resourceKind, reqEditors...)
@@ -1230,68 +2144,205 @@ func (c *ClientWithResponses) ListRegisteredProvidersWithResponse(ctx context.Co
 	return ParseListRegisteredProvidersResponse(rsp)
 }
 
-// RegisterProviderWithBodyWithResponse request with arbitrary body returning *RegisterProviderResponse
-func (c *ClientWithResponses) RegisterProviderWithBodyWithResponse(ctx context.Context, resourceKind string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterProviderResponse, error) {
-	rsp, err := c.RegisterProviderWithBody(ctx, resourceKind, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+// RegisterProviderWithBodyWithResponse request with arbitrary body returning *RegisterProviderResponse
+func (c *ClientWithResponses) RegisterProviderWithBodyWithResponse(ctx context.Context, resourceKind string, params *RegisterProviderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterProviderResponse, error) {
+	rsp, err := c.RegisterProviderWithBody(ctx, resourceKind, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterProviderResponse(rsp)
+}
+
+func (c *ClientWithResponses) RegisterProviderWithResponse(ctx context.Context, resourceKind string, params *RegisterProviderParams, body RegisterProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterProviderResponse, error) {
+	rsp, err := c.RegisterProvider(ctx, resourceKind, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterProviderResponse(rsp)
+}
+
+// UnregisterProviderWithResponse request returning *UnregisterProviderResponse
+func (c *ClientWithResponses) UnregisterProviderWithResponse(ctx context.Context, resourceKind string, providerId string, reqEditors ...RequestEditorFn) (*UnregisterProviderResponse, error) {
+	rsp, err := c.UnregisterProvider(ctx, resourceKind, providerId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnregisterProviderResponse(rsp)
+}
+
+// GetRegisteredProviderWithResponse request returning *GetRegisteredProviderResponse
+func (c *ClientWithResponses) GetRegisteredProviderWithResponse(ctx context.Context, resourceKind string, providerId string, params *GetRegisteredProviderParams, reqEditors ...RequestEditorFn) (*GetRegisteredProviderResponse, error) {
+	rsp, err := c.GetRegisteredProvider(ctx, resourceKind, providerId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRegisteredProviderResponse(rsp)
+}
+
+// PatchRegisteredProviderWithBodyWithResponse request with arbitrary body returning *PatchRegisteredProviderResponse
+func (c *ClientWithResponses) PatchRegisteredProviderWithBodyWithResponse(ctx context.Context, resourceKind string, providerId string, params *PatchRegisteredProviderParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchRegisteredProviderResponse, error) {
+	rsp, err := c.PatchRegisteredProviderWithBody(ctx, resourceKind, providerId, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchRegisteredProviderResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchRegisteredProviderWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, resourceKind string, providerId string, params *PatchRegisteredProviderParams, body PatchRegisteredProviderApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRegisteredProviderResponse, error) {
+	rsp, err := c.PatchRegisteredProviderWithApplicationMergePatchPlusJSONBody(ctx, resourceKind, providerId, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchRegisteredProviderResponse(rsp)
+}
+
+// ParseApplyManifestsResponse parses an HTTP response from a ApplyManifestsWithResponse call
+func ParseApplyManifestsResponse(rsp *http.Response) (*ApplyManifestsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ApplyManifestsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ApplyResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCatalogResponse parses an HTTP response from a GetCatalogWithResponse call
+func ParseGetCatalogResponse(rsp *http.Response) (*GetCatalogResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCatalogResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CatalogView
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
 	}
-	return ParseRegisterProviderResponse(rsp)
+
+	return response, nil
 }
 
-func (c *ClientWithResponses) RegisterProviderWithResponse(ctx context.Context, resourceKind string, body RegisterProviderJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterProviderResponse, error) {
-	rsp, err := c.RegisterProvider(ctx, resourceKind, body, reqEditors...)
+// ParseGetLogLevelResponse parses an HTTP response from a GetLogLevelWithResponse call
+func ParseGetLogLevelResponse(rsp *http.Response) (*GetLogLevelResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseRegisterProviderResponse(rsp)
-}
 
-// UnregisterProviderWithResponse request returning *UnregisterProviderResponse
-func (c *ClientWithResponses) UnregisterProviderWithResponse(ctx context.Context, resourceKind string, providerId string, reqEditors ...RequestEditorFn) (*UnregisterProviderResponse, error) {
-	rsp, err := c.UnregisterProvider(ctx, resourceKind, providerId, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetLogLevelResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseUnregisterProviderResponse(rsp)
-}
 
-// GetRegisteredProviderWithResponse request returning *GetRegisteredProviderResponse
-func (c *ClientWithResponses) GetRegisteredProviderWithResponse(ctx context.Context, resourceKind string, providerId string, reqEditors ...RequestEditorFn) (*GetRegisteredProviderResponse, error) {
-	rsp, err := c.GetRegisteredProvider(ctx, resourceKind, providerId, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LogLevel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	}
-	return ParseGetRegisteredProviderResponse(rsp)
+
+	return response, nil
 }
 
-// ParseGetCatalogResponse parses an HTTP response from a GetCatalogWithResponse call
-func ParseGetCatalogResponse(rsp *http.Response) (*GetCatalogResponse, error) {
+// ParseSetLogLevelResponse parses an HTTP response from a SetLogLevelWithResponse call
+func ParseSetLogLevelResponse(rsp *http.Response) (*SetLogLevelResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetCatalogResponse{
+	response := &SetLogLevelResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest CatalogView
+		var dest LogLevel
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error500
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON500 = &dest
+		response.JSON400 = &dest
 
 	}
 
@@ -1320,7 +2371,7 @@ func ParseGetRegistryResponse(rsp *http.Response) (*GetRegistryResponse, error)
 		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error500
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1347,6 +2398,32 @@ func ParseListHealthResponse(rsp *http.Response) (*ListHealthResponse, error) {
 	return response, nil
 }
 
+// ParseGetLivenessResponse parses an HTTP response from a GetLivenessWithResponse call
+func ParseGetLivenessResponse(rsp *http.Response) (*GetLivenessResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLivenessResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HealthReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseListProvidersResponse parses an HTTP response from a ListProvidersWithResponse call
 func ParseListProvidersResponse(rsp *http.Response) (*ListProvidersResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -1369,14 +2446,14 @@ func ParseListProvidersResponse(rsp *http.Response) (*ListProvidersResponse, err
 		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Error400
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error500
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1409,19 +2486,40 @@ func ParseCreateProviderResponse(rsp *http.Response) (*CreateProviderResponse, e
 		response.JSON201 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Error400
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error500
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON500 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
 	}
 
 	return response, nil
@@ -1449,21 +2547,21 @@ func ParseDeleteProviderResponse(rsp *http.Response) (*DeleteProviderResponse, e
 		response.JSON204 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Error400
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest Error404
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON404 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error500
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1496,26 +2594,87 @@ func ParseGetProviderResponse(rsp *http.Response) (*GetProviderResponse, error)
 		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Error400
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchProviderResponse parses an HTTP response from a PatchProviderWithResponse call
+func ParsePatchProviderResponse(rsp *http.Response) (*PatchProviderResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchProviderResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Provider
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest Error404
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON404 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error500
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON500 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
 	}
 
 	return response, nil
@@ -1535,34 +2694,81 @@ func ParseApplyProviderResponse(rsp *http.Response) (*ApplyProviderResponse, err
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
 		var dest Provider
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON201 = &dest
+		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Error400
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest Error404
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON404 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error500
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON500 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetReadinessResponse parses an HTTP response from a GetReadinessWithResponse call
+func ParseGetReadinessResponse(rsp *http.Response) (*GetReadinessResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetReadinessResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HealthReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest HealthReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
 	}
 
 	return response, nil
@@ -1590,14 +2796,21 @@ func ParseListRegisteredProvidersResponse(rsp *http.Response) (*ListRegisteredPr
 		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Error400
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error500
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1630,19 +2843,47 @@ func ParseRegisterProviderResponse(rsp *http.Response) (*RegisterProviderRespons
 		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Error400
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error500
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON500 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
 	}
 
 	return response, nil
@@ -1662,15 +2903,29 @@ func ParseUnregisterProviderResponse(rsp *http.Response) (*UnregisterProviderRes
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest Error404
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON404 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error500
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1702,20 +2957,102 @@ func ParseGetRegisteredProviderResponse(rsp *http.Response) (*GetRegisteredProvi
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchRegisteredProviderResponse parses an HTTP response from a PatchRegisteredProviderWithResponse call
+func ParsePatchRegisteredProviderResponse(rsp *http.Response) (*PatchRegisteredProviderResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchRegisteredProviderResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RegisteredProvider
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest Error404
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON404 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error500
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON500 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
 	}
 
 	return response, nil
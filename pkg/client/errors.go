@@ -0,0 +1,137 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	api "github.com/dcm-project/service-provider-api/api/v1alpha1"
+)
+
+// Errors matched by APIError with errors.Is, based on the code of the error
+// envelope returned by the Service Provider API
+var (
+	ErrInvalidArgument     = errors.New("invalid argument")
+	ErrNotFound            = errors.New("not found")
+	ErrAlreadyExists       = errors.New("already exists")
+	ErrPreconditionFailed  = errors.New("precondition failed")
+	ErrEndpointUnreachable = errors.New("provider endpoint unreachable")
+	ErrIdempotencyConflict = errors.New("idempotency key conflict")
+)
+
+// Codes of the error envelope
+const (
+	CodeInvalidArgument      = "INVALID_ARGUMENT"
+	CodeValidation           = "VALIDATION_ERROR"
+	CodeRequestTooLarge      = "REQUEST_TOO_LARGE"
+	CodeNotFound             = "NOT_FOUND"
+	CodeProviderNotFound     = "PROVIDER_NOT_FOUND"
+	CodeAlreadyExists        = "ALREADY_EXISTS"
+	CodeRegistrationConflict = "REGISTRATION_CONFLICT"
+	CodePreconditionFailed   = "PRECONDITION_FAILED"
+	CodeEndpointUnreachable  = "ENDPOINT_UNREACHABLE"
+	CodeIdempotencyKeyReused = "IDEMPOTENCY_KEY_REUSED"
+	CodeIdempotencyKeyInUse  = "IDEMPOTENCY_KEY_IN_USE"
+	CodeInternal             = "INTERNAL"
+	// CodeUnknown is the code of the responses without an error envelope whose
+	// status does not tell the error apart, e.g. a 502 of a proxy
+	CodeUnknown = "UNKNOWN"
+)
+
+var errorsByCode = map[string]error{
+	CodeInvalidArgument:      ErrInvalidArgument,
+	CodeValidation:           ErrInvalidArgument,
+	CodeRequestTooLarge:      ErrInvalidArgument,
+	CodeNotFound:             ErrNotFound,
+	CodeProviderNotFound:     ErrNotFound,
+	CodeAlreadyExists:        ErrAlreadyExists,
+	CodeRegistrationConflict: ErrAlreadyExists,
+	CodePreconditionFailed:   ErrPreconditionFailed,
+	CodeEndpointUnreachable:  ErrEndpointUnreachable,
+	CodeIdempotencyKeyReused: ErrIdempotencyConflict,
+	CodeIdempotencyKeyInUse:  ErrIdempotencyConflict,
+}
+
+// codesByStatus are the codes of the responses without an error envelope.
+// These responses come from e.g. a proxy in front of the API, so only the
+// statuses describing the request itself have a code: a 404 or a 502 of a
+// proxy is not about a provider, and is reported with CodeUnknown.
+var codesByStatus = map[int]string{
+	http.StatusBadRequest:            CodeInvalidArgument,
+	http.StatusPreconditionFailed:    CodePreconditionFailed,
+	http.StatusRequestEntityTooLarge: CodeRequestTooLarge,
+}
+
+// APIError is an error response of the Service Provider API
+type APIError struct {
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+	// Code is the machine-readable error code, e.g. "VALIDATION_ERROR"
+	Code      string
+	Message   string
+	Details   []map[string]interface{}
+	RequestID string
+}
+
+func (e *APIError) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("%s (%d): %s [request_id=%s]", e.Code, e.StatusCode, e.Message, e.RequestID)
+	}
+	return fmt.Sprintf("%s (%d): %s", e.Code, e.StatusCode, e.Message)
+}
+
+// Is reports whether target is the sentinel error for the code of e
+func (e *APIError) Is(target error) bool {
+	return errorsByCode[e.Code] == target
+}
+
+// CheckResponse returns nil for successful responses and an *APIError
+// decoded from the body otherwise. It can be used with the StatusCode and Body
+// of the ClientWithResponses results.
+func CheckResponse(statusCode int, body []byte) error {
+	if statusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	var envelope api.Error
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.Code == "" {
+		// Not an error envelope, e.g. returned by a proxy in front of the API
+		code, ok := codesByStatus[statusCode]
+		if !ok {
+			code = CodeUnknown
+		}
+		return &APIError{
+			StatusCode: statusCode,
+			Code:       code,
+			Message:    string(body),
+		}
+	}
+
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Code:       envelope.Code,
+		Message:    envelope.Message,
+	}
+	if envelope.Details != nil {
+		apiErr.Details = *envelope.Details
+	}
+	if envelope.RequestId != nil {
+		apiErr.RequestID = *envelope.RequestId
+	}
+	return apiErr
+}
+
+// ErrorFromResponse reads the body of resp and returns the decoded error, or
+// nil if the response is successful. It can be used with the Client results.
+func ErrorFromResponse(resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read error response: %w", err)
+	}
+	return CheckResponse(resp.StatusCode, body)
+}
@@ -0,0 +1,69 @@
+// Package provider is a framework for writing DCM providers. A provider
+// implements a ResourceHandler per resource kind, the package serves them over
+// HTTP, registers them with DCM, keeps the registrations alive and removes
+// them on shutdown.
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned by a ResourceHandler for resources that do not
+	// exist, it is served as 404
+	ErrNotFound = errors.New("resource not found")
+
+	// ErrAlreadyExists is returned by a ResourceHandler when creating a
+	// resource that already exists, it is served as 409
+	ErrAlreadyExists = errors.New("resource already exists")
+
+	// ErrInvalidRequest is returned for requests that cannot be served as
+	// given, it is served as 422
+	ErrInvalidRequest = errors.New("invalid request")
+)
+
+// CreateRequest is the body of the creation of a resource
+type CreateRequest struct {
+	Name string `json:"name"`
+	// CatalogItem is the name of the catalog item the resource is created from
+	CatalogItem string `json:"catalog_item,omitempty"`
+	// Spec holds the attributes specific to the resource kind
+	Spec map[string]interface{} `json:"spec,omitempty"`
+}
+
+// Resource is a resource managed by a provider
+type Resource struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	CatalogItem string `json:"catalog_item,omitempty"`
+	// Status is the provider specific status of the resource
+	Status string `json:"status,omitempty"`
+	// Properties hold the attributes specific to the resource kind
+	Properties map[string]interface{} `json:"properties,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// ResourceHandler manages the resources of a resource kind. The errors
+// wrapping ErrNotFound, ErrAlreadyExists and ErrInvalidRequest are served
+// with their status code, the other ones as 500.
+type ResourceHandler interface {
+	Create(ctx context.Context, request CreateRequest) (*Resource, error)
+	Get(ctx context.Context, id string) (*Resource, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]Resource, error)
+}
+
+// Validator is implemented by the ResourceHandlers that validate the creation
+// requests beyond the checks of the framework. Validate is called before
+// Create, its error is served as 422.
+type Validator interface {
+	Validate(request CreateRequest) error
+}
+
+// HealthChecker is implemented by the ResourceHandlers that can report their
+// health, /health fails while Health returns an error
+type HealthChecker interface {
+	Health(ctx context.Context) error
+}
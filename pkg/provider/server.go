@@ -0,0 +1,362 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/pkg/registration/client"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// APIPrefix is the path prefix of the resource kinds, the resources of a
+	// kind are served under <APIPrefix>/<kind>
+	APIPrefix = "/api"
+
+	defaultAddress           = ":8080"
+	defaultHeartbeatInterval = time.Minute
+	defaultShutdownTimeout   = 10 * time.Second
+	maxRequestBytes          = 1 << 20
+)
+
+// Operations are the operations registered for every resource kind
+var Operations = []string{"CREATE", "READ", "DELETE", "LIST"}
+
+// Config for creating a Server
+type Config struct {
+	// ServiceID identifies the provider in DCM, it must be a UUID that is
+	// stable across restarts. The registry holds a single resource kind per
+	// service ID, so with several handlers each kind is registered with a
+	// name based UUID derived from it, see Server.ServiceID.
+	ServiceID string
+
+	// Address is the listen address of the HTTP server, ":8080" by default
+	Address string
+
+	// AdvertiseURL is the base URL DCM reaches the provider at,
+	// http://<Address> by default
+	AdvertiseURL string
+
+	// DCMURL is the base URL of the DCM API. The resource kinds are not
+	// registered when empty.
+	DCMURL string
+
+	// Zone and Region are the metadata of the registrations
+	Zone   string
+	Region string
+
+	// ResourceConstraints are the optional constraints of the registrations
+	ResourceConstraints map[string]string
+
+	// Handlers are the resource handlers by resource kind
+	Handlers map[string]ResourceHandler
+
+	// HeartbeatInterval is the period the registrations are renewed at, one
+	// minute by default
+	HeartbeatInterval time.Duration
+
+	// ShutdownTimeout bounds the deregistration and the draining of the
+	// connections on shutdown, 10 seconds by default
+	ShutdownTimeout time.Duration
+
+	// HTTPClient is the optional HTTP client of the registration requests
+	HTTPClient *http.Client
+}
+
+// Server serves the resource handlers of a provider and registers them with
+// DCM
+type Server struct {
+	cfg        Config
+	kinds      []string
+	serviceIDs map[string]string
+}
+
+// New creates a new provider server
+func New(cfg Config) (*Server, error) {
+	if _, err := uuid.Parse(cfg.ServiceID); err != nil {
+		return nil, fmt.Errorf("service ID must be a valid UUID: %w", err)
+	}
+	if len(cfg.Handlers) == 0 {
+		return nil, fmt.Errorf("at least one resource handler is required")
+	}
+	kinds := make([]string, 0, len(cfg.Handlers))
+	for kind, handler := range cfg.Handlers {
+		if errs := validation.IsDNS1123Label(kind); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid resource kind %q: %s", kind, strings.Join(errs, ", "))
+		}
+		if handler == nil {
+			return nil, fmt.Errorf("resource handler of %s is nil", kind)
+		}
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	if cfg.DCMURL != "" && (cfg.Zone == "" || cfg.Region == "") {
+		return nil, fmt.Errorf("zone and region are required to register with DCM")
+	}
+
+	if cfg.Address == "" {
+		cfg.Address = defaultAddress
+	}
+	if cfg.AdvertiseURL == "" {
+		host, port, err := net.SplitHostPort(cfg.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q: %w", cfg.Address, err)
+		}
+		if host == "" {
+			host = "localhost"
+		}
+		cfg.AdvertiseURL = "http://" + net.JoinHostPort(host, port)
+	}
+	cfg.AdvertiseURL = strings.TrimSuffix(cfg.AdvertiseURL, "/")
+	if cfg.HeartbeatInterval == 0 {
+		cfg.HeartbeatInterval = defaultHeartbeatInterval
+	}
+	if cfg.ShutdownTimeout == 0 {
+		cfg.ShutdownTimeout = defaultShutdownTimeout
+	}
+
+	serviceIDs := make(map[string]string, len(kinds))
+	namespace := uuid.MustParse(cfg.ServiceID)
+	for _, kind := range kinds {
+		serviceIDs[kind] = cfg.ServiceID
+		if len(kinds) > 1 {
+			serviceIDs[kind] = uuid.NewSHA1(namespace, []byte(kind)).String()
+		}
+	}
+
+	return &Server{cfg: cfg, kinds: kinds, serviceIDs: serviceIDs}, nil
+}
+
+// Endpoint returns the registration endpoint of a resource kind
+func (s *Server) Endpoint(kind string) string {
+	return s.cfg.AdvertiseURL + APIPrefix + "/" + kind
+}
+
+// ServiceID returns the service ID a resource kind is registered with: the
+// configured one for a single kind, a name based UUID derived from it and the
+// kind otherwise
+func (s *Server) ServiceID(kind string) string {
+	return s.serviceIDs[kind]
+}
+
+// Handler returns the HTTP API of the provider. /health reports the health of
+// the provider, and each resource kind is served under <APIPrefix>/<kind>:
+// POST / creates a resource, GET / lists them, GET and DELETE /{id} get and
+// delete one and GET /health reports the health for the endpoint checks of
+// DCM.
+func (s *Server) Handler() http.Handler {
+	r := chi.NewRouter()
+	r.Get("/health", s.health)
+	for _, kind := range s.kinds {
+		h := &kindHandler{handler: s.cfg.Handlers[kind]}
+		r.Route(APIPrefix+"/"+kind, func(r chi.Router) {
+			r.Get("/health", s.health)
+			r.Post("/", h.create)
+			r.Get("/", h.list)
+			r.Get("/{id}", h.get)
+			r.Delete("/{id}", h.delete)
+		})
+	}
+	return r
+}
+
+// Run serves the provider until ctx is done. Once the server listens the
+// resource kinds are registered with DCM, and renewed every heartbeat
+// interval. On shutdown the registrations are removed before the connections
+// are drained.
+func (s *Server) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.cfg.Address)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Printf("Provider %s listening on %s", s.cfg.ServiceID, listener.Addr())
+		serveErr <- srv.Serve(listener)
+	}()
+
+	var registrar *client.AutoRegistrar
+	if s.cfg.DCMURL != "" {
+		registrar = client.NewAutoRegistrar(client.AutoRegistrarConfig{
+			Client:             client.New(client.Config{BaseURL: s.cfg.DCMURL, HTTPClient: s.cfg.HTTPClient}),
+			Registrations:      s.registrations(),
+			ReregisterInterval: s.cfg.HeartbeatInterval,
+		})
+		if err := registrar.Start(ctx); err != nil {
+			s.shutdown(srv, nil)
+			return fmt.Errorf("registering with DCM: %w", err)
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return s.shutdown(srv, registrar)
+	case err := <-serveErr:
+		_ = s.shutdown(srv, registrar)
+		return err
+	}
+}
+
+// shutdown removes the registrations, if any, and drains the connections
+func (s *Server) shutdown(srv *http.Server, registrar *client.AutoRegistrar) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
+	defer cancel()
+
+	if registrar != nil {
+		registrar.Stop()
+		_ = registrar.UnregisterAll(ctx)
+		log.Printf("Provider %s unregistered from DCM", s.cfg.ServiceID)
+	}
+	if err := srv.Shutdown(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) registrations() []client.Registration {
+	registrations := make([]client.Registration, 0, len(s.kinds))
+	for _, kind := range s.kinds {
+		registrations = append(registrations, client.Registration{
+			ResourceKind: kind,
+			Request: &client.RegistrationRequest{
+				ServiceID: s.ServiceID(kind),
+				Endpoint:  s.Endpoint(kind),
+				Metadata: client.Metadata{
+					Zone:                s.cfg.Zone,
+					Region:              s.cfg.Region,
+					ResourceConstraints: s.cfg.ResourceConstraints,
+				},
+				Operations: Operations,
+			},
+		})
+	}
+	return registrations
+}
+
+func (s *Server) health(w http.ResponseWriter, r *http.Request) {
+	for _, kind := range s.kinds {
+		checker, ok := s.cfg.Handlers[kind].(HealthChecker)
+		if !ok {
+			continue
+		}
+		if err := checker.Health(r.Context()); err != nil {
+			writeError(w, http.StatusServiceUnavailable, "UNAVAILABLE", fmt.Sprintf("%s: %v", kind, err))
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]string{
+		"status":     "healthy",
+		"service_id": s.cfg.ServiceID,
+	})
+}
+
+// kindHandler serves the resources of a resource kind
+type kindHandler struct {
+	handler ResourceHandler
+}
+
+func (h *kindHandler) create(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	decoder.DisallowUnknownFields()
+	var request CreateRequest
+	if err := decoder.Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if err := h.validate(request); err != nil {
+		writeHandlerError(w, err)
+		return
+	}
+
+	resource, err := h.handler.Create(r.Context(), request)
+	if err != nil {
+		writeHandlerError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, resource)
+}
+
+func (h *kindHandler) validate(request CreateRequest) error {
+	if request.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidRequest)
+	}
+	if errs := validation.IsDNS1123Subdomain(request.Name); len(errs) > 0 {
+		return fmt.Errorf("%w: name %q: %s", ErrInvalidRequest, request.Name, strings.Join(errs, ", "))
+	}
+	if validator, ok := h.handler.(Validator); ok {
+		if err := validator.Validate(request); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+		}
+	}
+	return nil
+}
+
+func (h *kindHandler) list(w http.ResponseWriter, r *http.Request) {
+	resources, err := h.handler.List(r.Context())
+	if err != nil {
+		writeHandlerError(w, err)
+		return
+	}
+	if resources == nil {
+		resources = []Resource{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"resources": resources})
+}
+
+func (h *kindHandler) get(w http.ResponseWriter, r *http.Request) {
+	resource, err := h.handler.Get(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeHandlerError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resource)
+}
+
+func (h *kindHandler) delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.handler.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+		writeHandlerError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Error is the body of the error responses
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func writeHandlerError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidRequest):
+		writeError(w, http.StatusUnprocessableEntity, "INVALID_ARGUMENT", err.Error())
+	case errors.Is(err, ErrNotFound):
+		writeError(w, http.StatusNotFound, "NOT_FOUND", err.Error())
+	case errors.Is(err, ErrAlreadyExists):
+		writeError(w, http.StatusConflict, "ALREADY_EXISTS", err.Error())
+	default:
+		log.Printf("Resource handler failed: %v", err)
+		writeError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code, message string) {
+	writeJSON(w, statusCode, Error{Code: code, Message: message})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
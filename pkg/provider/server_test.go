@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/pkg/registration/fake"
+	"github.com/google/uuid"
+)
+
+// handler is a ResourceHandler without resources
+type handler struct{}
+
+func (handler) Create(ctx context.Context, request CreateRequest) (*Resource, error) {
+	return &Resource{ID: request.Name, Name: request.Name, CreatedAt: time.Now()}, nil
+}
+
+func (handler) Get(ctx context.Context, id string) (*Resource, error) { return nil, ErrNotFound }
+
+func (handler) Delete(ctx context.Context, id string) error { return ErrNotFound }
+
+func (handler) List(ctx context.Context) ([]Resource, error) { return nil, nil }
+
+func TestServiceIDs(t *testing.T) {
+	serviceID := uuid.NewString()
+	single, err := New(Config{ServiceID: serviceID, Handlers: map[string]ResourceHandler{"file": handler{}}})
+	if err != nil {
+		t.Fatalf("creating the server: %v", err)
+	}
+	if got := single.ServiceID("file"); got != serviceID {
+		t.Errorf("expected the configured service ID for a single kind, got %s", got)
+	}
+
+	multiple, err := New(Config{ServiceID: serviceID, Handlers: map[string]ResourceHandler{"file": handler{}, "container": handler{}}})
+	if err != nil {
+		t.Fatalf("creating the server: %v", err)
+	}
+	file, container := multiple.ServiceID("file"), multiple.ServiceID("container")
+	if file == container || file == serviceID || container == serviceID {
+		t.Errorf("expected a distinct service ID per kind, got %s and %s", file, container)
+	}
+	if again, _ := New(Config{ServiceID: serviceID, Handlers: map[string]ResourceHandler{"file": handler{}, "container": handler{}}}); again.ServiceID("file") != file {
+		t.Errorf("expected the service IDs to be stable across restarts, got %s and %s", file, again.ServiceID("file"))
+	}
+}
+
+func TestRunRegistersEveryKind(t *testing.T) {
+	dcm := fake.NewServer(t)
+	server, err := New(Config{
+		ServiceID:    uuid.NewString(),
+		Address:      "127.0.0.1:0",
+		AdvertiseURL: "http://provider.example:8080",
+		DCMURL:       dcm.URL,
+		Zone:         "zone-a",
+		Region:       "region-1",
+		Handlers:     map[string]ResourceHandler{"file": handler{}, "container": handler{}},
+	})
+	if err != nil {
+		t.Fatalf("creating the server: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- server.Run(ctx) }()
+	dcm.WaitForRequests(t, http.MethodPost, "", 2, 5*time.Second)
+
+	for _, kind := range []string{"file", "container"} {
+		p, ok := dcm.Registration(kind, server.ServiceID(kind))
+		if !ok {
+			t.Fatalf("expected %s to be registered as %s, got %+v", kind, server.ServiceID(kind), dcm.Registrations())
+		}
+		if p.Endpoint != "http://provider.example:8080/api/"+kind {
+			t.Errorf("expected the endpoint of %s, got %s", kind, p.Endpoint)
+		}
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("running the server: %v", err)
+	}
+	if registrations := dcm.Registrations(); len(registrations) != 0 {
+		t.Errorf("expected every kind to be unregistered on shutdown, got %+v", registrations)
+	}
+	if got := dcm.RequestCount(http.MethodDelete, ""); got != 2 {
+		t.Errorf("expected 2 unregistrations, got %d", got)
+	}
+}
@@ -0,0 +1,263 @@
+// Package providertest checks that a provider implements the operations it
+// declares, as described by the provider contract of
+// api/provider/v1alpha1. The checks create a resource, read it, list it,
+// delete it and read it again expecting 404, and validate every response
+// against the contract.
+package providertest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	contract "github.com/dcm-project/service-provider-api/api/provider/v1alpha1"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/google/uuid"
+)
+
+// Operations of the registrations checked by the suite
+const (
+	OperationCreate = "CREATE"
+	OperationRead   = "READ"
+	OperationList   = "LIST"
+	OperationDelete = "DELETE"
+)
+
+// Config for running the suite
+type Config struct {
+	// Endpoint is the endpoint of the registration of the provider
+	Endpoint string
+
+	// Operations are the operations the provider declares. CREATE is required
+	// to check the other operations, which need a resource.
+	Operations []string
+
+	// CreateBody is the body of the creation request, a request with a random
+	// name by default
+	CreateBody map[string]interface{}
+
+	// HTTPClient sends the requests, a client with a 30 seconds timeout by
+	// default
+	HTTPClient *http.Client
+}
+
+// Run runs the suite against the provider and returns its report. It returns
+// an error only when the suite cannot run, the failed checks are reported.
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	if _, err := url.ParseRequestURI(cfg.Endpoint); err != nil {
+		return nil, fmt.Errorf("invalid endpoint: %w", err)
+	}
+	doc, err := contract.GetContract()
+	if err != nil {
+		return nil, fmt.Errorf("loading the provider contract: %w", err)
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	if cfg.CreateBody == nil {
+		cfg.CreateBody = map[string]interface{}{"name": "conformance-" + uuid.New().String()[:8]}
+	}
+
+	s := &suite{
+		cfg:      cfg,
+		doc:      doc,
+		endpoint: strings.TrimSuffix(cfg.Endpoint, "/"),
+		report: &Report{
+			Endpoint:   cfg.Endpoint,
+			Operations: normalize(cfg.Operations),
+			StartedAt:  time.Now().UTC(),
+		},
+	}
+	s.run(ctx)
+	s.report.Duration = time.Since(s.report.StartedAt)
+	return s.report, nil
+}
+
+// suite holds the state of a run, the ID of the created resource is shared by
+// the checks that follow the creation
+type suite struct {
+	cfg      Config
+	doc      *openapi3.T
+	endpoint string
+	report   *Report
+	id       string
+}
+
+func (s *suite) run(ctx context.Context) {
+	declared := func(operation string) bool { return slices.Contains(s.report.Operations, operation) }
+
+	s.check(ctx, "health", "", true, func(ctx context.Context) error {
+		return s.call(ctx, http.MethodGet, "/health", "/health", nil, nil, http.StatusOK)
+	})
+
+	created := s.check(ctx, "create", OperationCreate, declared(OperationCreate), func(ctx context.Context) error {
+		var resource struct {
+			ID string `json:"id"`
+		}
+		if err := s.call(ctx, http.MethodPost, "/", "/", nil, s.cfg.CreateBody, http.StatusCreated, &resource); err != nil {
+			return err
+		}
+		s.id = resource.ID
+		return nil
+	})
+
+	s.check(ctx, "read", OperationRead, declared(OperationRead) && created, func(ctx context.Context) error {
+		var resource struct {
+			ID string `json:"id"`
+		}
+		if err := s.call(ctx, http.MethodGet, "/{id}", "/"+url.PathEscape(s.id), map[string]string{"id": s.id}, nil, http.StatusOK, &resource); err != nil {
+			return err
+		}
+		if resource.ID != s.id {
+			return fmt.Errorf("read resource %q instead of %q", resource.ID, s.id)
+		}
+		return nil
+	})
+
+	s.check(ctx, "list", OperationList, declared(OperationList) && created, func(ctx context.Context) error {
+		var list struct {
+			Resources []struct {
+				ID string `json:"id"`
+			} `json:"resources"`
+		}
+		if err := s.call(ctx, http.MethodGet, "/", "/", nil, nil, http.StatusOK, &list); err != nil {
+			return err
+		}
+		for _, resource := range list.Resources {
+			if resource.ID == s.id {
+				return nil
+			}
+		}
+		return fmt.Errorf("created resource %q is not listed", s.id)
+	})
+
+	deleted := s.check(ctx, "delete", OperationDelete, declared(OperationDelete) && created, func(ctx context.Context) error {
+		return s.call(ctx, http.MethodDelete, "/{id}", "/"+url.PathEscape(s.id), map[string]string{"id": s.id}, nil, http.StatusNoContent)
+	})
+
+	s.check(ctx, "read after delete", OperationRead, declared(OperationRead) && deleted, func(ctx context.Context) error {
+		return s.call(ctx, http.MethodGet, "/{id}", "/"+url.PathEscape(s.id), map[string]string{"id": s.id}, nil, http.StatusNotFound)
+	})
+}
+
+// check runs a check, or reports it as skipped when run is false, and returns
+// whether it passed
+func (s *suite) check(ctx context.Context, name, operation string, run bool, fn func(ctx context.Context) error) bool {
+	result := Result{Name: name, Operation: operation, Status: StatusSkipped}
+	if !run {
+		result.Message = "depends on a failed or skipped check"
+		if operation != "" && !slices.Contains(s.report.Operations, operation) {
+			result.Message = fmt.Sprintf("operation %s is not declared", operation)
+		}
+		s.report.Results = append(s.report.Results, result)
+		return false
+	}
+
+	start := time.Now()
+	err := fn(ctx)
+	result.Duration = time.Since(start)
+	result.Status = StatusPassed
+	if err != nil {
+		result.Status = StatusFailed
+		result.Message = err.Error()
+	}
+	s.report.Results = append(s.report.Results, result)
+	return err == nil
+}
+
+// call sends a request to the provider, checks its status and validates the
+// response against the operation of the contract at path. The body is decoded
+// into out, if any.
+func (s *suite) call(ctx context.Context, method, path, requestPath string, pathParams map[string]string, body interface{}, status int, out ...interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.endpoint+strings.TrimSuffix(requestPath, "/"), reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading the response: %w", err)
+	}
+
+	if resp.StatusCode != status {
+		return fmt.Errorf("%s %s returned %d instead of %d: %s", method, requestPath, resp.StatusCode, status, truncate(bytes.TrimSpace(data)))
+	}
+	if err := s.validate(ctx, req, method, path, pathParams, resp, data); err != nil {
+		return fmt.Errorf("%s %s response does not match the contract: %w", method, requestPath, err)
+	}
+	for _, o := range out {
+		if err := json.Unmarshal(data, o); err != nil {
+			return fmt.Errorf("decoding the %s %s response: %w", method, requestPath, err)
+		}
+	}
+	return nil
+}
+
+func (s *suite) validate(ctx context.Context, req *http.Request, method, path string, pathParams map[string]string, resp *http.Response, data []byte) error {
+	pathItem := s.doc.Paths.Find(path)
+	if pathItem == nil || pathItem.GetOperation(method) == nil {
+		return fmt.Errorf("the contract has no %s %s operation", method, path)
+	}
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    req,
+			PathParams: pathParams,
+			Route: &routers.Route{
+				Spec:      s.doc,
+				Path:      path,
+				PathItem:  pathItem,
+				Method:    method,
+				Operation: pathItem.GetOperation(method),
+			},
+		},
+		Status:  resp.StatusCode,
+		Header:  resp.Header,
+		Options: &openapi3filter.Options{IncludeResponseStatus: true, MultiError: true},
+	}
+	input.SetBodyBytes(data)
+	return openapi3filter.ValidateResponse(ctx, input)
+}
+
+// normalize returns the upper case operations
+func normalize(operations []string) []string {
+	normalized := make([]string, 0, len(operations))
+	for _, operation := range operations {
+		if operation = strings.ToUpper(strings.TrimSpace(operation)); operation != "" {
+			normalized = append(normalized, operation)
+		}
+	}
+	return normalized
+}
+
+func truncate(data []byte) string {
+	const max = 512
+	if len(data) > max {
+		return string(data[:max]) + "..."
+	}
+	return string(data)
+}
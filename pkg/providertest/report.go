@@ -0,0 +1,131 @@
+package providertest
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Status is the outcome of a check
+type Status string
+
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+)
+
+// Result is the outcome of a check of the suite
+type Result struct {
+	Name string `json:"name"`
+	// Operation is the operation exercised by the check, empty for the checks
+	// of every provider such as health
+	Operation string        `json:"operation,omitempty"`
+	Status    Status        `json:"status"`
+	Message   string        `json:"message,omitempty"`
+	Duration  time.Duration `json:"duration_ns"`
+}
+
+// Report is the outcome of a run of the suite
+type Report struct {
+	Endpoint   string        `json:"endpoint"`
+	Operations []string      `json:"operations"`
+	StartedAt  time.Time     `json:"started_at"`
+	Duration   time.Duration `json:"duration_ns"`
+	Results    []Result      `json:"results"`
+}
+
+// Count returns the number of results with status
+func (r *Report) Count(status Status) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Passed reports whether no check failed. The checks of undeclared operations
+// are skipped and do not fail the report.
+func (r *Report) Passed() bool {
+	return r.Count(StatusFailed) == 0
+}
+
+// WriteJSON writes the report as indented JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnit writes the report as a JUnit XML test suite named after the
+// endpoint, with a test case per check
+func (r *Report) WriteJUnit(w io.Writer) error {
+	suite := junitTestSuite{
+		Name:      r.Endpoint,
+		Tests:     len(r.Results),
+		Failures:  r.Count(StatusFailed),
+		Skipped:   r.Count(StatusSkipped),
+		Time:      seconds(r.Duration),
+		Timestamp: r.StartedAt.Format(time.RFC3339),
+	}
+	for _, result := range r.Results {
+		testCase := junitTestCase{
+			Name:      result.Name,
+			ClassName: "providertest",
+			Time:      seconds(result.Duration),
+		}
+		switch result.Status {
+		case StatusFailed:
+			testCase.Failure = &junitMessage{Message: result.Message}
+		case StatusSkipped:
+			testCase.Skipped = &junitMessage{Message: result.Message}
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitTestSuites{Suites: []junitTestSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
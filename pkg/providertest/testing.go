@@ -0,0 +1,28 @@
+package providertest
+
+import (
+	"context"
+	"testing"
+)
+
+// Test runs the suite against the provider from a Go test, each check is
+// reported as a subtest. Providers can run it against an httptest.Server
+// serving their handler.
+func Test(t *testing.T, cfg Config) {
+	t.Helper()
+
+	report, err := Run(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("running the conformance suite: %v", err)
+	}
+	for _, result := range report.Results {
+		t.Run(result.Name, func(t *testing.T) {
+			switch result.Status {
+			case StatusFailed:
+				t.Error(result.Message)
+			case StatusSkipped:
+				t.Skip(result.Message)
+			}
+		})
+	}
+}
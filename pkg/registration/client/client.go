@@ -8,6 +8,8 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	apiclient "github.com/dcm-project/service-provider-api/pkg/client"
 )
 
 // Client for Resource Provider to register with DCM
@@ -73,6 +75,15 @@ type RegistrationResponse struct {
 	Message      string    `json:"message,omitempty"`
 }
 
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a context that makes Register send key as the
+// Idempotency-Key header. Retries of a registration that reuse the context
+// are then answered with the original response instead of registering again.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
 // Register sends a registration request to DCM
 func (c *Client) Register(ctx context.Context, resourceKind string, req *RegistrationRequest) (*RegistrationResponse, error) {
 	url := fmt.Sprintf("%s/resource/%s/provider", c.baseURL, resourceKind)
@@ -88,6 +99,9 @@ func (c *Client) Register(ctx context.Context, resourceKind string, req *Registr
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
+	if key, ok := ctx.Value(idempotencyKeyContextKey{}).(string); ok && key != "" {
+		httpReq.Header.Set("Idempotency-Key", key)
+	}
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
@@ -101,7 +115,7 @@ func (c *Client) Register(ctx context.Context, resourceKind string, req *Registr
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("registration failed with status %d: %s", resp.StatusCode, string(respBody))
+		return nil, fmt.Errorf("registration failed: %w", responseError(resp.StatusCode, respBody))
 	}
 
 	var regResp RegistrationResponse
@@ -129,7 +143,7 @@ func (c *Client) Unregister(ctx context.Context, resourceKind, providerID string
 
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("unregister failed with status %d: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("unregister failed: %w", responseError(resp.StatusCode, body))
 	}
 
 	return nil
@@ -152,7 +166,7 @@ func (c *Client) GetRegistration(ctx context.Context, resourceKind, serviceID st
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("get failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("get failed: %w", responseError(resp.StatusCode, body))
 	}
 
 	var regResp RegistrationResponse
@@ -162,3 +176,12 @@ func (c *Client) GetRegistration(ctx context.Context, resourceKind, serviceID st
 
 	return &regResp, nil
 }
+
+// responseError decodes the error envelope of an unexpected response into an
+// *apiclient.APIError, which can be matched with errors.Is and errors.As
+func responseError(statusCode int, body []byte) error {
+	if err := apiclient.CheckResponse(statusCode, body); err != nil {
+		return err
+	}
+	return fmt.Errorf("unexpected status %d: %s", statusCode, string(body))
+}
@@ -0,0 +1,84 @@
+package fake
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/pkg/registration"
+)
+
+// ExpectRegistered fails the test unless a service is registered for
+// resourceKind with exactly the operations, in any order. It returns the
+// registration.
+func (s *Server) ExpectRegistered(t testing.TB, resourceKind string, operations ...string) registration.RegisteredProvider {
+	t.Helper()
+
+	registrations := s.store.list(resourceKind)
+	if len(registrations) == 0 {
+		t.Fatalf("expected a registration for resource kind %s, got none", resourceKind)
+	}
+	want := sorted(operations)
+	for _, p := range registrations {
+		if slices.Equal(sorted(p.Operations), want) {
+			return p
+		}
+	}
+	t.Fatalf("expected a registration for resource kind %s with operations %v, got %v", resourceKind, want, registrations[0].Operations)
+	return registration.RegisteredProvider{}
+}
+
+// ExpectNotRegistered fails the test if a service is registered for
+// resourceKind
+func (s *Server) ExpectNotRegistered(t testing.TB, resourceKind string) {
+	t.Helper()
+
+	if registrations := s.store.list(resourceKind); len(registrations) > 0 {
+		t.Fatalf("expected no registration for resource kind %s, got service %s", resourceKind, registrations[0].ServiceID)
+	}
+}
+
+// RequestCount returns the number of requests received with method for
+// resourceKind, all resource kinds when empty, including the failed ones
+func (s *Server) RequestCount(method, resourceKind string) int {
+	n := 0
+	for _, request := range s.Requests() {
+		if request.Method == method && (resourceKind == "" || request.ResourceKind == resourceKind) {
+			n++
+		}
+	}
+	return n
+}
+
+// ExpectRegisterAttempts fails the test unless exactly n registration
+// requests were received for resourceKind
+func (s *Server) ExpectRegisterAttempts(t testing.TB, resourceKind string, n int) {
+	t.Helper()
+
+	if got := s.RequestCount(http.MethodPost, resourceKind); got != n {
+		t.Fatalf("expected %d registration requests for resource kind %s, got %d", n, resourceKind, got)
+	}
+}
+
+// WaitForRequests waits until at least n requests were received with method
+// for resourceKind, e.g. the re-registrations of a heartbeat, and fails the
+// test after timeout
+func (s *Server) WaitForRequests(t testing.TB, method, resourceKind string, n int, timeout time.Duration) {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for s.RequestCount(method, resourceKind) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d %s requests for resource kind %s within %s, got %d", n, method, resourceKind, timeout, s.RequestCount(method, resourceKind))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func sorted(values []string) []string {
+	values = slices.Clone(values)
+	sort.Strings(values)
+	return values
+}
@@ -0,0 +1,404 @@
+// Package fake provides an in-process DCM server implementing the
+// registration endpoints, for the unit tests of providers. Registrations are
+// held in memory and go through the registration.Handler of the real server,
+// so validation errors and etags behave the same. Like the registry of the
+// real server a service ID holds a single resource kind: registering it for
+// another kind replaces the previous registration. Failures and latency can
+// be injected to test the retries of the registration clients.
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dcm-project/service-provider-api/internal/api/server"
+	handlers "github.com/dcm-project/service-provider-api/internal/handlers/v1alpha1"
+	"github.com/dcm-project/service-provider-api/internal/store/model"
+	"github.com/dcm-project/service-provider-api/pkg/registration"
+	"github.com/go-chi/chi/v5"
+)
+
+// Request is a request received by the server
+type Request struct {
+	Method       string
+	Path         string
+	ResourceKind string
+	// ServiceID is the service ID of the path or of the registration body
+	ServiceID string
+	// Status is the status code of the response
+	Status int
+	// Injected reports whether the response was an injected failure
+	Injected bool
+}
+
+// Fault is a failure injected in the responses of the server
+type Fault struct {
+	// Method and ResourceKind restrict the requests the fault applies to,
+	// all when empty
+	Method       string
+	ResourceKind string
+
+	// Status is the status code returned instead of handling the request, the
+	// request is handled when 0, after Latency
+	Status int
+	// Code is the error code of the response, INTERNAL by default
+	Code string
+
+	// Latency delays the response
+	Latency time.Duration
+
+	// Times is the number of requests the fault applies to, all the following
+	// ones when 0
+	Times int
+}
+
+// Server is a fake DCM server, its URL is the base URL of the registration
+// clients
+type Server struct {
+	*httptest.Server
+
+	handler *registration.Handler
+	store   *memoryStore
+
+	mu       sync.Mutex
+	faults   []*Fault
+	requests []Request
+}
+
+// NewServer starts a fake DCM server, it is closed at the end of the test
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{store: &memoryStore{registrations: map[string]registration.RegisteredProvider{}}}
+	handler, err := registration.NewHandler(registration.Config{
+		RegistryStore: s.store,
+		CatalogStore:  s.store,
+	})
+	if err != nil {
+		t.Fatalf("creating the registration handler: %v", err)
+	}
+	s.handler = handler
+
+	r := chi.NewRouter()
+	r.Route("/resource/{resourceKind}/provider", func(r chi.Router) {
+		r.Post("/", s.register)
+		r.Get("/", s.list)
+		r.Get("/{providerId}", s.get)
+		r.Delete("/{providerId}", s.unregister)
+	})
+	s.Server = httptest.NewServer(s.record(r))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Inject adds a fault, the faults apply in the order they were added
+func (s *Server) Inject(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// FailNext makes the next n requests fail with status
+func (s *Server) FailNext(n int, status int) {
+	s.Inject(Fault{Status: status, Times: n})
+}
+
+// SetLatency delays all the following responses by latency
+func (s *Server) SetLatency(latency time.Duration) {
+	s.Inject(Fault{Latency: latency})
+}
+
+// ClearFaults removes the injected faults
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the requests received so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Registrations returns the current registrations, ordered by resource kind
+// and service ID
+func (s *Server) Registrations() []registration.RegisteredProvider {
+	return s.store.list("")
+}
+
+// Registration returns the registration of a service for a resource kind
+func (s *Server) Registration(resourceKind, serviceID string) (registration.RegisteredProvider, bool) {
+	p, err := s.store.GetProvider(context.Background(), serviceID, resourceKind)
+	if err != nil {
+		return registration.RegisteredProvider{}, false
+	}
+	return *p, true
+}
+
+// Register registers a service directly, e.g. to test updates
+func (s *Server) Register(ctx context.Context, resourceKind string, request server.RegistrationRequest) error {
+	_, err := s.handler.Register(ctx, request.ServiceId, resourceKind, request.Endpoint, request.Metadata, request.Operations)
+	return err
+}
+
+// fault returns the first fault applying to the request, consuming one of
+// its times
+func (s *Server) fault(method, resourceKind string) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, f := range s.faults {
+		if (f.Method != "" && f.Method != method) || (f.ResourceKind != "" && f.ResourceKind != resourceKind) {
+			continue
+		}
+		fault := *f
+		if f.Times > 0 {
+			if f.Times--; f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return &fault
+	}
+	return nil
+}
+
+// record applies the faults and records the requests
+func (s *Server) record(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := Request{Method: r.Method, Path: r.URL.Path}
+		request.ResourceKind, request.ServiceID = pathParams(r.URL.Path)
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		r = r.WithContext(context.WithValue(r.Context(), requestKey{}, &request))
+
+		if fault := s.fault(r.Method, request.ResourceKind); fault != nil {
+			if fault.Latency > 0 {
+				select {
+				case <-time.After(fault.Latency):
+				case <-r.Context().Done():
+				}
+			}
+			if fault.Status != 0 {
+				code := fault.Code
+				if code == "" {
+					code = handlers.ErrCodeInternal
+				}
+				request.Injected = true
+				writeError(sw, fault.Status, code, fmt.Sprintf("injected failure %d", fault.Status))
+				s.append(request, sw.status)
+				return
+			}
+		}
+
+		next.ServeHTTP(sw, r)
+		s.append(request, sw.status)
+	})
+}
+
+func (s *Server) append(request Request, status int) {
+	request.Status = status
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, request)
+}
+
+type requestKey struct{}
+
+// pathParams returns the resource kind and the service ID of the path of a
+// registration request
+func pathParams(path string) (resourceKind, serviceID string) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 3 || parts[0] != "resource" || parts[2] != "provider" {
+		return "", ""
+	}
+	if len(parts) > 3 {
+		serviceID = parts[3]
+	}
+	return parts[1], serviceID
+}
+
+func (s *Server) register(w http.ResponseWriter, r *http.Request) {
+	var body server.RegistrationRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, handlers.ErrCodeInvalidArgument, err.Error())
+		return
+	}
+	if request, ok := r.Context().Value(requestKey{}).(*Request); ok {
+		request.ServiceID = body.ServiceId
+	}
+
+	resp, err := s.handler.RegisterIfMatch(r.Context(), r.Header.Get("If-Match"), body.ServiceId, chi.URLParam(r, "resourceKind"), body.Endpoint, body.Metadata, body.Operations)
+	if err != nil {
+		writeRegistrationError(w, err)
+		return
+	}
+	w.Header().Set("ETag", *resp.Etag)
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	providers, err := s.handler.ListRegistrations(r.Context(), chi.URLParam(r, "resourceKind"))
+	if err != nil {
+		writeRegistrationError(w, err)
+		return
+	}
+	result := make([]server.RegisteredProvider, 0, len(providers))
+	for _, p := range providers {
+		result = append(result, toAPI(p))
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) get(w http.ResponseWriter, r *http.Request) {
+	p, err := s.handler.GetRegistration(r.Context(), chi.URLParam(r, "providerId"), chi.URLParam(r, "resourceKind"))
+	if err != nil {
+		writeRegistrationError(w, err)
+		return
+	}
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" && model.MatchETag(ifNoneMatch, p.ETag) {
+		w.Header().Set("ETag", p.ETag)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", p.ETag)
+	writeJSON(w, http.StatusOK, toAPI(*p))
+}
+
+func (s *Server) unregister(w http.ResponseWriter, r *http.Request) {
+	if err := s.handler.Unregister(r.Context(), chi.URLParam(r, "providerId"), chi.URLParam(r, "resourceKind")); err != nil {
+		writeRegistrationError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toAPI(p registration.RegisteredProvider) server.RegisteredProvider {
+	return server.RegisteredProvider{
+		ServiceId:    &p.ServiceID,
+		ResourceKind: &p.ResourceKind,
+		Endpoint:     &p.Endpoint,
+		Metadata:     &p.Metadata,
+		Operations:   &p.Operations,
+		CatalogItem:  &p.CatalogItem,
+		Status:       &p.Status,
+		RegisteredAt: &p.RegisteredAt,
+		UpdatedAt:    &p.UpdatedAt,
+		Etag:         &p.ETag,
+	}
+}
+
+// memoryStore implements the registry and the catalog of the handler. The
+// registrations are keyed by service ID, as in the database of the real
+// server.
+type memoryStore struct {
+	mu            sync.Mutex
+	registrations map[string]registration.RegisteredProvider
+	version       int64
+}
+
+func (m *memoryStore) UpsertProvider(ctx context.Context, provider registration.RegisteredProvider) (*registration.RegisteredProvider, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// The registration of another resource kind is replaced
+	if existing, ok := m.registrations[provider.ServiceID]; ok && provider.ETag != "" && existing.ETag != provider.ETag {
+		return nil, registration.ErrConflict
+	}
+	m.version++
+	provider.ETag = model.FormatETag(m.version)
+	provider.Status = "active"
+	m.registrations[provider.ServiceID] = provider
+	return &provider, nil
+}
+
+func (m *memoryStore) GetProvider(ctx context.Context, serviceID, resourceKind string) (*registration.RegisteredProvider, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := m.registrations[serviceID]
+	if !ok {
+		return nil, fmt.Errorf("service not found")
+	}
+	if p.ResourceKind != resourceKind {
+		return nil, fmt.Errorf("service not found for resource kind %s", resourceKind)
+	}
+	return &p, nil
+}
+
+func (m *memoryStore) DeleteProvider(ctx context.Context, serviceID, resourceKind string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if p, ok := m.registrations[serviceID]; ok && p.ResourceKind == resourceKind {
+		delete(m.registrations, serviceID)
+	}
+	return nil
+}
+
+func (m *memoryStore) ListProviders(ctx context.Context, resourceKind string) ([]registration.RegisteredProvider, error) {
+	return m.list(resourceKind), nil
+}
+
+// list returns the registrations of a resource kind, all when empty
+func (m *memoryStore) list(resourceKind string) []registration.RegisteredProvider {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	providers := []registration.RegisteredProvider{}
+	for _, p := range m.registrations {
+		if resourceKind == "" || p.ResourceKind == resourceKind {
+			providers = append(providers, p)
+		}
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		if providers[i].ResourceKind != providers[j].ResourceKind {
+			return providers[i].ResourceKind < providers[j].ResourceKind
+		}
+		return providers[i].ServiceID < providers[j].ServiceID
+	})
+	return providers
+}
+
+// The catalog mappings are derived from the registrations
+func (m *memoryStore) UpdateCatalogMapping(ctx context.Context, serviceID, resourceKind, catalogItem string) error {
+	return nil
+}
+
+func (m *memoryStore) RemoveCatalogMapping(ctx context.Context, serviceID, resourceKind string) error {
+	return nil
+}
+
+func writeRegistrationError(w http.ResponseWriter, err error) {
+	var regErr *registration.RegistrationError
+	if !errors.As(err, &regErr) {
+		writeError(w, http.StatusInternalServerError, handlers.ErrCodeInternal, err.Error())
+		return
+	}
+	writeError(w, handlers.RegistrationErrorStatus(regErr.Code), regErr.Code, regErr.Message)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, server.Error{Code: code, Message: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
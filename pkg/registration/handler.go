@@ -2,10 +2,13 @@ package registration
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/dcm-project/service-provider-api/internal/api/server"
+	"github.com/dcm-project/service-provider-api/internal/fieldmask"
+	"github.com/dcm-project/service-provider-api/internal/store/model"
 )
 
 // RegistrationError represents errors that occur during provider registration
@@ -33,6 +36,21 @@ const (
 	ErrCodeRegistryUpdate      = "REGISTRY_UPDATE_FAILED"
 	ErrCodeCatalogUpdate       = "CATALOG_UPDATE_FAILED"
 	ErrCodeEndpointUnreachable = "ENDPOINT_UNREACHABLE"
+	ErrCodePreconditionFailed  = "PRECONDITION_FAILED"
+	ErrCodeConflict            = "REGISTRATION_CONFLICT"
+	// ErrCodeInvalidArgument is returned for requests that cannot be applied
+	// as given, such as an invalid update mask, like the provider API does
+	ErrCodeInvalidArgument = "INVALID_ARGUMENT"
+)
+
+var (
+	// ErrConflict is returned by a RegistryStore when a registration was modified
+	// concurrently and the update was rejected
+	ErrConflict = errors.New("registration was modified concurrently")
+
+	// ErrAlreadyRegistered is returned by a RegistryStore when the service is
+	// already registered and cannot be registered again
+	ErrAlreadyRegistered = errors.New("service is already registered")
 )
 
 // RegisteredProvider represents a service registered in the Resource Registry (domain model)
@@ -47,13 +65,17 @@ type RegisteredProvider struct {
 	Status       string
 	RegisteredAt time.Time
 	UpdatedAt    time.Time
+	// ETag identifies the version of the registration. When set on an upsert
+	// the store must reject the update if the stored version differs.
+	ETag string
 }
 
 // RegistryStore interface for Resource Registry operations
 // This allows the package to be agnostic of the actual storage implementation
 type RegistryStore interface {
 	// UpsertProvider creates or updates a provider registration in the Resource Registry
-	UpsertProvider(ctx context.Context, provider RegisteredProvider) error
+	// and returns the stored registration
+	UpsertProvider(ctx context.Context, provider RegisteredProvider) (*RegisteredProvider, error)
 
 	// GetProvider retrieves a service by ID and resource kind
 	GetProvider(ctx context.Context, serviceID, resourceKind string) (*RegisteredProvider, error)
@@ -81,18 +103,39 @@ type EndpointChecker interface {
 	CheckEndpoint(ctx context.Context, endpoint string) error
 }
 
+// Operations reported to a Recorder
+const (
+	OperationRegister   = "register"
+	OperationUnregister = "unregister"
+)
+
+// OutcomeOK is the code reported to a Recorder for successful operations
+const OutcomeOK = "OK"
+
+// Recorder records the outcome of registration operations, e.g. as metrics
+type Recorder interface {
+	// RecordOutcome is called once per operation with OutcomeOK or the code of
+	// the RegistrationError returned by the operation
+	RecordOutcome(operation, code string)
+}
+
 // Handler handles Resource Provider registration requests
 type Handler struct {
 	registryStore   RegistryStore
 	catalogStore    CatalogStore
 	validator       *Validator
 	endpointChecker EndpointChecker
+	recorder        Recorder
 }
 
 func newValidationError(message string, err error) *RegistrationError {
 	return &RegistrationError{Code: ErrCodeValidation, Message: message, Err: err}
 }
 
+func newInvalidArgumentError(message string, err error) *RegistrationError {
+	return &RegistrationError{Code: ErrCodeInvalidArgument, Message: message, Err: err}
+}
+
 func newRegistryUpdateError(message string, err error) *RegistrationError {
 	return &RegistrationError{Code: ErrCodeRegistryUpdate, Message: message, Err: err}
 }
@@ -101,6 +144,14 @@ func newCatalogUpdateError(message string, err error) *RegistrationError {
 	return &RegistrationError{Code: ErrCodeCatalogUpdate, Message: message, Err: err}
 }
 
+func newPreconditionFailedError(message string, err error) *RegistrationError {
+	return &RegistrationError{Code: ErrCodePreconditionFailed, Message: message, Err: err}
+}
+
+func newConflictError(message string, err error) *RegistrationError {
+	return &RegistrationError{Code: ErrCodeConflict, Message: message, Err: err}
+}
+
 func newEndpointUnreachableError(endpoint string, err error) *RegistrationError {
 	return &RegistrationError{
 		Code:    ErrCodeEndpointUnreachable,
@@ -115,6 +166,8 @@ type Config struct {
 	CatalogStore    CatalogStore
 	Validator       *Validator
 	EndpointChecker EndpointChecker
+	// Recorder is optional and records the outcome of Register and Unregister
+	Recorder Recorder
 }
 
 // NewHandler creates a new registration handler
@@ -136,12 +189,51 @@ func NewHandler(cfg Config) (*Handler, error) {
 		catalogStore:    cfg.CatalogStore,
 		validator:       validator,
 		endpointChecker: cfg.EndpointChecker,
+		recorder:        cfg.Recorder,
 	}, nil
 }
 
+// WithStores returns a copy of the handler using the given stores, e.g. the
+// ones of a transaction
+func (h *Handler) WithStores(registryStore RegistryStore, catalogStore CatalogStore) *Handler {
+	handler := *h
+	handler.registryStore = registryStore
+	handler.catalogStore = catalogStore
+	return &handler
+}
+
+// record reports the outcome of operation to the recorder, if any
+func (h *Handler) record(operation string, err error) {
+	if h.recorder == nil {
+		return
+	}
+	code := OutcomeOK
+	if err != nil {
+		code = ErrCodeRegistryUpdate
+		var regErr *RegistrationError
+		if errors.As(err, &regErr) {
+			code = regErr.Code
+		}
+	}
+	h.recorder.RecordOutcome(operation, code)
+}
+
 // Register handles a provider registration request
 // This implements the idempotent registration flow described in the ADR
 func (h *Handler) Register(ctx context.Context, serviceID, resourceKind, endpoint string, metadata server.ProviderMetadata, operations []string) (*server.RegistrationResponse, error) {
+	return h.RegisterIfMatch(ctx, "", serviceID, resourceKind, endpoint, metadata, operations)
+}
+
+// RegisterIfMatch handles a conditional provider registration request.
+// When ifMatch is not empty the registration must already exist and its etag
+// must match, otherwise a PRECONDITION_FAILED error is returned.
+func (h *Handler) RegisterIfMatch(ctx context.Context, ifMatch, serviceID, resourceKind, endpoint string, metadata server.ProviderMetadata, operations []string) (*server.RegistrationResponse, error) {
+	resp, err := h.registerIfMatch(ctx, ifMatch, serviceID, resourceKind, endpoint, metadata, operations)
+	h.record(OperationRegister, err)
+	return resp, err
+}
+
+func (h *Handler) registerIfMatch(ctx context.Context, ifMatch, serviceID, resourceKind, endpoint string, metadata server.ProviderMetadata, operations []string) (*server.RegistrationResponse, error) {
 	// 1. Validate the request
 	if err := h.validator.ValidateRegistration(serviceID, resourceKind, endpoint, metadata, operations); err != nil {
 		return nil, err
@@ -158,6 +250,10 @@ func (h *Handler) Register(ctx context.Context, serviceID, resourceKind, endpoin
 	existingProvider, err := h.registryStore.GetProvider(ctx, serviceID, resourceKind)
 	isUpdate := err == nil && existingProvider != nil
 
+	if ifMatch != "" && (!isUpdate || !model.MatchETag(ifMatch, existingProvider.ETag)) {
+		return nil, newPreconditionFailedError("registration etag does not match If-Match", nil)
+	}
+
 	// 4. Create or update service in Resource Registry
 	now := time.Now()
 
@@ -176,12 +272,20 @@ func (h *Handler) Register(ctx context.Context, serviceID, resourceKind, endpoin
 		UpdatedAt:    now,
 	}
 
-	// Preserve original registration time if updating
+	// Preserve original registration time and guard against concurrent updates
 	if isUpdate {
 		registeredProvider.RegisteredAt = existingProvider.RegisteredAt
+		registeredProvider.ETag = existingProvider.ETag
 	}
 
-	if err := h.registryStore.UpsertProvider(ctx, registeredProvider); err != nil {
+	storedProvider, err := h.registryStore.UpsertProvider(ctx, registeredProvider)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrConflict):
+			return nil, newPreconditionFailedError("registration was modified concurrently", err)
+		case errors.Is(err, ErrAlreadyRegistered):
+			return nil, newConflictError(fmt.Sprintf("Service %s is already registered", serviceID), err)
+		}
 		return nil, newRegistryUpdateError("failed to update Resource Registry", err)
 	}
 
@@ -205,11 +309,144 @@ func (h *Handler) Register(ctx context.Context, serviceID, resourceKind, endpoin
 		Status:       &status,
 		RegisteredAt: &registeredProvider.RegisteredAt,
 		Message:      &message,
+		Etag:         &storedProvider.ETag,
 	}, nil
 }
 
+var (
+	registrationMutableFields = []string{
+		"endpoint", "operations", "metadata",
+		"metadata.zone", "metadata.region", "metadata.labels", "metadata.resource_constraints",
+	}
+	registrationImmutableFields = []string{"service_id", "resource_kind"}
+)
+
+// PatchRegistration updates the fields of an existing registration selected by
+// updateMask. Without an update mask all fields present in the patch are
+// updated. The merged registration is validated like a new registration.
+func (h *Handler) PatchRegistration(ctx context.Context, ifMatch, serviceID, resourceKind string, patch server.RegistrationPatch, updateMask string) (*RegisteredProvider, error) {
+	existing, err := h.GetRegistration(ctx, serviceID, resourceKind)
+	if err != nil {
+		return nil, err
+	}
+
+	if ifMatch != "" && !model.MatchETag(ifMatch, existing.ETag) {
+		return nil, newPreconditionFailedError("registration etag does not match If-Match", nil)
+	}
+
+	mask := fieldmask.Parse(updateMask)
+	if len(mask) == 0 {
+		mask = registrationPatchMask(patch, *existing)
+	}
+	if err := mask.Validate(registrationMutableFields, registrationImmutableFields); err != nil {
+		return nil, newInvalidArgumentError("invalid update_mask", err)
+	}
+
+	patched := *existing
+	metadataPatch := server.ProviderMetadataPatch{}
+	if patch.Metadata != nil {
+		metadataPatch = *patch.Metadata
+	}
+	if mask.Contains("endpoint") {
+		patched.Endpoint = ""
+		if patch.Endpoint != nil {
+			patched.Endpoint = *patch.Endpoint
+		}
+	}
+	if mask.Contains("operations") {
+		patched.Operations = nil
+		if patch.Operations != nil {
+			patched.Operations = *patch.Operations
+		}
+	}
+	if mask.Contains("metadata.zone") {
+		patched.Metadata.Zone = ""
+		if metadataPatch.Zone != nil {
+			patched.Metadata.Zone = *metadataPatch.Zone
+		}
+	}
+	if mask.Contains("metadata.region") {
+		patched.Metadata.Region = ""
+		if metadataPatch.Region != nil {
+			patched.Metadata.Region = *metadataPatch.Region
+		}
+	}
+	if mask.Contains("metadata.labels") {
+		patched.Metadata.Labels = metadataPatch.Labels
+	}
+	if mask.Contains("metadata.resource_constraints") {
+		patched.Metadata.ResourceConstraints = metadataPatch.ResourceConstraints
+	}
+
+	if err := h.validator.ValidateRegistration(serviceID, resourceKind, patched.Endpoint, patched.Metadata, patched.Operations); err != nil {
+		return nil, err
+	}
+
+	if h.endpointChecker != nil && patched.Endpoint != existing.Endpoint {
+		if err := h.endpointChecker.CheckEndpoint(ctx, patched.Endpoint); err != nil {
+			return nil, newEndpointUnreachableError(patched.Endpoint, err)
+		}
+	}
+
+	patched.UpdatedAt = time.Now()
+	stored, err := h.registryStore.UpsertProvider(ctx, patched)
+	if err != nil {
+		if errors.Is(err, ErrConflict) {
+			return nil, newPreconditionFailedError("registration was modified concurrently", err)
+		}
+		return nil, newRegistryUpdateError("failed to update Resource Registry", err)
+	}
+
+	// Catalog item is derived from resource kind, the mapping holds the endpoint
+	if err := h.catalogStore.UpdateCatalogMapping(ctx, serviceID, resourceKind, resourceKind); err != nil {
+		return nil, newCatalogUpdateError("failed to update Service Catalog", err)
+	}
+
+	stored.CatalogItem = resourceKind
+	return stored, nil
+}
+
+// registrationPatchMask returns the implicit update mask of a patch, holding
+// every field present in the patch and any immutable field that differs
+func registrationPatchMask(patch server.RegistrationPatch, existing RegisteredProvider) fieldmask.Mask {
+	var mask fieldmask.Mask
+	if patch.ServiceId != nil && *patch.ServiceId != existing.ServiceID {
+		mask = append(mask, "service_id")
+	}
+	if patch.ResourceKind != nil && *patch.ResourceKind != existing.ResourceKind {
+		mask = append(mask, "resource_kind")
+	}
+	if patch.Endpoint != nil {
+		mask = append(mask, "endpoint")
+	}
+	if patch.Operations != nil {
+		mask = append(mask, "operations")
+	}
+	if patch.Metadata != nil {
+		if patch.Metadata.Zone != nil {
+			mask = append(mask, "metadata.zone")
+		}
+		if patch.Metadata.Region != nil {
+			mask = append(mask, "metadata.region")
+		}
+		if patch.Metadata.Labels != nil {
+			mask = append(mask, "metadata.labels")
+		}
+		if patch.Metadata.ResourceConstraints != nil {
+			mask = append(mask, "metadata.resource_constraints")
+		}
+	}
+	return mask
+}
+
 // Unregister removes a service registration
 func (h *Handler) Unregister(ctx context.Context, serviceID, resourceKind string) error {
+	err := h.unregister(ctx, serviceID, resourceKind)
+	h.record(OperationUnregister, err)
+	return err
+}
+
+func (h *Handler) unregister(ctx context.Context, serviceID, resourceKind string) error {
 	// 1. Validate inputs
 	if serviceID == "" {
 		return newValidationError("serviceID is required", nil)
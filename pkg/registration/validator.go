@@ -8,6 +8,10 @@ import (
 	"github.com/google/uuid"
 )
 
+// BuiltinScheme is the endpoint scheme reserved for the providers running in
+// the API server, their endpoints are builtin://<name>
+const BuiltinScheme = "builtin"
+
 // Validator validates registration requests
 type Validator struct{}
 
@@ -70,8 +74,8 @@ func (v *Validator) validateEndpoint(endpoint string) error {
 		return fmt.Errorf("endpoint must be a valid URL: %w", err)
 	}
 
-	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
-		return fmt.Errorf("endpoint must use http or https scheme")
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" && parsedURL.Scheme != BuiltinScheme {
+		return fmt.Errorf("endpoint must use http, https or %s scheme", BuiltinScheme)
 	}
 
 	if parsedURL.Host == "" {